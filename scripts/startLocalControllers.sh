@@ -0,0 +1,13 @@
+#!/bin/bash
+
+# Starts a highly available group of three controllers on this machine. Run from the src directory after make
+# build. Storage nodes reach them on ports 23000, 24000 and 25000, clients on 23003, 24003 and 25003.
+# They reach each other on 23101, 23201 and 23301, clear of the port 23100 storage nodes use between them.
+
+CONTROLLERS=localhost:23101,localhost:23201,localhost:23301
+
+./controllerExec 23000 23003 metadata1/ random - localhost:23101 $CONTROLLERS &
+./controllerExec 24000 24003 metadata2/ random - localhost:23201 $CONTROLLERS &
+./controllerExec 25000 25003 metadata3/ random - localhost:23301 $CONTROLLERS &
+
+echo "Spawned processes in the background."
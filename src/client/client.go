@@ -1,177 +1,186 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go.uber.org/zap"
-	"net"
-	"os"
-	"src/file"
-	messages "src/messages/controller_client"
-	proto3 "src/proto/controller_client"
+	"src/dfs"
 	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 )
 
-type Client struct {
-	serverPort string
-	file       *file.FileHandler
-	logger     *zap.Logger
-	conn       net.Conn
-	proto      *proto3.ProtoHandler
-	msgHandler *messages.MessageHandler
-}
-
-type File struct {
-	name      string
-	size      int64
-	chunkSize int64
-	checkSum  [32]byte
-}
+const (
+	//fragments are kept in file_dir and combined once all of them arrived
+	OUTPUT_FRAGMENTS = ""
+	//fragments are written straight to their place in the output file
+	OUTPUT_FILE = "file"
+	//the file is written to stdout in order
+	OUTPUT_STDOUT = "stdout"
+)
 
-func NewClient(serverPort string, logger *zap.Logger) (client *Client) {
+func newClient(controller Address, logger *zap.Logger) *dfs.Client {
 
-	client = &Client{
-		serverPort: serverPort,
-		logger:     logger,
+	controllers := controller.Host + ":" + controller.Port
+	for _, standby := range controller.Standbys {
+		controllers += "," + standby.Host + ":" + standby.Port
 	}
-
-	return
+	return dfs.NewClient(controllers, logger)
 }
 
-func (c *Client) SetFileHandler(handler *file.FileHandler) {
-	c.file = handler
+func put(ctx context.Context, input *inputPUTYaml, logger *zap.Logger) (err error) {
 
-}
-func (c *Client) Disconnect() {
-	c.conn.Close()
-}
-func (c *Client) Conn(conn net.Conn) {
-	c.conn = conn
-}
+	client := newClient(input.Controller, logger)
+	client.SetChunkSize(input.ChunkSize)
+	client.SetMinReplicas(input.MinReplicas)
+	client.SetReplicationFactor(input.ReplicationFactor)
+	client.SetErasureCoding(input.ErasureCoding.DataShards, input.ErasureCoding.ParityShards)
+	client.SetConcurrency(input.Concurrency, input.NodeConcurrency)
+	client.SetTimeouts(input.Timeouts.durations())
 
-func (c *Client) MsgHandler(handler *messages.MessageHandler) {
-	c.msgHandler = handler
+	return client.PutFile(ctx, input.FileDir, input.InputFile)
 }
 
-func (c *Client) Proto(proto *proto3.ProtoHandler) {
-	c.proto = proto
-}
+func get(ctx context.Context, input *inputGETYaml, logger *zap.Logger) (err error) {
 
-func (c *Client) Dial() (err error) {
-	server := c.serverPort
+	client := newClient(input.Controller, logger)
+	client.SetTimeouts(input.Timeouts.durations())
+	r := dfs.Range{Offset: input.Offset, Length: input.Length}
 
-	conn, err := net.Dial("tcp", server)
+	switch {
+	case input.Output == OUTPUT_STDOUT:
+		return client.GetRange(ctx, input.InputFile, r, stdout)
 
-	if err != nil {
-		c.logger.Fatal("There was an error connecting to the host.")
-		panic("There was an error connecting to the host.")
+	case input.Output == OUTPUT_FILE || r != dfs.Range{}:
+		var path string
+		path, err = client.GetFile(ctx, input.FileDir, input.InputFile, r)
+		if err == nil {
+			logger.Info("File fetched", zap.String("file", path))
+		}
 		return
-	}
-	msgHandler := messages.NewMessageHandler(conn)
-	c.MsgHandler(msgHandler)
-	proto := proto3.NewProtoHandler(msgHandler, c.logger)
-	c.Proto(proto)
-	c.Conn(conn)
 
-	return
+	case input.Output == OUTPUT_FRAGMENTS:
+		return client.Download(ctx, input.FileDir, input.InputFile)
+	}
+	return fmt.Errorf("unknown output %q, use %q or %q", input.Output, OUTPUT_FILE, OUTPUT_STDOUT)
 }
 
-func (c *Client) HandleConnection() (err error) {
-	for {
-		wrapper, _ := c.proto.MsgHandler().ControllerResponseReceive()
-
-		switch wrapper.ControllerMessage.(type) {
-		default:
-			res := c.proto.HandleControllerResponse(wrapper)
-
-			switch res.GetResType() {
-			case "PlanResponse":
-				if res.(*proto3.PlanResponse).StatusCode == "OK" {
-					c.DispatchFile(res)
-				} else {
-					fmt.Println("Error: ", res.(*proto3.PlanResponse).StatusCode)
-				}
-
-			case "FragmentLayoutResponse":
-				if res.(*proto3.FragLayoutResponse).StatusCode == "OK" {
-					c.FetchFile(res)
-				} else {
-					fmt.Println("Error: ", res.(*proto3.FragLayoutResponse).StatusCode)
-				}
-
-			case "LsResponse":
-				if res.(*proto3.LsResponse).StatusCode == "OK" {
-					c.PrintFiles(res)
-					return
-				} else {
-					fmt.Println("Error: ", res.(*proto3.LsResponse).StatusCode)
-				}
-
-			case "NodeStats":
-				if res.(*proto3.NodeStats).StatusCode == "OK" {
-					c.PrintNodeStats(res)
-					return
-				} else {
-					fmt.Println("Error: ", res.(*proto3.NodeStats).StatusCode)
-				}
-			}
-		case nil:
-			return
+// printFiles prints a page of a listing as a table, then the token of the next page if there is one.
+func printFiles(entries []dfs.Entry, nextPageToken string) {
 
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSIZE\tFRAGMENTS\tSTATUS")
+	for _, entry := range entries {
+
+		//directories are told apart by a trailing slash
+		if entry.Dir {
+			fmt.Fprintf(w, "%s/\t-\t-\t-\n", entry.Name)
+			continue
+		}
+		status := "OK"
+		if entry.UnderReplicated {
+			status = "UNDER_REPLICATED"
 		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", entry.Name, entry.Size, entry.Fragments, status)
 	}
-}
-
-func (c *Client) HandlePUT(file *file.FileHandler, fragSize int64) (err error) {
+	w.Flush()
 
-	c.file = file
-	c.proto.HandlePutRequest(file.FileName(), file.FileSize(), fragSize)
-	return
+	if nextPageToken != "" {
+		fmt.Fprintln(stdout, "Next page token: "+nextPageToken)
+	}
 }
 
-func (c *Client) HandleGET(file string) {
-
-	c.logger.Info("Handling GET request")
-	c.proto.HandleGetRequest(file)
-
+type listingJSON struct {
+	Entries       []entryJSON `json:"entries"`
+	NextPageToken string      `json:"next_page_token,omitempty"`
 }
 
-func (c *Client) PrintFiles(res proto3.ResponseInterface) {
-
-	c.logger.Info("Files present in the DFS:")
-	for _, file := range res.(*proto3.LsResponse).Files {
-
-		c.logger.Info(file)
-
-	}
-
-	os.Exit(0)
-
+type entryJSON struct {
+	Name            string `json:"name"`
+	Dir             bool   `json:"dir"`
+	Size            int64  `json:"size,omitempty"`
+	Fragments       int    `json:"fragments,omitempty"`
+	UnderReplicated bool   `json:"under_replicated,omitempty"`
 }
 
-func (c *Client) HandleListFiles() {
+// printFilesJSON prints a page of a listing and the token of the next page as one JSON object.
+func printFilesJSON(entries []dfs.Entry, nextPageToken string) error {
 
-	c.proto.HandleLsRequest()
+	listing := listingJSON{Entries: make([]entryJSON, 0, len(entries)), NextPageToken: nextPageToken}
+	for _, entry := range entries {
+		listing.Entries = append(listing.Entries, entryJSON(entry))
+	}
 
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(listing)
 }
 
-func (c *Client) PrintNodeStats(res proto3.ResponseInterface) {
+func printNodeStats(logger *zap.Logger, nodes []dfs.NodeStat) {
 
-	c.logger.Info("Node Stats:")
-	for _, node := range res.(*proto3.NodeStats).Nodes {
+	logger.Info("Node Stats:")
+	for _, node := range nodes {
 
-		c.logger.Info("Node Id:" + node.NodeId)
-		c.logger.Info("Free space:" + strconv.FormatInt(node.DiskSpace, 10))
+		logger.Info("Node Id:" + node.NodeId)
+		logger.Info("Free space:" + strconv.FormatInt(node.DiskSpace, 10))
 
 		fmt.Println()
 		fmt.Println()
 	}
+}
 
-	os.Exit(0)
+// printStat prints what the Controller knows about a file, and a line for every fragment with where it is.
+func printStat(info *dfs.FileInfo) {
 
+	fmt.Println("File:               " + info.Name)
+	fmt.Println("Size:               " + strconv.FormatInt(info.Size, 10))
+	fmt.Println("Chunk size:         " + strconv.FormatInt(info.ChunkSize, 10))
+	fmt.Println("Fragments:          " + strconv.Itoa(info.Fragments))
+	if info.Erasure != nil {
+		fmt.Printf("Erasure coding:     %d data + %d parity shards\n", info.Erasure.DataShards, info.Erasure.ParityShards)
+	} else {
+		fmt.Println("Replication factor: " + strconv.Itoa(info.ReplicationFactor))
+	}
+	if !info.Created.IsZero() {
+		fmt.Println("Created:            " + info.Created.Format(time.RFC3339))
+	}
+	fmt.Println("Owner:              " + info.Owner)
+	fmt.Println("Checksum (SHA-256): " + info.Checksum)
+
+	fmt.Println()
+	for _, frag := range info.Layout {
+		nodes := make([]string, 0, len(frag.Replicas))
+		for _, replica := range frag.Replicas {
+			nodes = append(nodes, replica.NodeId+" ("+replica.Addr+")")
+		}
+		fmt.Printf("%4d %s %d bytes %s: %s\n", frag.Index, frag.Id, frag.Size, frag.Health, strings.Join(nodes, ", "))
+	}
 }
 
-func (c *Client) HandleNodeStats() {
+// printError reports why a command failed, with the fragments or replicas that failed when there are some.
+func printError(err error) {
 
-	c.proto.HandleNodeStatsRequest()
+	var dispatchErr *dfs.DispatchError
+	var deleteErr *dfs.DeleteError
 
+	switch {
+	case errors.As(err, &dispatchErr):
+		fmt.Println("Error: " + dispatchErr.Error())
+		for _, frag := range dispatchErr.Fragments() {
+			fmt.Println(frag + ": " + dispatchErr.Failed[frag].Error())
+		}
+
+	case errors.As(err, &deleteErr):
+		fmt.Println("Error: ", deleteErr.Status)
+		fmt.Println("Replicas that could not be deleted:")
+		for _, replica := range deleteErr.Failed {
+			fmt.Println("Fragment: " + replica.FragmentId + " Node Id: " + replica.NodeId + " Host: " + replica.Addr)
+		}
+
+	default:
+		fmt.Println("Error: ", err)
+	}
 }
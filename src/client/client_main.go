@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"os"
-	FileHandler "src/file"
+	"os/signal"
+	"src/dfs"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	//	FileHandler "src/file"
 )
 
+// stdout is where a GET with output stdout writes the file. os.Stdout is pointed at stderr for it.
+var stdout = os.Stdout
+
 func main() {
 
 	inputType, err := parseArgs(os.Args)
@@ -23,71 +31,92 @@ func main() {
 	logger := appendLogger()
 	defer logger.Sync()
 
-	switch inputType.(type) {
-	case *inputPUTYaml:
+	//an interrupted PUT or GET stops its transfers and keeps its journal, so it can be resumed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
+	switch input := inputType.(type) {
+	case *inputPUTYaml:
 		fmt.Println("PUT")
-		putInput := inputType.(*inputPUTYaml)
-		chunkSize := putInput.ChunkSize
-		_ = putInput.FileDir
-
-		addr := putInput.Controller.Host + ":" + putInput.Controller.Port
-		client := NewClient(addr, logger)
-		client.Dial()
-
-		fileName := putInput.InputFile
-		fileHandler := FileHandler.NewFileHandler(fileName)
-		fileHandler.SetDir(putInput.FileDir)
-		fileHandler.CalcFileSize()
-		if err != nil {
-			logger.Error("Error reading file", zap.Error(err))
-			return
-		}
-		client.HandlePUT(fileHandler, chunkSize)
-		client.HandleConnection()
+		err = put(ctx, input, logger)
 
 	case *inputGETYaml:
 		fmt.Println("GET")
-		getInput := inputType.(*inputGETYaml)
-		_ = getInput.FileDir
-
-		addr := getInput.Controller.Host + ":" + getInput.Controller.Port
-		client := NewClient(addr, logger)
-		client.Dial()
-		fileHandler := FileHandler.NewFileHandler(getInput.InputFile)
-		fileHandler.SetDir(getInput.FileDir)
-		fileName := getInput.InputFile
-		client.SetFileHandler(fileHandler)
-		client.HandleGET(fileName)
-		client.HandleConnection()
+		err = get(ctx, input, logger)
 
 	case *inputListFilesYaml:
-		fmt.Println("List Files")
-		listFilesInput := inputType.(*inputListFilesYaml)
-
-		addr := listFilesInput.Controller.Host + ":" + listFilesInput.Controller.Port
-		client := NewClient(addr, logger)
-		client.Dial()
-		client.HandleListFiles()
-		client.HandleConnection()
+		//the JSON listing is all that is printed, so it can be piped
+		if !input.JSON {
+			fmt.Println("List Files")
+		}
+		var entries []dfs.Entry
+		var next string
+		entries, next, err = newClient(input.Controller, logger).ListPage(ctx, input.Dir, dfs.ListOptions{
+			Pattern:   input.Pattern,
+			PageToken: input.PageToken,
+			Limit:     input.Limit,
+		})
+		if err == nil && input.JSON {
+			err = printFilesJSON(entries, next)
+		} else if err == nil {
+			printFiles(entries, next)
+		}
 
 	case *inputNodeStatsYaml:
 		fmt.Println("Node Stats")
-		nodeStatsInput := inputType.(*inputNodeStatsYaml)
+		var nodes []dfs.NodeStat
+		nodes, err = newClient(input.Controller, logger).NodeStats(ctx)
+		if err == nil {
+			printNodeStats(logger, nodes)
+		}
+
+	case *inputDeleteYaml:
+		fmt.Println("Delete")
+		err = newClient(input.Controller, logger).Delete(ctx, input.FileName)
+		if err == nil {
+			logger.Info("File deleted from the DFS")
+		}
+
+	case *inputStatYaml:
+		fmt.Println("Stat")
+		var info *dfs.FileInfo
+		info, err = newClient(input.Controller, logger).Stat(ctx, input.FileName)
+		if err == nil {
+			printStat(info)
+		}
+
+	case *inputMkdirYaml:
+		fmt.Println("Mkdir")
+		err = newClient(input.Controller, logger).Mkdir(ctx, input.Dir)
+		if err == nil {
+			logger.Info("Directory created")
+		}
+
+	case *inputRmdirYaml:
+		fmt.Println("Rmdir")
+		err = newClient(input.Controller, logger).Rmdir(ctx, input.Dir)
+		if err == nil {
+			logger.Info("Directory removed")
+		}
 
-		addr := nodeStatsInput.Controller.Host + ":" + nodeStatsInput.Controller.Port
-		client := NewClient(addr, logger)
-		client.Dial()
-		client.HandleNodeStats()
-		client.HandleConnection()
+	case *inputRenameYaml:
+		fmt.Println("Rename")
+		err = newClient(input.Controller, logger).Rename(ctx, input.From, input.To)
+		if err == nil {
+			logger.Info("Renamed " + input.From + " to " + input.To)
+		}
 
 	case nil:
 		fmt.Println("No input type specified")
 		return
 	}
 
-	select {}
-
+	if err != nil {
+		printError(err)
+		logger.Sync()
+		stop()
+		os.Exit(1)
+	}
 }
 
 type InputInterface interface {
@@ -96,6 +125,29 @@ type InputInterface interface {
 type Address struct {
 	Host string `yaml:"host"`
 	Port string `yaml:"port"`
+	//the other controllers of a highly available group, tried in turn when this one is not active
+	Standbys []Address `yaml:"standbys,omitempty"`
+}
+
+// parseController reads the host:port of the Controller, or of every controller of a highly available group
+// separated by commas.
+func parseController(hostPorts string) (controller Address, err error) {
+
+	for i, hostPort := range strings.Split(hostPorts, ",") {
+		//split host and port
+		hostPortSplit := strings.Split(hostPort, ":")
+		if len(hostPortSplit) != 2 {
+			return Address{}, fmt.Errorf("invalid host:port format")
+		}
+
+		address := Address{Host: hostPortSplit[0], Port: hostPortSplit[1]}
+		if i == 0 {
+			controller = address
+		} else {
+			controller.Standbys = append(controller.Standbys, address)
+		}
+	}
+	return
 }
 
 type Job struct {
@@ -109,6 +161,14 @@ type inputGETYaml struct {
 	Controller Address `yaml:"controller"`
 	InputFile  string  `yaml:"input_file"`
 	FileDir    string  `yaml:"file_dir"`
+	// Reads only bytes [offset, offset + length) of the file when set. A length of 0 reads to the end
+	Offset int64 `yaml:"offset,omitempty"`
+	Length int64 `yaml:"length,omitempty"`
+	// "file" writes fragments straight into the output file, "stdout" streams the file to stdout. Left empty,
+	// fragments are kept in file_dir and combined once all of them arrived
+	Output string `yaml:"output,omitempty"`
+	// Deadlines on connecting to and talking with the Controller and the nodes
+	Timeouts Timeouts `yaml:"timeouts,omitempty"`
 }
 
 func (i *inputGETYaml) Type() string {
@@ -120,6 +180,33 @@ type inputPUTYaml struct {
 	InputFile  string  `yaml:"input_file"`
 	FileDir    string  `yaml:"file_dir"`
 	ChunkSize  int64   `yaml:"chunk_size"`
+	// Replicas of each fragment that must be written before the PUT succeeds. 0 means all of them
+	MinReplicas int `yaml:"min_replicas"`
+	// Nodes each fragment is stored on. 0 uses the Controller's default
+	ReplicationFactor int `yaml:"replication_factor"`
+	// Stores the file erasure coded instead of replicated when set
+	ErasureCoding ErasureCoding `yaml:"erasure_coding,omitempty"`
+	// Fragments uploaded at once, and at once to the same node. 0 uses DEFAULT_CONCURRENCY and DEFAULT_NODE_CONCURRENCY
+	Concurrency     int `yaml:"concurrency,omitempty"`
+	NodeConcurrency int `yaml:"node_concurrency,omitempty"`
+	// Deadlines on connecting to and talking with the Controller and the nodes
+	Timeouts Timeouts `yaml:"timeouts,omitempty"`
+}
+
+type ErasureCoding struct {
+	DataShards   int `yaml:"data_shards"`
+	ParityShards int `yaml:"parity_shards"`
+}
+
+// Timeouts are in seconds. 0 uses dfs.DEFAULT_CONNECT_TIMEOUT, DEFAULT_READ_TIMEOUT and DEFAULT_WRITE_TIMEOUT
+type Timeouts struct {
+	Connect int `yaml:"connect"`
+	Read    int `yaml:"read"`
+	Write   int `yaml:"write"`
+}
+
+func (t Timeouts) durations() (connect time.Duration, read time.Duration, write time.Duration) {
+	return time.Duration(t.Connect) * time.Second, time.Duration(t.Read) * time.Second, time.Duration(t.Write) * time.Second
 }
 
 func (i *inputPUTYaml) Type() string {
@@ -128,6 +215,14 @@ func (i *inputPUTYaml) Type() string {
 
 type inputListFilesYaml struct {
 	Controller Address `yaml:"controller"`
+	//the root directory if empty
+	Dir string `yaml:"dir"`
+	//which page of the directory is listed, see dfs.ListOptions
+	Pattern   string `yaml:"pattern"`
+	PageToken string `yaml:"page_token"`
+	Limit     int    `yaml:"limit"`
+	//prints the listing as JSON rather than a table
+	JSON bool `yaml:"json"`
 }
 
 func (i *inputListFilesYaml) Type() string {
@@ -142,6 +237,52 @@ func (i *inputNodeStatsYaml) Type() string {
 	return "node_stats"
 }
 
+type inputDeleteYaml struct {
+	Controller Address `yaml:"controller"`
+	FileName   string  `yaml:"file_name"`
+}
+
+func (i *inputDeleteYaml) Type() string {
+	return "delete"
+}
+
+type inputStatYaml struct {
+	Controller Address `yaml:"controller"`
+	FileName   string  `yaml:"file_name"`
+}
+
+func (i *inputStatYaml) Type() string {
+	return "stat"
+}
+
+type inputMkdirYaml struct {
+	Controller Address `yaml:"controller"`
+	Dir        string  `yaml:"dir"`
+}
+
+func (i *inputMkdirYaml) Type() string {
+	return "mkdir"
+}
+
+type inputRmdirYaml struct {
+	Controller Address `yaml:"controller"`
+	Dir        string  `yaml:"dir"`
+}
+
+func (i *inputRmdirYaml) Type() string {
+	return "rmdir"
+}
+
+type inputRenameYaml struct {
+	Controller Address `yaml:"controller"`
+	From       string  `yaml:"from"`
+	To         string  `yaml:"to"`
+}
+
+func (i *inputRenameYaml) Type() string {
+	return "rename"
+}
+
 func parseArgs(args []string) (inputType InputInterface, err error) {
 	if len(args) < 2 {
 		return nil, fmt.Errorf("not enough arguments:\n use -h for help")
@@ -161,12 +302,27 @@ func parseArgs(args []string) (inputType InputInterface, err error) {
 		fmt.Println("To populate config file with template values:")
 		fmt.Println("./clientExec --populate-config <PUT or GET> <config file>")
 
-		fmt.Println("To list all files in DFS:")
-		fmt.Println("./clientExec --list-files <host:port>")
+		fmt.Println("To list the files and directories in a directory of the DFS, the root if none is given:")
+		fmt.Println("./clientExec --list-files <host:port> [dir] [--pattern <glob or prefix>] [--page-token <token>] [--limit <n>] [--json]")
 
 		fmt.Println("To get a list of nodes:")
 		fmt.Println("./clientExec --list-nodes")
 
+		fmt.Println("To delete a file from DFS:")
+		fmt.Println("./clientExec --delete <host:port> <file>")
+
+		fmt.Println("To describe a file in DFS, with where its fragments are and their health:")
+		fmt.Println("./clientExec --stat <host:port> <file>")
+
+		fmt.Println("To create a directory in DFS:")
+		fmt.Println("./clientExec --mkdir <host:port> <dir>")
+
+		fmt.Println("To remove an empty directory from DFS:")
+		fmt.Println("./clientExec --rmdir <host:port> <dir>")
+
+		fmt.Println("To rename or move a file or directory in DFS:")
+		fmt.Println("./clientExec --rename <host:port> <from> <to>")
+
 		os.Exit(0)
 
 	case "--load-config":
@@ -220,6 +376,10 @@ func parseArgs(args []string) (inputType InputInterface, err error) {
 				return inputType, err
 			}
 
+			if readData.Output == OUTPUT_STDOUT {
+				//stdout only carries the file, everything printed and logged goes to stderr
+				os.Stdout = os.Stderr
+			}
 			fmt.Printf("Read data: %#v\n", readData)
 			inputType = &readData
 			return inputType, err
@@ -308,24 +468,22 @@ func parseArgs(args []string) (inputType InputInterface, err error) {
 
 		if len(args) < 3 {
 
-			err = fmt.Errorf("not enough arguments:\n use --list-files <host:port>")
+			err = fmt.Errorf("not enough arguments:\n use --list-files <host:port> [dir] [--pattern <glob or prefix>] [--page-token <token>] [--limit <n>] [--json]")
 			return
 		}
 
-		hostPort := args[2]
-
-		//split host and port
-		hostPortSplit := strings.Split(hostPort, ":")
-		if len(hostPortSplit) != 2 {
-			err = fmt.Errorf("invalid host:port format")
+		controller, errA := parseController(args[2])
+		if errA != nil {
+			err = errA
 			return
 		}
 
 		data := inputListFilesYaml{
-			Controller: Address{
-				Host: hostPortSplit[0],
-				Port: hostPortSplit[1],
-			},
+			Controller: controller,
+		}
+		err = parseListOptions(args[3:], &data)
+		if err != nil {
+			return
 		}
 
 		inputType = &data
@@ -338,20 +496,97 @@ func parseArgs(args []string) (inputType InputInterface, err error) {
 			return
 		}
 
-		hostPort := args[2]
-
-		//split host and port
-		hostPortSplit := strings.Split(hostPort, ":")
-		if len(hostPortSplit) != 2 {
-			err = fmt.Errorf("invalid host:port format")
+		controller, errA := parseController(args[2])
+		if errA != nil {
+			err = errA
 			return
 		}
 
 		data := inputNodeStatsYaml{
-			Controller: Address{
-				Host: hostPortSplit[0],
-				Port: hostPortSplit[1],
-			},
+			Controller: controller,
+		}
+
+		inputType = &data
+
+	case "--delete":
+
+		if len(args) < 4 {
+
+			err = fmt.Errorf("not enough arguments:\n use --delete <host:port> <file>")
+			return
+		}
+
+		controller, errA := parseController(args[2])
+		if errA != nil {
+			err = errA
+			return
+		}
+
+		data := inputDeleteYaml{
+			Controller: controller,
+			FileName:   args[3],
+		}
+
+		inputType = &data
+
+	case "--stat":
+
+		if len(args) < 4 {
+
+			err = fmt.Errorf("not enough arguments:\n use --stat <host:port> <file>")
+			return
+		}
+
+		controller, errA := parseController(args[2])
+		if errA != nil {
+			err = errA
+			return
+		}
+
+		data := inputStatYaml{
+			Controller: controller,
+			FileName:   args[3],
+		}
+
+		inputType = &data
+
+	case "--mkdir", "--rmdir":
+
+		if len(args) < 4 {
+
+			err = fmt.Errorf("not enough arguments:\n use %s <host:port> <dir>", flag)
+			return
+		}
+
+		controller, errA := parseController(args[2])
+		if errA != nil {
+			err = errA
+			return
+		}
+		if flag == "--mkdir" {
+			inputType = &inputMkdirYaml{Controller: controller, Dir: args[3]}
+		} else {
+			inputType = &inputRmdirYaml{Controller: controller, Dir: args[3]}
+		}
+
+	case "--rename":
+
+		if len(args) < 5 {
+
+			err = fmt.Errorf("not enough arguments:\n use --rename <host:port> <from> <to>")
+			return
+		}
+
+		controller, errA := parseController(args[2])
+		if errA != nil {
+			err = errA
+			return
+		}
+
+		data := inputRenameYaml{
+			Controller: controller,
+			From:       args[3],
+			To:         args[4],
 		}
 
 		inputType = &data
@@ -384,3 +619,39 @@ func appendLogger() *zap.Logger {
 	logger := zap.New(zapcore.NewTee(fileCore, consoleCore))
 	return logger
 }
+
+// parseListOptions reads the directory and the options following the address of a --list-files.
+func parseListOptions(args []string, data *inputListFilesYaml) error {
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			data.JSON = true
+
+		case "--pattern", "--page-token", "--limit":
+			if i+1 == len(args) {
+				return fmt.Errorf("missing value of %s", args[i])
+			}
+			i++
+			switch args[i-1] {
+			case "--pattern":
+				data.Pattern = args[i]
+			case "--page-token":
+				data.PageToken = args[i]
+			default:
+				limit, err := strconv.Atoi(args[i])
+				if err != nil || limit < 0 {
+					return fmt.Errorf("invalid limit %q", args[i])
+				}
+				data.Limit = limit
+			}
+
+		default:
+			if strings.HasPrefix(args[i], "--") || data.Dir != "" {
+				return fmt.Errorf("unexpected argument %q", args[i])
+			}
+			data.Dir = args[i]
+		}
+	}
+	return nil
+}
@@ -1,22 +1,34 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"go.uber.org/zap"
+	"io"
 	"net"
+	"path"
 	"src/controller/file_distributor"
+	"src/controller/metadata"
 	"src/controller/storage_handler"
+	"src/erasure"
 	clientMessages "src/messages/controller_client"
+	"src/messages/wire"
 	clientProto3 "src/proto/controller_client"
+	"time"
 )
 
-func acceptClientConnections(listener2 net.Listener, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+func acceptClientConnections(ctx context.Context, listener2 net.Listener, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
 
 	for {
 		if conn, err := listener2.Accept(); err == nil {
 
 			msgHandler := clientMessages.NewMessageHandler(conn)
-			go handleClient(msgHandler, spokeHandler, logger)
+			msgHandler.SetTimeouts(CLIENT_TIMEOUT*time.Second, CLIENT_TIMEOUT*time.Second)
+			go handleClient(ctx, msgHandler, spokeHandler, logger)
 
+		} else if ctx.Err() != nil {
+			return
 		} else {
 
 			logger.Error(err.Error())
@@ -24,65 +36,216 @@ func acceptClientConnections(listener2 net.Listener, spokeHandler *storage_handl
 	}
 }
 
-func handleClient(msgHandler *clientMessages.MessageHandler, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+func handleClient(ctx context.Context, msgHandler *clientMessages.MessageHandler, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
 	defer msgHandler.Close()
+	defer closeOnDone(ctx, msgHandler.Close)()
 	proto := clientProto3.NewProtoHandler(msgHandler, logger)
 
 	for {
-		wrapper, _ := proto.MsgHandler().ClientRequestReceive()
+		wrapper, err := proto.MsgHandler().ClientRequestReceive()
+		if err != nil {
+			//a corrupt frame or an unknown protocol version was already answered with an error frame
+			if err != io.EOF {
+				logger.Info("Dropping client", zap.Error(err))
+			}
+			return
+		}
 
 		switch wrapper.ClientMessage.(type) {
 
 		default:
 			//TODO: handle this
 			req := proto.HandleClientRequest(wrapper)
+			//files and directories are keyed by their cleaned path, so "/a/b" and "a/b/" are the same file
+			name := metadata.CleanPath(req.GetFileName())
 			switch req.GetReqType() {
 			case "PUT":
 				logger.Info("Processing PUT request")
 
 				var fragMap map[*file_distributor.Fragment][]*storage_handler.Node
+				var layout *erasure.Layout
+				var uploadId string
+
+				if meta, ok := spokeHandler.ResumeUpload(name, req.GetUploadId()); ok {
+					logger.Info("Resuming upload", zap.String("file", name), zap.String("uploadId", meta.UploadId))
+					distributor := file_distributor.NewFileDistributor(meta.Name, meta.Size, meta.ChunkSize, meta.ReplicationFactor, spokeHandler)
+					distributor.SetBlocks(meta.Blocks)
+					if meta.ErasureCoded() {
+						distributor.SetErasureCoding(meta.DataShards, meta.ParityShards)
+					}
+
+					//the fragments are planned again, the client skips the ones it already stored
+					spokeHandler.ReleaseReservations(meta.Name)
+					var err error
+					fragMap, err = planUpload(meta.Name, distributor, spokeHandler)
+					if err == storage_handler.ErrInsufficientSpace {
+						logger.Error(err.Error())
+						proto.HandlePlanError("NOT_ENOUGH_SPACE", req)
+						return
+					} else if err != nil {
+						logger.Error(err.Error())
+						proto.HandlePlanError("NOT_ENOUGH_NODES", req)
+						return
+					}
+					proto.HandlePlanResponse(fragMap, distributor.ErasureLayout(), meta.UploadId, req)
+					return
+				}
 
 				//TODO: FindFiles might be a little slow here. Find a better way to do this
-				FileMap := spokeHandler.FindFiles(req.GetFileName(), logger)
-				if FileMap != nil {
+				FileMap := spokeHandler.FindFiles(name, logger)
+				recorded, _ := spokeHandler.FileExists(name)
+				replicationFactor, errR := spokeHandler.ResolveReplicationFactor(req.GetReplicationFactor())
+				redundancy := metadata.Redundancy{ReplicationFactor: replicationFactor}
+				var errE error
+				if req.GetDataShards() != 0 || req.GetParityShards() != 0 {
+					redundancy, errE = spokeHandler.ResolveErasureCoding(req.GetDataShards(), req.GetParityShards())
+				}
+
+				if FileMap != nil || recorded {
 					logger.Info("File exists.")
 					fragMap = nil
+				} else if errN := spokeHandler.CheckNewFile(name); errN != nil {
+					logger.Info("Cannot create the file", zap.String("file", name), zap.Error(errN))
+					proto.HandlePlanError(namespaceStatus(errN), req)
+					return
+				} else if errR != nil {
+					logger.Error(errR.Error())
+					proto.HandlePlanError("INVALID_REPLICATION_FACTOR", req)
+					return
+				} else if errE != nil {
+					logger.Error(errE.Error())
+					proto.HandlePlanError("INVALID_ERASURE_CODING", req)
+					return
 				} else {
 					logger.Info("File doesn't Exist.")
+					distributor := file_distributor.NewFileDistributor(name, req.GetFileSize(), req.GetChunkSize(), redundancy.ReplicationFactor, spokeHandler)
+					if redundancy.ErasureCoded() {
+						distributor.SetErasureCoding(redundancy.DataShards, redundancy.ParityShards)
+					}
+					layout = distributor.ErasureLayout()
+
 					var fileDistributor file_distributor.FileDistributorInterface
-					fileDistributor = file_distributor.NewFileDistributor(req.GetFileName(), req.GetFileSize(), req.GetChunkSize(), spokeHandler)
+					fileDistributor = distributor
 
 					var err error
-					fragMap, err = fileDistributor.DistributeFile()
-					if err != nil {
+					fragMap, err = planUpload(name, fileDistributor, spokeHandler)
+					if err == storage_handler.ErrInsufficientSpace {
 						logger.Error(err.Error())
+						proto.HandlePlanError("NOT_ENOUGH_SPACE", req)
+						return
+					} else if err != nil {
+						logger.Error(err.Error())
+						proto.HandlePlanError("NOT_ENOUGH_NODES", req)
+						return
+					} else if len(fragMap) != 0 {
+						uploadId = newUploadId()
+						err = recordPlan(name, distributor.Blocks(), req.GetFileSize(), distributor.ChunkSize(), redundancy, uploadId, req.GetOwner(), spokeHandler, logger)
+						if err == metadata.ErrBlockInUse {
+							proto.HandlePlanError("FILE_ALREADY_EXISTS", req)
+							return
+						} else if err != nil {
+							proto.HandlePlanError(namespaceStatus(err), req)
+							return
+						}
 					}
-					//spokeHandler.AddFile(req.GetFileName())
 				}
 
-				proto.HandlePlanResponse(fragMap, req)
+				proto.HandlePlanResponse(fragMap, layout, uploadId, req)
 
 			case "GET":
 				logger.Info("Processing GET request")
 
-				FileMap := spokeHandler.FindFiles(req.GetFileName(), logger)
+				FileMap := spokeHandler.FindFiles(name, logger)
 				if FileMap == nil {
 					logger.Info("File doesn't exists.")
-					proto.HandleGetResponse(nil, req)
+					proto.HandleGetResponse(nil, nil, nil, "", nil, req)
 				} else {
 					logger.Info("File exists.")
 
 					logger.Sugar().Info("FileMap length: ", len(FileMap))
-					proto.HandleGetResponse(FileMap, req)
+					var layout *erasure.Layout
+					if l, ok := spokeHandler.ErasureLayout(name); ok {
+						//missing shards are rebuilt by the client from the rest of their group
+						layout = &l
+					}
+
+					var fileRange *storage_handler.FileRange
+					if req.Ranged() {
+						var err error
+						FileMap, fileRange, err = spokeHandler.SelectRange(name, FileMap, req.GetOffset(), req.GetLength())
+						if err != nil {
+							logger.Info("Invalid range", zap.Int64("offset", req.GetOffset()), zap.Int64("length", req.GetLength()))
+							proto.HandleGetError("INVALID_RANGE", req)
+							return
+						}
+					} else if _, whole, err := spokeHandler.SelectRange(name, FileMap, 0, 0); err == nil {
+						//the size of every fragment, so the client can write each one straight to its place
+						fileRange = whole
+					}
+					proto.HandleGetResponse(FileMap, spokeHandler.Blocks(name), layout, spokeHandler.UploadId(name), fileRange, req)
 				}
 
 			case "DELETE":
+				logger.Info("Processing DELETE request")
+
+				FileMap := spokeHandler.LocateFragments(name)
+				if FileMap == nil {
+					logger.Info("File doesn't exists.")
+					proto.HandleDeleteResponse(nil, nil, req)
+				} else {
+					logger.Sugar().Info("Deleting fragments: ", len(FileMap))
+					//the file is hidden until it is gone, a delete that fails is retried by collectStaleUploads
+					spokeHandler.StartDelete(name)
+					failed := deleteFile(FileMap, spokeHandler, logger)
+					if len(failed) == 0 {
+						spokeHandler.ForgetFile(name)
+					}
+					proto.HandleDeleteResponse(FileMap, failed, req)
+				}
+
+			case "COMMIT":
+				logger.Info("Processing COMMIT request")
+
+				err := spokeHandler.CommitFile(name, req.GetChecksum())
+				if err == storage_handler.ErrFileNotFound {
+					proto.HandleCommitResponse("FILE_NOT_FOUND", req)
+				} else if err != nil {
+					logger.Error("Error committing file", zap.String("file", name), zap.Error(err))
+					proto.HandleCommitResponse("ERROR", req)
+				} else {
+					logger.Info("File committed", zap.String("file", name))
+					proto.HandleCommitResponse("OK", req)
+				}
+
+			case "STAT":
+				logger.Info("Processing STAT request", zap.String("file", name))
+				stat, err := spokeHandler.StatFile(name)
+				if err == storage_handler.ErrFileNotFound {
+					proto.HandleStatResponse(nil, "FILE_NOT_FOUND", req)
+				} else {
+					proto.HandleStatResponse(stat, "OK", req)
+				}
+
 			case "LIST":
-				logger.Info("Processing LIST request")
-				fileFragments := spokeHandler.FindAllFiles(logger)
+				logger.Info("Processing LIST request", zap.String("dir", name))
+				entries, next, err := spokeHandler.ListDir(name, storage_handler.ListOptions{
+					Pattern:   req.GetPattern(),
+					PageToken: req.GetPageToken(),
+					Limit:     req.GetLimit(),
+				})
+				proto.HandleListResponse(entries, next, namespaceStatus(err), req)
+
+			case "MKDIR":
+				logger.Info("Processing MKDIR request", zap.String("dir", name))
+				proto.HandleNamespaceResponse(namespaceStatus(spokeHandler.Mkdir(name)), req)
 
-				fileSet := spokeHandler.ExtractFiles(fileFragments, logger)
-				proto.HandleListResponse(fileSet, req)
+			case "RMDIR":
+				logger.Info("Processing RMDIR request", zap.String("dir", name))
+				proto.HandleNamespaceResponse(namespaceStatus(spokeHandler.Rmdir(name)), req)
+
+			case "RENAME":
+				logger.Info("Processing RENAME request", zap.String("from", name), zap.String("to", req.GetDestination()))
+				proto.HandleNamespaceResponse(namespaceStatus(spokeHandler.Rename(name, req.GetDestination())), req)
 
 			case "NODE_INFO":
 				logger.Info("Processing NODE_INFO request")
@@ -104,9 +267,75 @@ func handleClient(msgHandler *clientMessages.MessageHandler, spokeHandler *stora
 			return
 
 		case nil:
+			proto.MsgHandler().ErrorSend(wire.ERROR_BAD_REQUEST, "empty or unknown request")
 			return
 		}
 
 	}
 
 }
+
+// planUpload plans where the fragments of a file go and reserves the space they take. If concurrent PUTs
+// reserved the space first, the file is planned again on what is left.
+func planUpload(fileName string, fileDistributor file_distributor.FileDistributorInterface, spokeHandler *storage_handler.StorageNodeHandler) (fragMap map[*file_distributor.Fragment][]*storage_handler.Node, err error) {
+
+	for attempt := 0; attempt < PLAN_ATTEMPTS; attempt++ {
+		fragMap, err = fileDistributor.DistributeFile()
+		if err != nil {
+			return nil, err
+		}
+
+		err = spokeHandler.Reserve(fileName, file_distributor.Reservations(fragMap))
+		if err != storage_handler.ErrInsufficientSpace {
+			return
+		}
+	}
+	return nil, err
+}
+
+// newUploadId returns a random id for a new PUT.
+func newUploadId() string {
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// namespaceStatus is the status code a client is answered with for what a namespace operation returned.
+func namespaceStatus(err error) string {
+
+	switch err {
+	case nil:
+		return "OK"
+	case metadata.ErrNotFound:
+		return "FILE_NOT_FOUND"
+	case metadata.ErrExists:
+		return "FILE_ALREADY_EXISTS"
+	case metadata.ErrNotDir:
+		return "NOT_A_DIRECTORY"
+	case metadata.ErrIsDir:
+		return "IS_A_DIRECTORY"
+	case metadata.ErrNotEmpty:
+		return "DIRECTORY_NOT_EMPTY"
+	case metadata.ErrInvalidPath:
+		return "INVALID_PATH"
+	case metadata.ErrBusy:
+		return "FILE_BUSY"
+	case path.ErrBadPattern:
+		return "INVALID_PATTERN"
+	}
+	return "ERROR"
+}
+
+// recordPlan persists the layout of a newly planned file in the metadata store. A plan that could not be
+// recorded releases the space reserved for its blocks. The file itself is left alone, a concurrent PUT of
+// the same name may have recorded it first.
+func recordPlan(fileName string, blocks []string, fileSize int64, chunkSize int64, redundancy metadata.Redundancy, uploadId string, owner string, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) (err error) {
+
+	err = spokeHandler.RecordFile(fileName, fileSize, chunkSize, redundancy, blocks, uploadId, owner)
+	if err != nil {
+		logger.Info("Plan not recorded", zap.String("file", fileName), zap.Error(err))
+		spokeHandler.ReleaseBlocks(blocks)
+	}
+	return
+}
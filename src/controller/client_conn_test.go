@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reflect"
+	"src/controller/metadata"
+	"src/controller/storage_handler"
+	"testing"
+)
+
+func TestRecordPlan_race(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := metadata.Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	spokeHandler := storage_handler.NewStorageNodeHandler(zap.NewNop())
+	spokeHandler.SetMetadataStore(store, 0)
+
+	startFakeNode(ctx, "node1", spokeHandler)
+	waitFor(t, "the node to register", func() bool {
+		node := spokeHandler.GetNodeInfo().(map[string]storage_handler.Node)["node1"]
+		return node.GetFreeSpace() > 0
+	})
+
+	//two PUTs of the same file planned at once, both passed the check that the file does not exist
+	type plan struct {
+		uploadId   string
+		blocks     []string
+		redundancy metadata.Redundancy
+	}
+	plans := []plan{
+		{uploadId: "upload1", blocks: []string{"block1"}, redundancy: metadata.Redundancy{ReplicationFactor: 1}},
+		{uploadId: "upload2", blocks: []string{"block2"}, redundancy: metadata.Redundancy{ReplicationFactor: 2}},
+	}
+	for _, p := range plans {
+		err = spokeHandler.Reserve("file", []storage_handler.Reservation{{NodeId: "node1", Fragment: p.blocks[0], Bytes: 10}})
+		if err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+	}
+
+	errs := make(chan error, len(plans))
+	for _, p := range plans {
+		go func(p plan) {
+			errs <- recordPlan("file", p.blocks, 10, 10, p.redundancy, p.uploadId, "", spokeHandler, zap.NewNop())
+		}(p)
+	}
+	recorded := 0
+	for range plans {
+		switch err := <-errs; err {
+		case nil:
+			recorded++
+		case metadata.ErrExists:
+		default:
+			t.Fatalf("recordPlan() error = %v, want nil or %v", err, metadata.ErrExists)
+		}
+	}
+	if recorded != 1 {
+		t.Fatalf("recordPlan() recorded %d plans, want 1", recorded)
+	}
+	meta, ok := store.GetFile("file")
+	if !ok {
+		t.Fatalf("GetFile() found no file")
+	}
+	var winner *plan
+	for i := range plans {
+		if plans[i].uploadId == meta.UploadId {
+			winner = &plans[i]
+		}
+	}
+	if winner == nil {
+		t.Fatalf("GetFile() upload id = %v, want one of the plans", meta.UploadId)
+	}
+
+	if got := spokeHandler.Blocks("file"); !reflect.DeepEqual(got, winner.blocks) {
+		t.Errorf("Blocks() = %v, want %v", got, winner.blocks)
+	}
+	if got := spokeHandler.ReplicationFactor("file"); got != winner.redundancy.ReplicationFactor {
+		t.Errorf("ReplicationFactor() = %v, want %v", got, winner.redundancy.ReplicationFactor)
+	}
+	//only the space of the plan that lost is released
+	for _, node := range spokeHandler.AvailableNodes() {
+		if node.GetFreeSpace() != fakeNodeSpace-10 {
+			t.Errorf("AvailableNodes() free space = %v, want %v", node.GetFreeSpace(), fakeNodeSpace-10)
+		}
+	}
+}
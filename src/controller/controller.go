@@ -1,18 +1,48 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"net"
 	"os"
+	"os/signal"
+	"src/controller/ha"
+	"src/controller/metadata"
+	"src/controller/placement"
 	"src/controller/storage_handler"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 )
 
 const HEARTBEAT_INTERVAL = 5
 const ACCEPTED_DELAY = HEARTBEAT_INTERVAL * 3
+const SNAPSHOT_INTERVAL = 60
+const METADATA_DIR = "metadata/"
+const DEFAULT_REPLICATION_FACTOR = 3
+const MAX_REPLICATION_FACTOR = 5
+
+// COMMAND_TIMEOUT is how long, in seconds, a node has to acknowledge a command pushed on its session.
+const COMMAND_TIMEOUT = 300
+
+// RESERVATION_TIMEOUT is how long, in seconds, the space planned for a PUT stays reserved if its fragments never arrive.
+const RESERVATION_TIMEOUT = 600
+
+// UPLOAD_TIMEOUT is how long, in seconds, a PUT may go uncommitted before its fragments are removed.
+const UPLOAD_TIMEOUT = 900
+
+// CLIENT_TIMEOUT is how long, in seconds, the Controller waits on a client to send or take a message before it drops it.
+const CLIENT_TIMEOUT = 60
+
+// SESSION_WRITE_TIMEOUT is how long, in seconds, sending a message on a node's session may take. A node that
+// sends nothing for ACCEPTED_DELAY seconds has its session closed.
+const SESSION_WRITE_TIMEOUT = 30
+
+// PLAN_ATTEMPTS is how often a PUT is planned again when concurrent PUTs took the space it was planned on.
+const PLAN_ATTEMPTS = 3
 
 func initLogger(file *os.File) *zap.Logger {
 
@@ -47,49 +77,168 @@ func main() {
 
 	logger := initLogger(file)
 
-	if len(os.Args) != 3 {
+	if len(os.Args) < 3 || len(os.Args) > 8 || len(os.Args) == 7 {
 		logger.Error("Command line args not provided.")
-		logger.Info("Usage: ./controller <Storage Nodes facing port port> <Client facing port>")
-		logger.Info("Usage(2): go run controller/controller.go <Storage Nodes facing port port> <Client facing port>")
+		logger.Info("Usage: ./controller <Storage Nodes facing port port> <Client facing port> [metadata dir] [placement policy] [gRPC port or -] [controller address] [controller addresses]")
+		logger.Info("Usage(2): go run controller/controller.go <Storage Nodes facing port port> <Client facing port> [metadata dir] [placement policy] [gRPC port or -] [controller address] [controller addresses]")
 		logger.Fatal("Exiting.")
 		os.Exit(1)
 	}
 
-	port1, err := strconv.Atoi(os.Args[1])
+	ports := ports{}
+	ports.storage, err = strconv.Atoi(os.Args[1])
 	if err != nil {
 		fmt.Println("Invalid port number:", os.Args[1])
 		os.Exit(1)
 	}
 
-	logger.Sugar().Info("Listening on port for Storage node connections: ", port1)
+	ports.client, err = strconv.Atoi(os.Args[2])
+	if err != nil {
+		fmt.Println("Invalid port number:", os.Args[2])
+		os.Exit(1)
+	}
+
+	metadataDir := METADATA_DIR
+	if len(os.Args) >= 4 {
+		metadataDir = os.Args[3]
+	}
 
-	listener1, err := net.Listen("tcp", ":"+strconv.Itoa(port1))
+	policyName := placement.DEFAULT_POLICY
+	if len(os.Args) >= 5 {
+		policyName = os.Args[4]
+	}
+	policy, err := placement.New(policyName, time.Now().UnixNano())
 	if err != nil {
-		logger.Error(err.Error())
+		logger.Error("Error choosing the placement policy", zap.Error(err))
 		return
 	}
+	logger.Info("Placing fragments", zap.String("policy", policyName))
 
-	port2, err := strconv.Atoi(os.Args[2])
+	//clients may also reach the Controller over gRPC
+	if len(os.Args) >= 6 && os.Args[5] != "-" {
+		ports.grpc, err = strconv.Atoi(os.Args[5])
+		if err != nil {
+			fmt.Println("Invalid port number:", os.Args[5])
+			os.Exit(1)
+		}
+	}
+
+	store, err := metadata.Open(metadataDir, logger)
 	if err != nil {
-		fmt.Println("Invalid port number:", os.Args[2])
-		os.Exit(1)
+		logger.Error("Error opening the metadata store", zap.Error(err))
+		return
 	}
+	defer store.Close()
 
-	logger.Sugar().Info("Listening on port for Client connections: ", port2)
+	go func() {
 
-	listener2, err := net.Listen("tcp", ":"+strconv.Itoa(port2))
+		for {
+			//fold the write-ahead log into a snapshot
+			time.Sleep(SNAPSHOT_INTERVAL * time.Second)
+			store.Snapshot()
+		}
+	}()
+
+	//stopping closes the listeners and every connection, which ends the requests being handled
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(os.Args) < 8 {
+		err = serveActive(ctx, ports, store, policy, logger)
+		if err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		logger.Info("Shutting down")
+		return
+	}
+
+	//in a highly available group, only the active controller serves storage nodes and clients
+	address := os.Args[6]
+	node, err := ha.NewNode(address, strings.Split(os.Args[7], ","), store, logger)
+	if err != nil {
+		logger.Error("Error joining the controllers", zap.String("address", address), zap.Error(err))
+		return
+	}
+
+	logger.Sugar().Info("Listening for the other controllers on: ", address)
+	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		logger.Error(err.Error())
 		return
 	}
+	go node.Run(ctx, listener)
+
+	for {
+		activeCtx, err := node.WaitActive(ctx)
+		if err != nil {
+			break
+		}
+
+		err = serveActive(activeCtx, ports, store, policy, logger)
+		if err != nil {
+			logger.Error("Error serving as the active controller", zap.Error(err))
+			select {
+			case <-activeCtx.Done():
+			case <-time.After(HEARTBEAT_INTERVAL * time.Second):
+			}
+		}
+	}
+	logger.Info("Shutting down")
+}
+
+// ports are the ports the active controller serves storage nodes and clients on. No gRPC port is 0.
+type ports struct {
+	storage int
+	client  int
+	grpc    int
+}
+
+// serveActive serves storage nodes and clients until ctx is cancelled, from the metadata in store. The
+// listeners are opened when it starts and closed when it returns, so storage nodes and clients only reach
+// the active controller of a group.
+func serveActive(ctx context.Context, ports ports, store *metadata.Store, policy placement.Policy, logger *zap.Logger) (err error) {
+
+	logger.Sugar().Info("Listening on port for Storage node connections: ", ports.storage)
+	listener1, err := net.Listen("tcp", ":"+strconv.Itoa(ports.storage))
+	if err != nil {
+		return
+	}
+	defer listener1.Close()
+
+	logger.Sugar().Info("Listening on port for Client connections: ", ports.client)
+	listener2, err := net.Listen("tcp", ":"+strconv.Itoa(ports.client))
+	if err != nil {
+		return
+	}
+	defer listener2.Close()
+
+	var listener3 net.Listener
+	if ports.grpc != 0 {
+		logger.Sugar().Info("Listening on port for gRPC connections: ", ports.grpc)
+		listener3, err = net.Listen("tcp", ":"+strconv.Itoa(ports.grpc))
+		if err != nil {
+			return
+		}
+		defer listener3.Close()
+	}
 
 	spokeHandler := storage_handler.NewStorageNodeHandler(logger)
+	spokeHandler.SetPlacementPolicy(policy)
+	spokeHandler.SetReservationTimeout(RESERVATION_TIMEOUT * time.Second)
+	spokeHandler.SetReplicationLimits(DEFAULT_REPLICATION_FACTOR, MAX_REPLICATION_FACTOR)
+	//nodes get ACCEPTED_DELAY seconds to re-register after a restart or a failover before their replicas are forgotten
+	spokeHandler.SetMetadataStore(store, ACCEPTED_DELAY*time.Second)
 
 	go func() {
 
 		for {
 			//wait for a heartbeat interval
-			time.Sleep(HEARTBEAT_INTERVAL * time.Second)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(HEARTBEAT_INTERVAL * time.Second):
+			}
 			spokeHandler.ConcurrentStaleNodeRemoval(ACCEPTED_DELAY, logger)
 		}
 	}()
@@ -98,14 +247,39 @@ func main() {
 
 		for {
 			//wait for a accepted delay interval
-			time.Sleep(HEARTBEAT_INTERVAL * ACCEPTED_DELAY * time.Second)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(HEARTBEAT_INTERVAL * ACCEPTED_DELAY * time.Second):
+			}
 			spokeHandler.ConcurrentIndexing()
+			pushRepairs(spokeHandler, logger)
+			collectStaleUploads(spokeHandler, logger)
 
 		}
 	}()
 
-	go acceptStorageNodeConnections(listener1, spokeHandler, logger)
-	go acceptClientConnections(listener2, spokeHandler, logger)
+	go acceptStorageNodeConnections(ctx, listener1, spokeHandler, logger)
+	go acceptClientConnections(ctx, listener2, spokeHandler, logger)
+	if listener3 != nil {
+		go serveGRPC(ctx, listener3, spokeHandler, logger)
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// closeOnDone closes a connection once ctx is cancelled. stop ends the watch, it is called when the connection
+// is closed anyway.
+func closeOnDone(ctx context.Context, closeConn func()) (stop func()) {
 
-	select {}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			closeConn()
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
 }
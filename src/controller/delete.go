@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"src/controller/storage_handler"
+	"sync"
+	"time"
+)
+
+// deleteFile pushes a delete command to every node holding a fragment of the file, and waits for all of them
+// to acknowledge it. It returns the replicas that could not be removed, keyed by fragment.
+func deleteFile(fileMap map[string][]*storage_handler.Node, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) (failed map[string][]*storage_handler.Node) {
+
+	failed = make(map[string][]*storage_handler.Node)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	//one command per node for all the fragments it holds
+	fragments := make(map[string][]string)
+	nodes := make(map[string]*storage_handler.Node)
+	for frag, holders := range fileMap {
+		for _, node := range holders {
+			fragments[node.GetID()] = append(fragments[node.GetID()], frag)
+			nodes[node.GetID()] = node
+		}
+	}
+
+	for nodeId, frags := range fragments {
+		wg.Add(1)
+		go func(node *storage_handler.Node, frags []string) {
+			defer wg.Done()
+
+			notDeleted, err := deleteFragments(frags, node, spokeHandler)
+			if err != nil {
+				logger.Error("Failed to delete fragments", zap.Strings("fragments", frags), zap.String("nodeId", node.GetID()), zap.Error(err))
+			}
+
+			left := make(map[string]bool)
+			for _, frag := range notDeleted {
+				left[frag] = true
+			}
+
+			for _, frag := range frags {
+				if left[frag] {
+					mutex.Lock()
+					failed[frag] = append(failed[frag], node)
+					mutex.Unlock()
+					continue
+				}
+				spokeHandler.RemoveFragment(node.GetID(), frag)
+			}
+		}(nodes[nodeId], frags)
+	}
+
+	wg.Wait()
+	return
+}
+
+// deleteFragments pushes a delete command on the node's session, and returns the fragments it could not remove.
+func deleteFragments(frags []string, node *storage_handler.Node, spokeHandler *storage_handler.StorageNodeHandler) (notDeleted []string, err error) {
+
+	session := spokeHandler.Session(node.GetID())
+	if session == nil {
+		return frags, errors.New("no session with storage node")
+	}
+
+	ack, err := session.Delete(frags)
+	if err != nil {
+		return frags, err
+	}
+	return ack.Failed, nil
+}
+
+// collectStaleUploads removes the fragments of uploads that were never committed, and of deletes that did
+// not finish, once they are older than UPLOAD_TIMEOUT. A file is forgotten when all of its fragments are gone.
+func collectStaleUploads(spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+
+	for _, fileName := range spokeHandler.StaleUploads(UPLOAD_TIMEOUT * time.Second) {
+		logger.Info("Removing stale upload", zap.String("file", fileName))
+		spokeHandler.StartDelete(fileName)
+
+		fileMap := spokeHandler.LocateFragments(fileName)
+		if failed := deleteFile(fileMap, spokeHandler, logger); len(failed) != 0 {
+			logger.Warn("Stale upload not fully removed", zap.String("file", fileName), zap.Int("fragments left", len(failed)))
+			continue
+		}
+		spokeHandler.ForgetFile(fileName)
+	}
+}
@@ -1,24 +1,40 @@
 package file_distributor
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/rand"
 	"sort"
+	"src/controller/placement"
 	"src/controller/storage_handler"
+	"src/erasure"
+	"time"
 )
 
 type FileDistributor struct {
 	fileName string
+	//the ids the fragments are stored under in file order, generated unless SetBlocks was called
+	blocks   []string
 	fileSize int64
 	//set chunk size to 128MB
 	fragmentSize int64
-	storageSys   *storage_handler.StorageNodeHandler
+	//number of nodes each fragment is placed on
+	replicationFactor int
+	//data and parity shards per group, zero for a replicated file
+	dataShards   int
+	parityShards int
+	//decides between the nodes that may hold a fragment
+	placementPolicy placement.Policy
+	storageSys      *storage_handler.StorageNodeHandler
 }
 
 type Fragment struct {
 	fragName string
 	fragSize int64
+	//position of the fragment in the file, or of the shard in an erasure coded file
+	index int
 }
 
 func (f Fragment) GetFragmentName() string {
@@ -29,11 +45,16 @@ func (f Fragment) GetFragmentSize() int64 {
 	return f.fragSize
 }
 
-func NewFileDistributor(fileName string, fileSize int64, chunkSize int64, storageSys *storage_handler.StorageNodeHandler) (fileDistributor *FileDistributor) {
+func (f Fragment) GetFragmentIndex() int {
+	return f.index
+}
+
+func NewFileDistributor(fileName string, fileSize int64, chunkSize int64, replicationFactor int, storageSys *storage_handler.StorageNodeHandler) (fileDistributor *FileDistributor) {
 	fileDistributor = &FileDistributor{
-		fileName:   fileName,
-		fileSize:   fileSize,
-		storageSys: storageSys,
+		fileName:          fileName,
+		fileSize:          fileSize,
+		replicationFactor: replicationFactor,
+		storageSys:        storageSys,
 	}
 
 	if chunkSize == 0 {
@@ -45,6 +66,67 @@ func NewFileDistributor(fileName string, fileSize int64, chunkSize int64, storag
 	return
 }
 
+// SetBlocks stores the fragments of the file under the blocks recorded for it, in file order.
+func (fd *FileDistributor) SetBlocks(blocks []string) {
+	fd.blocks = blocks
+}
+
+// Blocks returns the ids the fragments of the file are stored under, in file order. They are random, so the
+// file can be renamed without touching its fragments, and generated the first time unless SetBlocks was called.
+func (fd *FileDistributor) Blocks() []string {
+	if fd.blocks != nil {
+		return fd.blocks
+	}
+
+	numFragments := int((fd.fileSize + fd.fragmentSize - 1) / fd.fragmentSize)
+	if fd.dataShards != 0 {
+		layout := erasure.Layout{DataShards: fd.dataShards, ParityShards: fd.parityShards, ChunkSize: fd.fragmentSize, FileSize: fd.fileSize}
+		numFragments = layout.Groups() * layout.Shards()
+	}
+	fd.blocks = make([]string, numFragments)
+	for i := range fd.blocks {
+		fd.blocks[i] = NewBlockId()
+	}
+	return fd.blocks
+}
+
+// NewBlockId returns a random id to store a fragment under.
+func NewBlockId() string {
+
+	id := make([]byte, 8)
+	crand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// SetPlacementPolicy overrides the placement policy of the storage system for this file.
+func (fd *FileDistributor) SetPlacementPolicy(policy placement.Policy) {
+	fd.placementPolicy = policy
+}
+
+// SetErasureCoding stores the file as groups of data and parity shards instead of replicated fragments.
+func (fd *FileDistributor) SetErasureCoding(dataShards int, parityShards int) {
+	fd.dataShards = dataShards
+	fd.parityShards = parityShards
+}
+
+func (fd *FileDistributor) ChunkSize() int64 {
+	return fd.fragmentSize
+}
+
+// ErasureLayout returns how the file is cut into shards, nil for a replicated file.
+func (fd *FileDistributor) ErasureLayout() *erasure.Layout {
+	if fd.dataShards == 0 {
+		return nil
+	}
+	return &erasure.Layout{
+		DataShards:   fd.dataShards,
+		ParityShards: fd.parityShards,
+		ChunkSize:    fd.fragmentSize,
+		FileSize:     fd.fileSize,
+		Blocks:       fd.Blocks(),
+	}
+}
+
 func (fd *FileDistributor) sliceOfFragments() (fragments []*Fragment, err error) {
 	if fd.fileSize == 0 {
 		return nil, errors.New("file size is 0")
@@ -58,11 +140,16 @@ func (fd *FileDistributor) sliceOfFragments() (fragments []*Fragment, err error)
 	}
 
 	fragments = make([]*Fragment, numFragments)
+	blocks := fd.Blocks()
+	if len(blocks) != numFragments {
+		return nil, fmt.Errorf("file has %d fragments, %d blocks recorded", numFragments, len(blocks))
+	}
 
 	for i := 0; i < numFragments-1; i++ {
 		fragment := &Fragment{
-			fragName: fd.fileName + "_" + fmt.Sprint(i),
+			fragName: blocks[i],
 			fragSize: fd.fragmentSize,
+			index:    i,
 		}
 		fragments[i] = fragment
 	}
@@ -74,8 +161,9 @@ func (fd *FileDistributor) sliceOfFragments() (fragments []*Fragment, err error)
 	}
 
 	lastFragment := &Fragment{
-		fragName: fd.fileName + "_" + fmt.Sprint(numFragments-1),
+		fragName: blocks[numFragments-1],
 		fragSize: lastFragmentSize,
+		index:    numFragments - 1,
 	}
 	fragments[numFragments-1] = lastFragment
 
@@ -83,18 +171,12 @@ func (fd *FileDistributor) sliceOfFragments() (fragments []*Fragment, err error)
 }
 
 func (fd *FileDistributor) SortNodes() (sortedNodes []*storage_handler.Node, err error) {
-	storageNodes := fd.storageSys.GetStorageNodes()
-	if len(storageNodes) == 0 {
+	//copies with the reserved space taken off, so planning does not change the live nodes
+	sortedNodes = fd.storageSys.AvailableNodes()
+	if len(sortedNodes) == 0 {
 		return nil, errors.New("no storage nodes available")
 	}
 
-	sortedNodes = make([]*storage_handler.Node, len(storageNodes))
-	i := 0
-	for _, node := range storageNodes {
-		sortedNodes[i] = node
-		i++
-	}
-
 	//sort the nodes by free storage
 	sort.Slice(sortedNodes, func(i, j int) bool {
 		return sortedNodes[i].GetFreeSpace() > sortedNodes[j].GetFreeSpace()
@@ -103,45 +185,123 @@ func (fd *FileDistributor) SortNodes() (sortedNodes []*storage_handler.Node, err
 	return sortedNodes, nil
 }
 
+// DistributeCopies adds replicas to every fragment until it has as many as its replication factor, on nodes
+// with room for them. The placement policy spreads the replicas of a fragment over as many failure domains as it can.
 func (fd *FileDistributor) DistributeCopies(chunkMap map[*Fragment][]*storage_handler.Node, nodes []*storage_handler.Node) (err error) {
-	//randomize the order of the nodes
-	randNodes := nodes
-	rand.Shuffle(len(randNodes), func(i, j int) { randNodes[i], randNodes[j] = randNodes[j], randNodes[i] })
+
+	if len(nodes) < fd.replicationFactor {
+		return fmt.Errorf("%d storage nodes available, replication factor is %d", len(nodes), fd.replicationFactor)
+	}
+
+	byId := make(map[string]*storage_handler.Node, len(nodes))
+	for _, node := range nodes {
+		byId[node.GetID()] = node
+	}
+	policy := fd.policy()
 
 	for chunk, nodeIDs := range chunkMap {
 
-		for {
-			node := randNodes[rand.Intn(len(randNodes))]
-			if qualifiesToHoldCopy(nodeIDs, node.GetID()) {
-				nodeIDs = append(nodeIDs, node)
-				chunkMap[chunk] = nodeIDs
+		holders := make([]placement.Node, len(nodeIDs))
+		for i, node := range nodeIDs {
+			holders[i] = node.Placement()
+		}
 
-			}
-			if len(nodeIDs) == 3 {
-				//randomize the order of the nodes
-				rand.Shuffle(len(chunkMap[chunk]), func(i, j int) { chunkMap[chunk][i], chunkMap[chunk][j] = chunkMap[chunk][j], chunkMap[chunk][i] })
-				break
-			}
+		for _, chosen := range placement.Place(policy, chunk.fragName, fd.replicationFactor-len(nodeIDs), holders, roomFor(nodes, chunk.fragSize)) {
+			node := byId[chosen.ID]
+			nodeIDs = append(nodeIDs, node)
+			node.SetFreeSpace(node.GetFreeSpace() - chunk.fragSize)
+		}
+		if len(nodeIDs) < fd.replicationFactor {
+			return storage_handler.ErrInsufficientSpace
 		}
+
+		//randomize the order of the nodes
+		rand.Shuffle(len(nodeIDs), func(i, j int) { nodeIDs[i], nodeIDs[j] = nodeIDs[j], nodeIDs[i] })
+		chunkMap[chunk] = nodeIDs
 	}
 	return
 }
 
-func qualifiesToHoldCopy(nodes []*storage_handler.Node, id string) bool {
+// DistributeShards places every shard of a group on a different node, so a group survives the loss of as many
+// nodes as it has parity shards. The placement policy spreads the shards of a group over as many failure domains as it can.
+func (fd *FileDistributor) DistributeShards(nodes []*storage_handler.Node) (chunkMap map[*Fragment][]*storage_handler.Node, err error) {
 
-	if len(nodes) < 3 {
+	layout := fd.ErasureLayout()
+	if fd.fileSize == 0 {
+		return nil, errors.New("file size is 0")
+	}
+	if len(nodes) < layout.Shards() {
+		return nil, fmt.Errorf("%d storage nodes available, erasure coding needs %d", len(nodes), layout.Shards())
+	}
+	if len(layout.Blocks) != layout.Groups()*layout.Shards() {
+		return nil, fmt.Errorf("file has %d shards, %d blocks recorded", layout.Groups()*layout.Shards(), len(layout.Blocks))
+	}
 
-		for _, node := range nodes {
-			if node.GetID() == id {
-				return false
+	byId := make(map[string]*storage_handler.Node, len(nodes))
+	for _, node := range nodes {
+		byId[node.GetID()] = node
+	}
+	policy := fd.policy()
+
+	chunkMap = make(map[*Fragment][]*storage_handler.Node)
+	for group := 0; group < layout.Groups(); group++ {
+
+		//free space changes with every group placed, the first shard is as long as any
+		candidates := roomFor(nodes, layout.ShardLength(group, 0))
+		chosen := placement.Place(policy, layout.Block(group, 0), layout.Shards(), nil, candidates)
+		if len(chosen) < layout.Shards() {
+			return nil, storage_handler.ErrInsufficientSpace
+		}
+
+		for shard := 0; shard < layout.Shards(); shard++ {
+			fragment := &Fragment{
+				fragName: layout.Block(group, shard),
+				fragSize: layout.ShardLength(group, shard),
+				index:    layout.Index(group, shard),
 			}
+			node := byId[chosen[shard].ID]
+			chunkMap[fragment] = []*storage_handler.Node{node}
+			node.SetFreeSpace(node.GetFreeSpace() - fragment.fragSize)
 		}
-		return true
+	}
+	return
+}
 
-	} else {
-		return false
+// roomFor describes the nodes with room for bytes more to the placement policy.
+func roomFor(nodes []*storage_handler.Node, bytes int64) (candidates []placement.Node) {
+
+	for _, node := range nodes {
+		if node.GetFreeSpace() >= bytes {
+			candidates = append(candidates, node.Placement())
+		}
+	}
+	return
+}
+
+// policy returns the placement policy set for the file, else the one of the storage system, else a random one.
+func (fd *FileDistributor) policy() placement.Policy {
+	if fd.placementPolicy != nil {
+		return fd.placementPolicy
+	}
+	if fd.storageSys == nil {
+		return placement.NewRandom(time.Now().UnixNano())
 	}
+	return fd.storageSys.PlacementPolicy()
+}
+
+// Reservations lists the space a plan takes on each of its nodes.
+func Reservations(chunkMap map[*Fragment][]*storage_handler.Node) (reservations []storage_handler.Reservation) {
 
+	for fragment, nodes := range chunkMap {
+		for _, node := range nodes {
+			reservations = append(reservations, storage_handler.Reservation{
+				NodeId:   node.GetID(),
+				Fragment: fragment.fragName,
+				Bytes:    fragment.fragSize,
+			})
+		}
+	}
+	return
 }
 
 // create a map of storage nodes and their available storage
@@ -153,6 +313,8 @@ func (fd *FileDistributor) DistributeFile() (chunkMap map[*Fragment][]*storage_h
 
 	if nodes, err := fd.SortNodes(); err != nil {
 		return nil, err
+	} else if fd.dataShards != 0 {
+		return fd.DistributeShards(nodes)
 	} else {
 
 		//chunkMap to be assigned to the file
@@ -164,15 +326,22 @@ func (fd *FileDistributor) DistributeFile() (chunkMap map[*Fragment][]*storage_h
 			return nil, err
 		}
 
-		//TODO: check if SetFreeSpace is creates race conditions
+		byId := make(map[string]*storage_handler.Node, len(nodes))
+		for _, node := range nodes {
+			byId[node.GetID()] = node
+		}
+		policy := fd.policy()
+
 		for _, fragment := range fragments {
-			for _, node := range nodes {
-				if node.GetFreeSpace() >= fragment.fragSize {
-					chunkMap[fragment] = append(chunkMap[fragment], node)
-					node.SetFreeSpace(node.GetFreeSpace() - fragment.fragSize)
-					break
-				}
+
+			//the first replica goes to a node with room for it, picked by the policy
+			chosen := placement.Place(policy, fragment.fragName, 1, nil, roomFor(nodes, fragment.fragSize))
+			if len(chosen) == 0 {
+				return nil, storage_handler.ErrInsufficientSpace
 			}
+			node := byId[chosen[0].ID]
+			chunkMap[fragment] = append(chunkMap[fragment], node)
+			node.SetFreeSpace(node.GetFreeSpace() - fragment.fragSize)
 		}
 
 		if err := fd.DistributeCopies(chunkMap, nodes); err != nil {
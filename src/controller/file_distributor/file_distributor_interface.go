@@ -1,9 +1,13 @@
 package file_distributor
 
-import "src/controller/storage_handler"
+import (
+	"src/controller/placement"
+	"src/controller/storage_handler"
+)
 
 type FileDistributorInterface interface {
 	DistributeFile() (map[*Fragment][]*storage_handler.Node, error) //map of chunk name to storage node IDs
+	SetPlacementPolicy(policy placement.Policy)
 	sliceOfFragments() ([]*Fragment, error)
 	SortNodes() ([]*storage_handler.Node, error)
 }
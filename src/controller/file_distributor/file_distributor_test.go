@@ -0,0 +1,188 @@
+package file_distributor
+
+import (
+	"src/controller/storage_handler"
+	"strconv"
+	"testing"
+)
+
+func TestFileDistributor_DistributeCopies(t *testing.T) {
+	tests := []struct {
+		name              string
+		numNodes          int
+		replicationFactor int
+		wantErr           bool
+	}{
+		{
+			name:              "Test scratch data with 1 replica",
+			numNodes:          3,
+			replicationFactor: 1,
+		},
+		{
+			name:              "Test default of 3 replicas",
+			numNodes:          3,
+			replicationFactor: 3,
+		},
+		{
+			name:              "Test critical data with 5 replicas",
+			numNodes:          6,
+			replicationFactor: 5,
+		},
+		{
+			name:              "Test not enough nodes",
+			numNodes:          2,
+			replicationFactor: 3,
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := make([]*storage_handler.Node, tt.numNodes)
+			for i := range nodes {
+				nodes[i] = &storage_handler.Node{ID: strconv.Itoa(i)}
+			}
+
+			chunkMap := map[*Fragment][]*storage_handler.Node{
+				{fragName: "file_0"}: {nodes[0]},
+				{fragName: "file_1"}: {nodes[1]},
+			}
+
+			fd := &FileDistributor{replicationFactor: tt.replicationFactor}
+			err := fd.DistributeCopies(chunkMap, nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DistributeCopies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for frag, holders := range chunkMap {
+				if len(holders) != tt.replicationFactor {
+					t.Errorf("%s has %d replicas, want %d", frag.fragName, len(holders), tt.replicationFactor)
+				}
+				seen := make(map[string]bool)
+				for _, node := range holders {
+					if seen[node.GetID()] {
+						t.Errorf("%s placed twice on node %s", frag.fragName, node.GetID())
+					}
+					seen[node.GetID()] = true
+				}
+			}
+		})
+	}
+}
+
+func TestFileDistributor_DistributeCopiesAcrossRacks(t *testing.T) {
+	racks := []string{"r1", "r1", "r1", "r2", "r2", "r3"}
+	nodes := make([]*storage_handler.Node, len(racks))
+	for i, rack := range racks {
+		nodes[i] = &storage_handler.Node{ID: strconv.Itoa(i)}
+		nodes[i].SetRack(rack)
+	}
+
+	chunkMap := map[*Fragment][]*storage_handler.Node{
+		{fragName: "file_0"}: {nodes[0]},
+		{fragName: "file_1"}: {nodes[3]},
+	}
+
+	fd := &FileDistributor{replicationFactor: 3}
+	if err := fd.DistributeCopies(chunkMap, nodes); err != nil {
+		t.Fatalf("DistributeCopies() error = %v", err)
+	}
+
+	for frag, holders := range chunkMap {
+		seen := make(map[string]bool)
+		for _, node := range holders {
+			if seen[node.GetRack()] {
+				t.Errorf("%s placed twice on rack %s", frag.fragName, node.GetRack())
+			}
+			seen[node.GetRack()] = true
+		}
+	}
+}
+
+func TestFileDistributor_DistributeShards(t *testing.T) {
+	tests := []struct {
+		name         string
+		numNodes     int
+		fileSize     int64
+		dataShards   int
+		parityShards int
+		wantShards   int
+		wantErr      bool
+	}{
+		{
+			name:         "Test single group",
+			numNodes:     3,
+			fileSize:     100,
+			dataShards:   2,
+			parityShards: 1,
+			wantShards:   3,
+		},
+		{
+			name:         "Test several groups with a short last group",
+			numNodes:     8,
+			fileSize:     250,
+			dataShards:   4,
+			parityShards: 2,
+			wantShards:   18,
+		},
+		{
+			name:         "Test not enough nodes",
+			numNodes:     5,
+			fileSize:     100,
+			dataShards:   4,
+			parityShards: 2,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := make([]*storage_handler.Node, tt.numNodes)
+			for i := range nodes {
+				nodes[i] = &storage_handler.Node{ID: strconv.Itoa(i)}
+				nodes[i].SetFreeSpace(1000)
+			}
+
+			fd := &FileDistributor{fileName: "file", fileSize: tt.fileSize, fragmentSize: 100}
+			fd.SetErasureCoding(tt.dataShards, tt.parityShards)
+			chunkMap, err := fd.DistributeShards(nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DistributeShards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(chunkMap) != tt.wantShards {
+				t.Fatalf("DistributeShards() planned %d shards, want %d", len(chunkMap), tt.wantShards)
+			}
+
+			layout := fd.ErasureLayout()
+			groups := make(map[int]map[string]bool)
+			var stored int64
+			for frag, holders := range chunkMap {
+				if len(holders) != 1 {
+					t.Errorf("%s placed on %d nodes, want 1", frag.fragName, len(holders))
+					continue
+				}
+				if layout.Block(layout.Locate(frag.index)) != frag.fragName {
+					t.Errorf("%s planned as shard %d", frag.fragName, frag.index)
+				}
+				group, shard := layout.Locate(frag.index)
+				if groups[group] == nil {
+					groups[group] = make(map[string]bool)
+				}
+				if groups[group][holders[0].GetID()] {
+					t.Errorf("two shards of group %d placed on node %s", group, holders[0].GetID())
+				}
+				groups[group][holders[0].GetID()] = true
+				if shard < tt.dataShards {
+					stored += frag.fragSize
+				}
+			}
+			if stored != tt.fileSize {
+				t.Errorf("data shards hold %d bytes, want %d", stored, tt.fileSize)
+			}
+		})
+	}
+}
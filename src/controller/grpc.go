@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"src/controller/storage_handler"
+	clientMessages "src/messages/controller_client"
+	"time"
+)
+
+// controllerService serves ControllerService over gRPC. Every call is handed to handleClient over an in-memory
+// connection, so requests are served by the same code whichever transport they came in on.
+type controllerService struct {
+	clientMessages.UnimplementedControllerServiceServer
+	ctx          context.Context
+	spokeHandler *storage_handler.StorageNodeHandler
+	logger       *zap.Logger
+}
+
+// serveGRPC serves ControllerService on listener until ctx is cancelled.
+func serveGRPC(ctx context.Context, listener net.Listener, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+
+	server := grpc.NewServer()
+	clientMessages.RegisterControllerServiceServer(server, &controllerService{ctx: ctx, spokeHandler: spokeHandler, logger: logger})
+
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+
+	err := server.Serve(listener)
+	if err != nil && ctx.Err() == nil {
+		logger.Error("gRPC server stopped", zap.Error(err))
+	}
+}
+
+// call runs one request through handleClient and returns its answer. The call's deadline and cancellation
+// end the request like a dropped TCP connection would.
+func (s *controllerService) call(ctx context.Context, req *clientMessages.ClientMessage) (*clientMessages.ControllerMessage, error) {
+
+	clientConn, serverConn := net.Pipe()
+
+	serverHandler := clientMessages.NewMessageHandler(serverConn)
+	serverHandler.SetTimeouts(CLIENT_TIMEOUT*time.Second, CLIENT_TIMEOUT*time.Second)
+	go handleClient(s.ctx, serverHandler, s.spokeHandler, s.logger)
+
+	msgHandler := clientMessages.NewMessageHandler(clientConn)
+	defer msgHandler.Close()
+	defer closeOnDone(ctx, msgHandler.Close)()
+
+	err := msgHandler.ClientRequestSend(req)
+	var res *clientMessages.ControllerMessage
+	if err == nil {
+		res, err = msgHandler.ControllerResponseReceive()
+	}
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+func unexpectedResponse(res *clientMessages.ControllerMessage) error {
+	return status.Errorf(codes.Internal, "unexpected response %T", res.GetControllerMessage())
+}
+
+func (s *controllerService) Plan(ctx context.Context, req *clientMessages.ClientMessage_PutRequest) (*clientMessages.ControllerMessage_PlanResponse, error) {
+
+	//the TCP protocol tells requests apart by their rest option
+	req.RestOption = clientMessages.ClientMessage_PUT
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_PutRequest_{PutRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetPlanResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetPlanResponse(), nil
+}
+
+func (s *controllerService) Layout(ctx context.Context, req *clientMessages.ClientMessage_GetRequest) (*clientMessages.ControllerMessage_FragLayoutResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_GET
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_GetRequest_{GetRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetFragLayoutResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetFragLayoutResponse(), nil
+}
+
+func (s *controllerService) Commit(ctx context.Context, req *clientMessages.ClientMessage_CommitRequest) (*clientMessages.ControllerMessage_CommitResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_COMMIT
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_CommitRequest_{CommitRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetCommitResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetCommitResponse(), nil
+}
+
+func (s *controllerService) List(ctx context.Context, req *clientMessages.ClientMessage_LsRequest) (*clientMessages.ControllerMessage_LsResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_LS
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_LsRequest_{LsRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetLsResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetLsResponse(), nil
+}
+
+func (s *controllerService) Delete(ctx context.Context, req *clientMessages.ClientMessage_DeleteRequest) (*clientMessages.ControllerMessage_DeleteResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_DELETE
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_DeleteRequest_{DeleteRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetDeleteResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetDeleteResponse(), nil
+}
+
+func (s *controllerService) Stats(ctx context.Context, req *clientMessages.ClientMessage_NodeStatsRequest) (*clientMessages.ControllerMessage_NodeStats, error) {
+
+	req.RestOption = clientMessages.ClientMessage_NODE_STATS
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_NodeStatsRequest_{NodeStatsRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetNodeStats() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetNodeStats(), nil
+}
+
+func (s *controllerService) Mkdir(ctx context.Context, req *clientMessages.ClientMessage_MkdirRequest) (*clientMessages.ControllerMessage_NamespaceResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_MKDIR
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_MkdirRequest_{MkdirRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetNamespaceResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetNamespaceResponse(), nil
+}
+
+func (s *controllerService) Rmdir(ctx context.Context, req *clientMessages.ClientMessage_RmdirRequest) (*clientMessages.ControllerMessage_NamespaceResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_RMDIR
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_RmdirRequest_{RmdirRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetNamespaceResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetNamespaceResponse(), nil
+}
+
+func (s *controllerService) Rename(ctx context.Context, req *clientMessages.ClientMessage_RenameRequest) (*clientMessages.ControllerMessage_NamespaceResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_RENAME
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_RenameRequest_{RenameRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetNamespaceResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetNamespaceResponse(), nil
+}
+
+func (s *controllerService) Stat(ctx context.Context, req *clientMessages.ClientMessage_StatRequest) (*clientMessages.ControllerMessage_StatResponse, error) {
+
+	req.RestOption = clientMessages.ClientMessage_STAT
+	res, err := s.call(ctx, &clientMessages.ClientMessage{ClientMessage: &clientMessages.ClientMessage_StatRequest_{StatRequest: req}})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetStatResponse() == nil {
+		return nil, unexpectedResponse(res)
+	}
+	return res.GetStatResponse(), nil
+}
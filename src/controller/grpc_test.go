@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"src/controller/metadata"
+	"src/controller/storage_handler"
+	clientMessages "src/messages/controller_client"
+	storageNodeMessages "src/messages/controller_storage"
+	storageNodeProto3 "src/proto/controller_storage"
+	"testing"
+	"time"
+)
+
+// fakeNodeSpace is the free space a fakeNode reports.
+const fakeNodeSpace = 1 << 30
+
+// fakeNode is a storage node session that only reports the fragments it is told it holds and acknowledges
+// the commands pushed to it.
+type fakeNode struct {
+	id      string
+	reports chan []string
+}
+
+// startFakeNode introduces a node to spokeHandler over an in-memory connection.
+func startFakeNode(ctx context.Context, id string, spokeHandler *storage_handler.StorageNodeHandler) *fakeNode {
+
+	controllerConn, nodeConn := net.Pipe()
+	go handleStorageNode(ctx, storageNodeMessages.NewMessageHandler(controllerConn), spokeHandler, zap.NewNop())
+
+	node := &fakeNode{id: id, reports: make(chan []string)}
+	proto := storageNodeProto3.NewProtoHandler(storageNodeMessages.NewMessageHandler(nodeConn))
+
+	//only this goroutine writes to the connection
+	go func() {
+		defer nodeConn.Close()
+		//the node opens the connection, so it speaks first
+		proto.HandleIntroRequest(id, "0", "localhost", "")
+		responses := make(chan storageNodeProto3.Response)
+		go func() {
+			for {
+				wrapper, err := proto.MsgHandler().ServerResponseReceive()
+				if err != nil {
+					close(responses)
+					return
+				}
+				select {
+				case responses <- proto.HandleControllerResponse(wrapper):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		seq := uint64(1)
+		proto.SendHeartbeatRequest(id, fakeNodeSpace, 0, storageNodeProto3.BlockReport{Seq: seq, Full: true})
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case files := <-node.reports:
+				seq++
+				proto.SendHeartbeatRequest(id, fakeNodeSpace, 0, storageNodeProto3.BlockReport{Seq: seq, Full: true, Files: files})
+			case res, ok := <-responses:
+				if !ok {
+					return
+				}
+				if del, ok := res.(*storageNodeProto3.DeleteRequest); ok {
+					proto.SendCommandAck(del.CommandId, nil)
+				}
+			}
+		}
+	}()
+	return node
+}
+
+func TestControllerService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := metadata.Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	spokeHandler := storage_handler.NewStorageNodeHandler(zap.NewNop())
+	spokeHandler.SetMetadataStore(store, 0)
+
+	var nodes []*fakeNode
+	for i := 1; i <= storage_handler.DEFAULT_REPLICATION_FACTOR; i++ {
+		nodes = append(nodes, startFakeNode(ctx, fmt.Sprintf("node%d", i), spokeHandler))
+	}
+	waitFor(t, "the nodes to register", func() bool {
+		registered := 0
+		for _, node := range spokeHandler.GetNodeInfo().(map[string]storage_handler.Node) {
+			if node.GetFreeSpace() > 0 {
+				registered++
+			}
+		}
+		return registered == len(nodes)
+	})
+
+	ln := bufconn.Listen(1024 * 1024)
+	go serveGRPC(ctx, ln, spokeHandler, zap.NewNop())
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	client := clientMessages.NewControllerServiceClient(conn)
+
+	//the file is planned, its fragments reported by the nodes and committed before the calls below
+	plan, err := client.Plan(ctx, &clientMessages.ClientMessage_PutRequest{Filename: "/dir/file", Filesize: 100})
+	if err != nil || plan.StatusCode != clientMessages.ControllerMessage_OK {
+		t.Fatalf("Plan() = %v, %v, want OK", plan, err)
+	}
+	held := make(map[string][]string)
+	for _, frag := range plan.FragmentLayout {
+		for _, node := range frag.StorageNodeIds {
+			held[node.StorageNodeId] = append(held[node.StorageNodeId], frag.FragmentId)
+		}
+	}
+	for _, node := range nodes {
+		node.reports <- held[node.id]
+	}
+	waitFor(t, "the fragments to be reported", func() bool {
+		return len(spokeHandler.LocateFragments("dir/file")) == len(plan.FragmentLayout)
+	})
+	commit, err := client.Commit(ctx, &clientMessages.ClientMessage_CommitRequest{Filename: "/dir/file"})
+	if err != nil || commit.StatusCode != clientMessages.ControllerMessage_OK {
+		t.Fatalf("Commit() = %v, %v, want OK", commit, err)
+	}
+
+	tests := []struct {
+		name string
+		call func() (clientMessages.ControllerMessage_StatusCode, error)
+		want clientMessages.ControllerMessage_StatusCode
+	}{
+		{
+			name: "Test put an existing file",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Plan(ctx, &clientMessages.ClientMessage_PutRequest{Filename: "dir/file", Filesize: 100})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_FILE_ALREADY_EXISTS,
+		},
+		{
+			name: "Test put with too many replicas",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Plan(ctx, &clientMessages.ClientMessage_PutRequest{Filename: "other", Filesize: 100, ReplicationFactor: 100})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_INVALID_REPLICATION_FACTOR,
+		},
+		{
+			name: "Test put under a file",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Plan(ctx, &clientMessages.ClientMessage_PutRequest{Filename: "dir/file/other", Filesize: 100})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_NOT_A_DIRECTORY,
+		},
+		{
+			name: "Test stat a file",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Stat(ctx, &clientMessages.ClientMessage_StatRequest{Filename: "dir/file"})
+				if err == nil && res.Size != 100 {
+					return res.GetStatusCode(), fmt.Errorf("size = %d, want 100", res.Size)
+				}
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_OK,
+		},
+		{
+			name: "Test stat a missing file",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Stat(ctx, &clientMessages.ClientMessage_StatRequest{Filename: "missing"})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_FILE_NOT_FOUND,
+		},
+		{
+			name: "Test list a directory",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.List(ctx, &clientMessages.ClientMessage_LsRequest{Path: "dir"})
+				if err == nil && (len(res.Entries) != 1 || res.Entries[0].Name != "file") {
+					return res.GetStatusCode(), fmt.Errorf("entries = %v, want file", res.Entries)
+				}
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_OK,
+		},
+		{
+			name: "Test list with a bad pattern",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.List(ctx, &clientMessages.ClientMessage_LsRequest{Path: "dir", Pattern: "["})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_INVALID_PATTERN,
+		},
+		{
+			name: "Test delete a file",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Delete(ctx, &clientMessages.ClientMessage_DeleteRequest{Filename: "dir/file"})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_OK,
+		},
+		{
+			name: "Test stat a deleted file",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Stat(ctx, &clientMessages.ClientMessage_StatRequest{Filename: "dir/file"})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_FILE_NOT_FOUND,
+		},
+		{
+			name: "Test delete a missing file",
+			call: func() (clientMessages.ControllerMessage_StatusCode, error) {
+				res, err := client.Delete(ctx, &clientMessages.ClientMessage_DeleteRequest{Filename: "dir/file"})
+				return res.GetStatusCode(), err
+			},
+			want: clientMessages.ControllerMessage_FILE_NOT_FOUND,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call()
+			if err != nil {
+				t.Fatalf("call error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("status = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// waitFor polls cond until it holds, and fails the test if it does not within a few seconds.
+func waitFor(t *testing.T, what string, cond func() bool) {
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
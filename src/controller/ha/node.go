@@ -0,0 +1,609 @@
+// Package ha keeps the Controller available when it fails. A group of controllers elects one of them active,
+// and only that one serves clients and storage nodes. The others are standbys: they apply every metadata
+// record the active controller commits, and a standby that missed some is sent a snapshot of the whole
+// metadata. A change to the metadata only succeeds once a majority of the group applied it.
+//
+// Controllers tell each other their term, role and last metadata record every heartbeat interval. When the
+// active controller is not heard from for an election timeout, a standby starts a new term and asks the others
+// for their votes. A controller votes once per term, and only for one whose last record is at least as recent
+// as its own, so whoever wins holds every change that succeeded. Winning takes the votes of a majority, and an
+// active controller that loses touch with a majority steps down. A group of three therefore keeps working when
+// any one controller is lost, and controllers cut off from the majority stop serving rather than diverge.
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"src/controller/metadata"
+	messages "src/messages/controller_controller"
+	"sync"
+	"time"
+)
+
+const (
+	// DEFAULT_HEARTBEAT_INTERVAL is how often controllers tell each other their status.
+	DEFAULT_HEARTBEAT_INTERVAL = 1 * time.Second
+	// DEFAULT_ELECTION_TIMEOUT is how long a standby goes without hearing from an active controller before it
+	// holds an election. It is also how long the active controller goes without hearing from a majority before
+	// it steps down, and how long a change waits for a majority to apply it.
+	DEFAULT_ELECTION_TIMEOUT = 5 * time.Second
+	// SNAPSHOT_TIMEOUT is how long sending a snapshot of the metadata to a standby may take.
+	SNAPSHOT_TIMEOUT = 60 * time.Second
+	// MAX_MESSAGE_SIZE is the largest message controllers accept, large enough for a snapshot.
+	MAX_MESSAGE_SIZE = 1 << 30
+	// RECENT_RECORDS is how many of the last records the active controller keeps, to send after a snapshot the
+	// ones committed while it was taken.
+	RECENT_RECORDS = 4096
+	// ELECTION_FILE keeps the term of the controller and whom it voted for in it, next to the metadata.
+	ELECTION_FILE = "election.json"
+)
+
+var ErrUnknownController = errors.New("controller is not in the list of controllers")
+
+// peer is another controller of the group, and what it last said about itself.
+type peer struct {
+	id string
+	//its position in the list of controllers, the lower the more it is preferred when electing
+	rank   int
+	status *messages.PeerMessage_Status
+	heard  time.Time
+}
+
+// election is what a controller remembers across restarts, so it never votes twice in a term.
+type election struct {
+	Term     uint64 `json:"term"`
+	VotedFor string `json:"voted_for,omitempty"`
+}
+
+// Node is a controller of a highly available group.
+type Node struct {
+	id    string
+	rank  int
+	peers map[string]*peer
+	store *metadata.Store
+
+	heartbeat       time.Duration
+	electionTimeout time.Duration
+
+	//the context Run was called with, terms as active controller are cancelled with it
+	ctx    context.Context
+	logger *zap.Logger
+
+	mutex    sync.Mutex
+	term     uint64
+	votedFor string
+	active   bool
+	//cancelled once the controller stops being active
+	activeCtx context.Context
+	stepDown  context.CancelFunc
+	//closed and replaced every time the controller becomes active or steps down
+	changed chan struct{}
+	//when an active controller was last heard from
+	lastActive time.Time
+
+	//guards what is shipped to the standbys, replCond is broadcast when a standby applied records or the
+	//controller stepped down
+	replMutex sync.Mutex
+	replCond  *sync.Cond
+	standbys  map[string]*standby
+	//the last records committed in recentTerm
+	recent     []*metadata.Record
+	recentTerm uint64
+}
+
+// NewNode returns the controller reached on id, one of controllers. controllers lists every controller of the
+// group, the same way on all of them, in the order they are preferred when electing. The records committed to
+// store are shipped to the standbys while the controller is active.
+func NewNode(id string, controllers []string, store *metadata.Store, logger *zap.Logger) (n *Node, err error) {
+
+	n = &Node{
+		id:              id,
+		rank:            -1,
+		peers:           make(map[string]*peer),
+		store:           store,
+		heartbeat:       DEFAULT_HEARTBEAT_INTERVAL,
+		electionTimeout: DEFAULT_ELECTION_TIMEOUT,
+		logger:          logger.With(zap.String("controller", id)),
+		changed:         make(chan struct{}),
+		standbys:        make(map[string]*standby),
+	}
+	n.replCond = sync.NewCond(&n.replMutex)
+	for rank, controller := range controllers {
+		if controller == id {
+			n.rank = rank
+			continue
+		}
+		n.peers[controller] = &peer{id: controller, rank: rank}
+		n.standbys[controller] = &standby{id: controller, wake: make(chan struct{}, 1)}
+	}
+	if n.rank < 0 {
+		return nil, ErrUnknownController
+	}
+
+	state := &election{}
+	data, err := os.ReadFile(filepath.Join(store.Dir(), ELECTION_FILE))
+	if err == nil {
+		err = json.Unmarshal(data, state)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	n.term, n.votedFor = state.Term, state.VotedFor
+
+	store.SetReplicator(n)
+	return n, nil
+}
+
+// SetIntervals sets how often controllers tell each other their status, and how long a standby waits on an
+// active controller before it holds an election. 0 keeps a default.
+func (n *Node) SetIntervals(heartbeat time.Duration, electionTimeout time.Duration) {
+
+	if heartbeat > 0 {
+		n.heartbeat = heartbeat
+	}
+	if electionTimeout > 0 {
+		n.electionTimeout = electionTimeout
+	}
+}
+
+// Run exchanges statuses with the other controllers, answers them on listener, and holds elections, until ctx
+// is cancelled. The controller starts as a standby.
+func (n *Node) Run(ctx context.Context, listener net.Listener) {
+
+	n.mutex.Lock()
+	n.ctx = ctx
+	//a controller that just started gives an active one the time to be heard from
+	n.lastActive = time.Now()
+	n.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	go n.accept(ctx, listener)
+
+	for id := range n.peers {
+		go n.exchangeStatus(ctx, id)
+		go n.replicate(ctx, n.standbys[id])
+	}
+
+	ticker := time.NewTicker(n.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			n.mutex.Lock()
+			n.demote("shutting down")
+			n.mutex.Unlock()
+			return
+		case <-ticker.C:
+			n.elect()
+		}
+	}
+}
+
+// WaitActive blocks until the controller is active, and returns a context that is cancelled once it steps
+// down. It fails when ctx is cancelled first.
+func (n *Node) WaitActive(ctx context.Context) (context.Context, error) {
+
+	for {
+		n.mutex.Lock()
+		if n.active {
+			activeCtx := n.activeCtx
+			n.mutex.Unlock()
+			return activeCtx, nil
+		}
+		changed := n.changed
+		n.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Active reports whether the controller is the active one, and its term.
+func (n *Node) Active() (active bool, term uint64) {
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.active, n.term
+}
+
+// majority is how many controllers, this one included, make a majority of the group.
+func (n *Node) majority() int {
+	return (len(n.peers)+1)/2 + 1
+}
+
+// live counts the controllers heard from within an election timeout, this one included. Callers hold the
+// mutex.
+func (n *Node) live() (count int) {
+
+	count = 1
+	for _, p := range n.peers {
+		if p.status != nil && time.Since(p.heard) <= n.electionTimeout {
+			count++
+		}
+	}
+	return
+}
+
+// status is what the controller tells the others about itself.
+func (n *Node) status() *messages.PeerMessage {
+
+	//the store is never locked while holding the mutex, Term is called with the store locked
+	lastTerm, seq := n.store.LastRecord()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return &messages.PeerMessage{PeerMessage: &messages.PeerMessage_Status_{Status: &messages.PeerMessage_Status{
+		ControllerId: n.id,
+		Term:         n.term,
+		Active:       n.active,
+		Seq:          seq,
+		LastTerm:     lastTerm,
+	}}}
+}
+
+// observe takes note of the status of another controller.
+func (n *Node) observe(status *messages.PeerMessage_Status) {
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	p, ok := n.peers[status.ControllerId]
+	if !ok {
+		n.logger.Warn("Status from an unknown controller", zap.String("from", status.ControllerId))
+		return
+	}
+	p.status, p.heard = status, time.Now()
+
+	n.adoptTerm(status.Term)
+	if status.Active && status.Term == n.term {
+		n.lastActive = time.Now()
+	}
+}
+
+// adoptTerm moves the controller to a term it heard of, and steps it down if that term is newer than its
+// own. Callers hold the mutex.
+func (n *Node) adoptTerm(term uint64) {
+
+	if term <= n.term {
+		return
+	}
+	n.term, n.votedFor = term, ""
+	n.save()
+	n.demote("a newer term started")
+}
+
+// save writes the term and the vote of the controller to disk, before it tells anyone of them. Callers hold
+// the mutex.
+func (n *Node) save() {
+
+	data, err := json.Marshal(&election{Term: n.term, VotedFor: n.votedFor})
+	if err != nil {
+		n.logger.Error("Error encoding the election state", zap.Error(err))
+		return
+	}
+
+	path := filepath.Join(n.store.Dir(), ELECTION_FILE)
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		n.logger.Error("Error saving the election state", zap.Error(err))
+		return
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		n.logger.Error("Error saving the election state", zap.Error(err))
+	}
+}
+
+// upToDate reports whether a last record of term lastTerm and sequence number seq is at least as recent as
+// the last record of the store, which then holds nothing the other may lack.
+func upToDate(lastTerm uint64, seq uint64, ownTerm uint64, ownSeq uint64) bool {
+	return lastTerm > ownTerm || (lastTerm == ownTerm && seq >= ownSeq)
+}
+
+// elect steps the active controller down when it no longer hears from a majority of the group. A standby that
+// heard from no active controller for an election timeout holds an election when it is the live controller
+// with the most recent records, the most preferred of them if several have as recent ones, and becomes active
+// if a majority votes for it.
+func (n *Node) elect() {
+
+	lastTerm, seq := n.store.LastRecord()
+
+	n.mutex.Lock()
+	if n.active {
+		if n.live() < n.majority() {
+			n.demote("lost touch with a majority of the controllers")
+		}
+		n.mutex.Unlock()
+		return
+	}
+	if time.Since(n.lastActive) < n.electionTimeout || n.live() < n.majority() {
+		n.mutex.Unlock()
+		return
+	}
+	for _, p := range n.peers {
+		if p.status == nil || time.Since(p.heard) > n.electionTimeout {
+			continue
+		}
+		if !upToDate(lastTerm, seq, p.status.LastTerm, p.status.Seq) ||
+			(p.status.LastTerm == lastTerm && p.status.Seq == seq && p.rank < n.rank) {
+			//the other controller holds the election
+			n.mutex.Unlock()
+			return
+		}
+	}
+
+	n.term, n.votedFor = n.term+1, n.id
+	n.save()
+	term := n.term
+	//an election that is not won is held again after another timeout, a random part of one later so that two
+	//controllers do not keep splitting the votes
+	n.lastActive = time.Now().Add(time.Duration(rand.Int63n(int64(n.electionTimeout))))
+	n.mutex.Unlock()
+
+	votes := 1 + n.requestVotes(term, lastTerm, seq)
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.term != term || votes < n.majority() {
+		n.logger.Info("Election lost", zap.Uint64("term", term), zap.Int("votes", votes))
+		return
+	}
+
+	n.active = true
+	n.activeCtx, n.stepDown = context.WithCancel(n.ctx)
+	n.notify()
+	n.logger.Info("Controller is active", zap.Uint64("term", n.term), zap.Uint64("seq", seq), zap.Int("votes", votes))
+
+	//the standbys are sent the metadata right away, changes only succeed once a majority has it
+	for _, sb := range n.standbys {
+		sb.poke()
+	}
+}
+
+// requestVotes asks every other controller for its vote in term, and returns how many voted for this one.
+func (n *Node) requestVotes(term uint64, lastTerm uint64, seq uint64) (granted int) {
+
+	req := &messages.PeerMessage{PeerMessage: &messages.PeerMessage_RequestVote_{RequestVote: &messages.PeerMessage_RequestVote{
+		Term:         term,
+		ControllerId: n.id,
+		Seq:          seq,
+		LastTerm:     lastTerm,
+	}}}
+
+	votes := make(chan *messages.PeerMessage_Vote, len(n.peers))
+	for id := range n.peers {
+		go func(id string) {
+			conn, err := n.dial(id)
+			if err != nil {
+				votes <- nil
+				return
+			}
+			defer conn.Close()
+			res, err := request(conn, req)
+			if err != nil {
+				votes <- nil
+				return
+			}
+			votes <- res.GetVote()
+		}(id)
+	}
+
+	for range n.peers {
+		vote := <-votes
+		if vote == nil {
+			continue
+		}
+		if vote.Granted {
+			granted++
+		}
+		n.mutex.Lock()
+		n.adoptTerm(vote.Term)
+		n.mutex.Unlock()
+	}
+	return
+}
+
+// vote answers a controller that holds an election.
+func (n *Node) vote(req *messages.PeerMessage_RequestVote) *messages.PeerMessage {
+
+	lastTerm, seq := n.store.LastRecord()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	vote := func(granted bool) *messages.PeerMessage {
+		return &messages.PeerMessage{PeerMessage: &messages.PeerMessage_Vote_{Vote: &messages.PeerMessage_Vote{
+			Term:    n.term,
+			Granted: granted,
+		}}}
+	}
+
+	//a controller that cannot hear the active one does not get to depose it while the others still do
+	if n.active || time.Since(n.lastActive) < n.electionTimeout/2 {
+		return vote(false)
+	}
+	n.adoptTerm(req.Term)
+	if req.Term != n.term || (n.votedFor != "" && n.votedFor != req.ControllerId) ||
+		!upToDate(req.LastTerm, req.Seq, lastTerm, seq) {
+		return vote(false)
+	}
+
+	n.votedFor = req.ControllerId
+	n.save()
+	n.lastActive = time.Now()
+	return vote(true)
+}
+
+// demote makes the controller a standby. Callers hold the mutex.
+func (n *Node) demote(reason string) {
+
+	if !n.active {
+		return
+	}
+	n.active = false
+	n.stepDown()
+	//the controller that took over is given the time to be heard from
+	n.lastActive = time.Now()
+	n.notify()
+	//changes waiting on the standbys fail, replMutex is not taken while holding the mutex
+	go n.broadcast()
+	n.logger.Info("Controller stepped down", zap.Uint64("term", n.term), zap.String("reason", reason))
+}
+
+// notify wakes up whoever waits on the role of the controller. Callers hold the mutex.
+func (n *Node) notify() {
+	close(n.changed)
+	n.changed = make(chan struct{})
+}
+
+// exchangeStatus tells another controller the status of this one every heartbeat interval, and takes note of
+// the status it answers with. Once active, a standby that is not in sync is sent the metadata.
+func (n *Node) exchangeStatus(ctx context.Context, id string) {
+
+	var conn *messages.MessageHandler
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(n.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if conn == nil {
+			var err error
+			conn, err = n.dial(id)
+			if err != nil {
+				continue
+			}
+		}
+
+		res, err := request(conn, n.status())
+		if err != nil || res.GetStatus() == nil {
+			conn.Close()
+			conn = nil
+			continue
+		}
+		n.observe(res.GetStatus())
+
+		if !res.GetStatus().Active {
+			n.standbys[id].poke()
+		}
+	}
+}
+
+// dial connects to another controller.
+func (n *Node) dial(id string) (*messages.MessageHandler, error) {
+
+	conn, err := net.DialTimeout("tcp", id, n.heartbeat)
+	if err != nil {
+		return nil, err
+	}
+	handler := messages.NewMessageHandler(conn)
+	handler.SetTimeouts(n.replicationTimeout(), n.replicationTimeout())
+	handler.SetMaxFrameSize(MAX_MESSAGE_SIZE)
+	return handler, nil
+}
+
+// replicationTimeout is how long another controller has to answer.
+func (n *Node) replicationTimeout() time.Duration {
+	return 2 * n.heartbeat
+}
+
+// request sends a message to another controller and returns its answer.
+func request(conn *messages.MessageHandler, msg *messages.PeerMessage) (*messages.PeerMessage, error) {
+
+	err := conn.Send(msg)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Receive()
+}
+
+// accept answers the controllers that connect to this one until ctx is cancelled.
+func (n *Node) accept(ctx context.Context, listener net.Listener) {
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			n.logger.Error("Error accepting a controller", zap.Error(err))
+			continue
+		}
+
+		handler := messages.NewMessageHandler(conn)
+		//records are only sent when the active controller commits some, so the connection may stay idle
+		handler.SetTimeouts(0, n.replicationTimeout())
+		handler.SetMaxFrameSize(MAX_MESSAGE_SIZE)
+		go n.serve(ctx, handler)
+	}
+}
+
+// serve answers the requests of another controller until it closes the connection.
+func (n *Node) serve(ctx context.Context, conn *messages.MessageHandler) {
+
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		msg, err := conn.Receive()
+		if err != nil {
+			return
+		}
+
+		var res *messages.PeerMessage
+		switch req := msg.PeerMessage.(type) {
+		case *messages.PeerMessage_Status_:
+			n.observe(req.Status)
+			res = n.status()
+		case *messages.PeerMessage_AppendRecords_:
+			res = n.appendRecords(req.AppendRecords)
+		case *messages.PeerMessage_InstallSnapshot_:
+			res = n.installSnapshot(req.InstallSnapshot)
+		case *messages.PeerMessage_RequestVote_:
+			res = n.vote(req.RequestVote)
+		default:
+			n.logger.Warn("Unknown message from a controller")
+			return
+		}
+
+		if conn.Send(res) != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package ha
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"net"
+	"src/controller/metadata"
+	messages "src/messages/controller_controller"
+	"testing"
+	"time"
+)
+
+// controller is a controller of a group running on localhost.
+type controller struct {
+	id    string
+	store *metadata.Store
+	node  *Node
+	stop  context.CancelFunc
+	done  chan struct{}
+}
+
+func startController(t *testing.T, id string, ids []string, store *metadata.Store) *controller {
+
+	listener, err := net.Listen("tcp", id)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	node, err := NewNode(id, ids, store, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewNode() error = %v", err)
+	}
+	node.SetIntervals(20*time.Millisecond, 200*time.Millisecond)
+
+	ctx, stop := context.WithCancel(context.Background())
+	c := &controller{id: id, store: store, node: node, stop: stop, done: make(chan struct{})}
+	go func() {
+		node.Run(ctx, listener)
+		close(c.done)
+	}()
+	return c
+}
+
+func (c *controller) kill() {
+	c.stop()
+	<-c.done
+}
+
+// waitFor polls cond until it holds, and fails the test if it does not within a few seconds.
+func waitFor(t *testing.T, what string, cond func() bool) {
+
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// activeOne returns the only active controller, nil if there is none or several.
+func activeOne(controllers []*controller) (active *controller) {
+
+	for _, c := range controllers {
+		if ok, _ := c.node.Active(); ok {
+			if active != nil {
+				return nil
+			}
+			active = c
+		}
+	}
+	return
+}
+
+func inSync(controllers []*controller, seq uint64) bool {
+	return applied(controllers, seq) == len(controllers)
+}
+
+// applied counts the controllers that applied the record seq.
+func applied(controllers []*controller, seq uint64) (count int) {
+
+	for _, c := range controllers {
+		if c.store.Seq() >= seq {
+			count++
+		}
+	}
+	return
+}
+
+// synced counts the standbys records are shipped to.
+func synced(c *controller) (count int) {
+
+	_, term := c.node.Active()
+	c.node.replMutex.Lock()
+	defer c.node.replMutex.Unlock()
+	for _, sb := range c.node.standbys {
+		if sb.term == term {
+			count++
+		}
+	}
+	return
+}
+
+// group starts n controllers on localhost, each with a store of its own.
+func group(t *testing.T, n int) (controllers []*controller, ids []string, stores []*metadata.Store) {
+
+	ids = make([]string, n)
+	stores = make([]*metadata.Store, n)
+	for i := range ids {
+		//the port is picked once and reused when the controller is restarted
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Listen() error = %v", err)
+		}
+		ids[i] = ln.Addr().String()
+		ln.Close()
+
+		store, err := metadata.Open(t.TempDir(), zap.NewNop())
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		stores[i] = store
+		t.Cleanup(func() { store.Close() })
+	}
+
+	controllers = make([]*controller, n)
+	for i, id := range ids {
+		controllers[i] = startController(t, id, ids, stores[i])
+	}
+	t.Cleanup(func() {
+		for _, c := range controllers {
+			c.kill()
+		}
+	})
+	return
+}
+
+func TestNode_Failover(t *testing.T) {
+
+	controllers, ids, stores := group(t, 3)
+
+	//every controller has as many records, the most preferred one is elected
+	waitFor(t, "an active controller", func() bool { return activeOne(controllers) != nil })
+	if active := activeOne(controllers); active != controllers[0] {
+		t.Fatalf("%s is active, want %s", active.id, ids[0])
+	}
+	waitFor(t, "the standbys to be in sync", func() bool { return synced(controllers[0]) == 2 })
+
+	if err := stores[0].CreateFile("dir/file", 10, 5, metadata.Redundancy{ReplicationFactor: 2}, []string{"b0", "b1"}, "upload", "alice"); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	stores[0].AddReplica("dir/file", "b0", "node1")
+
+	//a change succeeds once a majority applied it
+	if err := stores[0].Commit("dir/file", "9f86d0"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if got := applied(controllers, stores[0].Seq()); got < 2 {
+		t.Errorf("%d controllers applied the commit when it returned, want at least 2", got)
+	}
+	waitFor(t, "the standbys to apply every record", func() bool { return inSync(controllers, stores[0].Seq()) })
+
+	controllers[0].kill()
+	waitFor(t, "a standby to take over", func() bool { return activeOne(controllers[1:]) != nil })
+	if active := activeOne(controllers[1:]); active != controllers[1] {
+		t.Fatalf("%s took over, want %s", active.id, ids[1])
+	}
+	if _, term := controllers[1].node.Active(); term < 2 {
+		t.Errorf("term = %d after the failover, want at least 2", term)
+	}
+	if state, _ := stores[1].State("dir/file"); state != metadata.StateCommitted {
+		t.Errorf("file is %q on the controller that took over, want %q", state, metadata.StateCommitted)
+	}
+
+	//the restarted controller missed a record and comes back as a standby
+	waitFor(t, "the other standby to be in sync", func() bool { return synced(controllers[1]) == 1 })
+	if err := stores[1].Mkdir("other"); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+	controllers[0] = startController(t, ids[0], ids, stores[0])
+	waitFor(t, "the restarted controller to catch up", func() bool { return inSync(controllers, stores[1].Seq()) })
+	if activeOne(controllers) != controllers[1] {
+		t.Errorf("restarted controller took over again")
+	}
+	if _, err := stores[0].List("other"); err != nil {
+		t.Errorf("List() on the restarted controller error = %v", err)
+	}
+}
+
+func TestNode_Quorum(t *testing.T) {
+
+	controllers, _, stores := group(t, 3)
+	waitFor(t, "an active controller", func() bool { return activeOne(controllers) == controllers[0] })
+	waitFor(t, "the standbys to be in sync", func() bool { return synced(controllers[0]) == 2 })
+
+	//one standby is a majority with the active controller
+	controllers[2].kill()
+	if err := stores[0].Mkdir("kept"); err != nil {
+		t.Fatalf("Mkdir() with one standby lost error = %v", err)
+	}
+
+	//a change no majority applied fails, and the controller stops serving
+	controllers[1].kill()
+	if err := stores[0].Mkdir("lost"); err == nil {
+		t.Errorf("Mkdir() without a majority succeeded")
+	}
+	waitFor(t, "the controller to step down", func() bool { active, _ := controllers[0].node.Active(); return !active })
+	if err := stores[0].Mkdir("refused"); err != ErrNotActive {
+		t.Errorf("Mkdir() on a standby error = %v, want %v", err, ErrNotActive)
+	}
+
+	//alone, it is not elected again
+	time.Sleep(3 * controllers[0].node.electionTimeout)
+	if active, _ := controllers[0].node.Active(); active {
+		t.Errorf("controller without a majority was elected")
+	}
+}
+
+func TestNode_vote(t *testing.T) {
+
+	ids := []string{"controller1:1", "controller2:1", "controller3:1"}
+
+	tests := []struct {
+		name string
+		//the vote the controller already cast in term 3, and whether it hears from an active controller
+		votedFor    string
+		heardActive bool
+		active      bool
+		req         *messages.PeerMessage_RequestVote
+		wantGranted bool
+		wantTerm    uint64
+	}{
+		{
+			name:        "Test candidate as recent",
+			req:         &messages.PeerMessage_RequestVote{Term: 4, ControllerId: ids[1], LastTerm: 2, Seq: 3},
+			wantGranted: true,
+			wantTerm:    4,
+		},
+		{
+			name:        "Test candidate with a record of a later term",
+			req:         &messages.PeerMessage_RequestVote{Term: 4, ControllerId: ids[1], LastTerm: 3, Seq: 1},
+			wantGranted: true,
+			wantTerm:    4,
+		},
+		{
+			name:     "Test candidate missing a record",
+			req:      &messages.PeerMessage_RequestVote{Term: 4, ControllerId: ids[1], LastTerm: 2, Seq: 2},
+			wantTerm: 4,
+		},
+		{
+			name:     "Test candidate with more records of an earlier term",
+			req:      &messages.PeerMessage_RequestVote{Term: 4, ControllerId: ids[1], LastTerm: 1, Seq: 9},
+			wantTerm: 4,
+		},
+		{
+			name:     "Test stale term",
+			req:      &messages.PeerMessage_RequestVote{Term: 2, ControllerId: ids[1], LastTerm: 2, Seq: 3},
+			wantTerm: 3,
+		},
+		{
+			name:     "Test voted for another in the term",
+			votedFor: ids[2],
+			req:      &messages.PeerMessage_RequestVote{Term: 3, ControllerId: ids[1], LastTerm: 2, Seq: 3},
+			wantTerm: 3,
+		},
+		{
+			name:        "Test voted for the same in the term",
+			votedFor:    ids[1],
+			req:         &messages.PeerMessage_RequestVote{Term: 3, ControllerId: ids[1], LastTerm: 2, Seq: 3},
+			wantGranted: true,
+			wantTerm:    3,
+		},
+		{
+			name:        "Test active controller heard from",
+			heardActive: true,
+			req:         &messages.PeerMessage_RequestVote{Term: 4, ControllerId: ids[1], LastTerm: 2, Seq: 3},
+			wantTerm:    3,
+		},
+		{
+			name:     "Test active",
+			active:   true,
+			req:      &messages.PeerMessage_RequestVote{Term: 4, ControllerId: ids[1], LastTerm: 2, Seq: 3},
+			wantTerm: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := metadata.Open(t.TempDir(), zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer store.Close()
+			//the last record was committed in term 2
+			for seq, dir := range []string{"a", "b", "c"} {
+				store.ApplyRecord(&metadata.Record{Seq: uint64(seq) + 1, Term: 2, Op: metadata.OpMkdir, File: dir})
+			}
+
+			node, err := NewNode(ids[0], ids, store, zap.NewNop())
+			if err != nil {
+				t.Fatalf("NewNode() error = %v", err)
+			}
+			node.ctx = context.Background()
+			node.term, node.votedFor = 3, tt.votedFor
+			if tt.heardActive {
+				node.lastActive = time.Now()
+			}
+			if tt.active {
+				node.active = true
+				node.activeCtx, node.stepDown = context.WithCancel(node.ctx)
+			}
+
+			vote := node.vote(tt.req).GetVote()
+			if vote.Granted != tt.wantGranted || vote.Term != tt.wantTerm {
+				t.Errorf("vote() = %v, %d, want %v, %d", vote.Granted, vote.Term, tt.wantGranted, tt.wantTerm)
+			}
+			if active, _ := node.Active(); active != tt.active {
+				t.Errorf("Active() = %v after the vote, want %v", active, tt.active)
+			}
+
+			//a restarted controller does not vote again in the term
+			if tt.wantGranted {
+				restarted, err := NewNode(ids[0], ids, store, zap.NewNop())
+				if err != nil {
+					t.Fatalf("NewNode() error = %v", err)
+				}
+				if restarted.term != tt.wantTerm || restarted.votedFor != tt.req.ControllerId {
+					t.Errorf("restarted in term %d having voted for %q, want %d and %q", restarted.term, restarted.votedFor, tt.wantTerm, tt.req.ControllerId)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"src/controller/metadata"
+	messages "src/messages/controller_controller"
+	"time"
+)
+
+var (
+	ErrStaleTerm     = errors.New("another controller started a newer term")
+	ErrRefused       = errors.New("the standby did not apply what it was sent")
+	ErrNotActive     = errors.New("the controller is not the active one")
+	ErrNotReplicated = errors.New("a majority of the controllers did not apply the change in time")
+)
+
+// standby is another controller as the active one ships records to it. Only its replicate goroutine uses
+// conn, the rest is guarded by replMutex.
+type standby struct {
+	id   string
+	conn *messages.MessageHandler
+	//the term it was last sent a snapshot in, records are only shipped to it in that term
+	term uint64
+	//the last record it applied in term
+	acked uint64
+	//records committed in term that are not shipped yet
+	queue []*metadata.Record
+	//has the replicate goroutine look at the standby again
+	wake chan struct{}
+}
+
+func (sb *standby) poke() {
+	select {
+	case sb.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (sb *standby) close() {
+	if sb.conn != nil {
+		sb.conn.Close()
+		sb.conn = nil
+	}
+}
+
+// Term returns the term the controller is active in, records are only committed while it is.
+func (n *Node) Term() (uint64, error) {
+
+	active, term := n.Active()
+	if !active {
+		return 0, ErrNotActive
+	}
+	return term, nil
+}
+
+// Replicate queues a record the store committed for the standbys that are in sync.
+func (n *Node) Replicate(rec *metadata.Record) {
+
+	n.replMutex.Lock()
+	defer n.replMutex.Unlock()
+
+	if active, term := n.Active(); !active || term != rec.Term {
+		return
+	}
+	n.remember(rec.Term, rec)
+
+	for _, sb := range n.standbys {
+		if sb.term == rec.Term {
+			sb.queue = append(sb.queue, rec)
+			sb.poke()
+		}
+	}
+}
+
+// Replicated waits for a majority of the group to have applied the record seq. It fails when they have not
+// within an election timeout, or when the controller stepped down. The record stays in the metadata of the
+// active controller and is shipped to the standbys once they are in sync again.
+func (n *Node) Replicated(seq uint64) error {
+
+	deadline := time.Now().Add(n.electionTimeout)
+	timer := time.AfterFunc(n.electionTimeout, n.broadcast)
+	defer timer.Stop()
+
+	n.replMutex.Lock()
+	defer n.replMutex.Unlock()
+
+	for {
+		active, term := n.Active()
+		if !active {
+			return ErrNotActive
+		}
+		applied := 1
+		for _, sb := range n.standbys {
+			if sb.term == term && sb.acked >= seq {
+				applied++
+			}
+		}
+		if applied >= n.majority() {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			n.logger.Warn("Change not applied by a majority", zap.Uint64("seq", seq), zap.Int("applied", applied))
+			return ErrNotReplicated
+		}
+		n.replCond.Wait()
+	}
+}
+
+// broadcast wakes up the changes waiting on the standbys.
+func (n *Node) broadcast() {
+
+	n.replMutex.Lock()
+	defer n.replMutex.Unlock()
+	n.replCond.Broadcast()
+}
+
+// remember keeps a record for the standbys that are sent a snapshot taken before it. Callers hold replMutex.
+func (n *Node) remember(term uint64, rec *metadata.Record) {
+
+	//records of an earlier term as active controller may not be what the standbys have
+	if n.recentTerm != term {
+		n.recent, n.recentTerm = nil, term
+	}
+	n.recent = append(n.recent, rec)
+	if len(n.recent) > 2*RECENT_RECORDS {
+		n.recent = append([]*metadata.Record(nil), n.recent[RECENT_RECORDS:]...)
+	}
+}
+
+// since returns the records kept that follow seq. It fails if some of them are no longer kept. Callers hold
+// replMutex.
+func (n *Node) since(term uint64, seq uint64) (records []*metadata.Record, ok bool) {
+
+	if n.recentTerm != term {
+		return nil, true
+	}
+	for i, rec := range n.recent {
+		if rec.Seq > seq {
+			return append([]*metadata.Record(nil), n.recent[i:]...), rec.Seq == seq+1
+		}
+	}
+	return nil, true
+}
+
+// replicate ships to a standby what it is owed until ctx is cancelled, while the controller is active: the
+// metadata when it is not in sync, and the records committed from then on, in order.
+func (n *Node) replicate(ctx context.Context, sb *standby) {
+
+	defer sb.close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sb.wake:
+		}
+
+		active, term := n.Active()
+		n.replMutex.Lock()
+		synced := sb.term == term
+		records := sb.queue
+		sb.queue = nil
+		n.replMutex.Unlock()
+
+		switch {
+		case !active:
+			sb.close()
+		case !synced:
+			n.catchUp(sb, term)
+		case len(records) > 0:
+			n.shipRecords(sb, term, records)
+		}
+	}
+}
+
+// shipRecords sends records to a standby in sync, and takes note of it having applied them. A standby that
+// does not is sent a snapshot once it is heard from again.
+func (n *Node) shipRecords(sb *standby, term uint64, records []*metadata.Record) {
+
+	err := n.ship(sb, term, appendRecords(term, records), n.replicationTimeout())
+
+	n.replMutex.Lock()
+	defer n.replMutex.Unlock()
+
+	if sb.term != term {
+		return
+	}
+	if err != nil {
+		sb.term, sb.queue = 0, nil
+		n.logger.Warn("Standby is out of sync", zap.String("standby", sb.id), zap.Uint64("seq", records[0].Seq), zap.Error(err))
+		return
+	}
+	sb.acked = records[len(records)-1].Seq
+	n.replCond.Broadcast()
+}
+
+// catchUp sends the metadata to a standby that is not in sync, and the records committed while it was
+// exported and sent. Records are queued for it from then on.
+func (n *Node) catchUp(sb *standby, term uint64) {
+
+	data, seq, err := n.store.Export()
+	if err != nil {
+		n.logger.Error("Error exporting the metadata", zap.Error(err))
+		return
+	}
+
+	start := time.Now()
+	snapshot := &messages.PeerMessage{PeerMessage: &messages.PeerMessage_InstallSnapshot_{InstallSnapshot: &messages.PeerMessage_InstallSnapshot{
+		Term:     term,
+		Snapshot: data,
+	}}}
+	err = n.ship(sb, term, snapshot, SNAPSHOT_TIMEOUT)
+	if err != nil {
+		n.logger.Warn("Error sending the metadata to a standby", zap.String("standby", sb.id), zap.Error(err))
+		return
+	}
+
+	for {
+		n.replMutex.Lock()
+		if active, current := n.Active(); !active || current != term {
+			n.replMutex.Unlock()
+			return
+		}
+		records, ok := n.since(term, seq)
+		if !ok {
+			n.replMutex.Unlock()
+			//more records were committed than are kept, the next heartbeat tries again
+			n.logger.Warn("Standby fell too far behind to catch up", zap.String("standby", sb.id), zap.Uint64("seq", seq))
+			return
+		}
+		if len(records) == 0 {
+			//nothing committed is missing, and what is committed from now on is queued for it
+			sb.term, sb.acked, sb.queue = term, seq, nil
+			n.replCond.Broadcast()
+			n.replMutex.Unlock()
+			n.logger.Info("Standby is in sync", zap.String("standby", sb.id), zap.Uint64("seq", seq), zap.Duration("took", time.Since(start)))
+			return
+		}
+		n.replMutex.Unlock()
+
+		err = n.ship(sb, term, appendRecords(term, records), n.replicationTimeout())
+		if err != nil {
+			n.logger.Warn("Error sending records to a standby", zap.String("standby", sb.id), zap.Error(err))
+			return
+		}
+		seq = records[len(records)-1].Seq
+	}
+}
+
+// appendRecords returns the message that ships records committed in term.
+func appendRecords(term uint64, records []*metadata.Record) *messages.PeerMessage {
+
+	msg := &messages.PeerMessage_AppendRecords{Term: term}
+	for _, rec := range records {
+		//records are plain data and always encode
+		encoded, _ := json.Marshal(rec)
+		msg.Records = append(msg.Records, encoded)
+	}
+	return &messages.PeerMessage{PeerMessage: &messages.PeerMessage_AppendRecords_{AppendRecords: msg}}
+}
+
+// ship sends a message to a standby and waits for it to be applied. Only the replicate goroutine of the
+// standby calls it.
+func (n *Node) ship(sb *standby, term uint64, msg *messages.PeerMessage, timeout time.Duration) (err error) {
+
+	if sb.conn == nil {
+		sb.conn, err = n.dial(sb.id)
+		if err != nil {
+			return
+		}
+	}
+	sb.conn.SetTimeouts(timeout, timeout)
+
+	res, err := request(sb.conn, msg)
+	if err != nil {
+		sb.close()
+		return
+	}
+	ack := res.GetAck()
+	if ack == nil {
+		sb.close()
+		return ErrRefused
+	}
+	if ack.Term > term {
+		n.mutex.Lock()
+		n.adoptTerm(ack.Term)
+		n.mutex.Unlock()
+		return ErrStaleTerm
+	}
+	if !ack.Success {
+		return ErrRefused
+	}
+	return
+}
+
+// follow takes note of a message from the active controller of term. It fails if the controller is not the
+// active one of the newest term this one knows of.
+func (n *Node) follow(term uint64) bool {
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if term < n.term {
+		return false
+	}
+	n.adoptTerm(term)
+	if n.active {
+		//only one controller wins the votes of a term, this cannot be its active one
+		return false
+	}
+	n.lastActive = time.Now()
+	return true
+}
+
+// appendRecords applies the records the active controller sent.
+func (n *Node) appendRecords(req *messages.PeerMessage_AppendRecords) *messages.PeerMessage {
+
+	if !n.follow(req.Term) {
+		return n.ack(false)
+	}
+
+	for _, data := range req.Records {
+		rec := &metadata.Record{}
+		err := json.Unmarshal(data, rec)
+		if err == nil {
+			err = n.store.ApplyRecord(rec)
+		}
+		if err != nil {
+			n.logger.Warn("Could not apply a record from the active controller", zap.Uint64("seq", rec.Seq), zap.Error(err))
+			return n.ack(false)
+		}
+	}
+	return n.ack(true)
+}
+
+// installSnapshot replaces the metadata with the one the active controller sent.
+func (n *Node) installSnapshot(req *messages.PeerMessage_InstallSnapshot) *messages.PeerMessage {
+
+	if !n.follow(req.Term) {
+		return n.ack(false)
+	}
+
+	err := n.store.Restore(req.Snapshot)
+	if err != nil {
+		n.logger.Error("Could not restore the metadata of the active controller", zap.Error(err))
+		return n.ack(false)
+	}
+	return n.ack(true)
+}
+
+// ack answers the active controller with the term of this one and the last record it applied.
+func (n *Node) ack(success bool) *messages.PeerMessage {
+
+	seq := n.store.Seq()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return &messages.PeerMessage{PeerMessage: &messages.PeerMessage_Ack_{Ack: &messages.PeerMessage_Ack{
+		Term:    n.term,
+		Success: success,
+		Seq:     seq,
+	}}}
+}
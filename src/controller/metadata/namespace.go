@@ -0,0 +1,271 @@
+package metadata
+
+import (
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrExists      = errors.New("file or directory already exists")
+	ErrNotFound    = errors.New("no such file or directory")
+	ErrNotDir      = errors.New("not a directory")
+	ErrIsDir       = errors.New("is a directory")
+	ErrNotEmpty    = errors.New("directory not empty")
+	ErrInvalidPath = errors.New("invalid path")
+	//renaming a file that is being uploaded or deleted would lose track of it
+	ErrBusy = errors.New("file is being uploaded or deleted")
+)
+
+// DirMeta is a directory of the namespace. Directories only exist in the controller's metadata, storage
+// nodes never see them.
+type DirMeta struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
+
+// Entry is a file or directory listed by List.
+type Entry struct {
+	//the last element of the path
+	Name string
+	Dir  bool
+	//the size and number of fragments of a file
+	Size      int64
+	Fragments int
+}
+
+// CleanPath turns a path into the form files and directories are keyed by: slash separated, without leading
+// or trailing slashes, and with "." and ".." resolved. The root directory is "".
+func CleanPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+// parents returns the directories above p, outermost first, leaving out the root.
+func parents(p string) (dirs []string) {
+
+	for dir := path.Dir("/" + p); dir != "/"; dir = path.Dir(dir) {
+		dirs = append([]string{dir[1:]}, dirs...)
+	}
+	return
+}
+
+// within reports whether p is dir or lies below it.
+func within(p string, dir string) bool {
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// isDir reports whether p is the root or a directory. Callers hold the lock.
+func (s *Store) isDir(p string) bool {
+	_, ok := s.dirs[p]
+	return p == "" || ok
+}
+
+// checkParents fails if a file is in the way of the directories above p. Callers hold the lock.
+func (s *Store) checkParents(p string) error {
+
+	for _, dir := range parents(p) {
+		if _, ok := s.files[dir]; ok {
+			return ErrNotDir
+		}
+	}
+	return nil
+}
+
+// makeParents creates the directories above p that do not exist yet. Callers hold the lock.
+func (s *Store) makeParents(p string, created time.Time) {
+
+	for _, dir := range parents(p) {
+		if _, ok := s.dirs[dir]; !ok {
+			s.dirs[dir] = &DirMeta{Name: dir, Created: created}
+		}
+	}
+}
+
+// CheckCreate reports why a file could not be created at name, nil if it can.
+func (s *Store) CheckCreate(name string) error {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.checkCreate(name)
+}
+
+// checkCreate is CheckCreate for callers that hold the lock.
+func (s *Store) checkCreate(name string) error {
+
+	if _, ok := s.files[name]; ok {
+		return ErrExists
+	}
+	if name == "" || name != CleanPath(name) {
+		return ErrInvalidPath
+	}
+	if s.isDir(name) {
+		return ErrIsDir
+	}
+	return s.checkParents(name)
+}
+
+// Mkdir creates a directory, and the directories above it that do not exist yet.
+func (s *Store) Mkdir(dir string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dir = CleanPath(dir)
+	if _, ok := s.files[dir]; ok || s.isDir(dir) {
+		return ErrExists
+	}
+	err = s.checkParents(dir)
+	if err != nil {
+		return
+	}
+
+	return s.commit(&Record{Op: OpMkdir, File: dir})
+}
+
+// Rmdir removes an empty directory.
+func (s *Store) Rmdir(dir string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dir = CleanPath(dir)
+	switch _, file := s.files[dir]; {
+	case dir == "":
+		return ErrInvalidPath
+	case file:
+		return ErrNotDir
+	case !s.isDir(dir):
+		return ErrNotFound
+	}
+
+	for name := range s.files {
+		if within(name, dir) {
+			return ErrNotEmpty
+		}
+	}
+	for name := range s.dirs {
+		if name != dir && within(name, dir) {
+			return ErrNotEmpty
+		}
+	}
+
+	return s.commit(&Record{Op: OpRmdir, File: dir})
+}
+
+// Rename moves a file or a directory with everything below it to to. Only the metadata changes, the
+// fragments stay where they are under their block ids. The directory to is moved into must exist.
+func (s *Store) Rename(from string, to string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	from, to = CleanPath(from), CleanPath(to)
+	if from == "" || to == "" || (from != to && within(to, from)) {
+		return ErrInvalidPath
+	}
+
+	meta, file := s.files[from]
+	switch {
+	case !file && !s.isDir(from):
+		return ErrNotFound
+	case file && meta.State != StateCommitted:
+		return ErrBusy
+	}
+	if _, ok := s.files[to]; ok || s.isDir(to) {
+		return ErrExists
+	}
+	if parent := path.Dir("/" + to)[1:]; !s.isDir(parent) {
+		if _, ok := s.files[parent]; ok {
+			return ErrNotDir
+		}
+		return ErrNotFound
+	}
+
+	for name, meta := range s.files {
+		if within(name, from) && meta.State != StateCommitted {
+			return ErrBusy
+		}
+	}
+
+	return s.commit(&Record{Op: OpRename, File: from, Dest: to})
+}
+
+// rename applies a rename, moving every file and directory at or below from. Callers hold the lock.
+func (s *Store) rename(from string, to string) {
+
+	moved := func(name string) string {
+		return to + strings.TrimPrefix(name, from)
+	}
+
+	for name, meta := range s.files {
+		if within(name, from) {
+			delete(s.files, name)
+			meta.Name = moved(name)
+			s.files[meta.Name] = meta
+			for _, id := range meta.Blocks {
+				s.blocks[id] = meta.Name
+			}
+		}
+	}
+	for name, dir := range s.dirs {
+		if within(name, from) {
+			delete(s.dirs, name)
+			dir.Name = moved(name)
+			s.dirs[dir.Name] = dir
+		}
+	}
+}
+
+// List returns what is directly in a directory, sorted by name. Files that are not committed are left out.
+func (s *Store) List(dir string) (entries []Entry, err error) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	dir = CleanPath(dir)
+	if !s.isDir(dir) {
+		if _, ok := s.files[dir]; ok {
+			return nil, ErrNotDir
+		}
+		return nil, ErrNotFound
+	}
+
+	prefix := dir + "/"
+	if dir == "" {
+		prefix = ""
+	}
+	child := func(name string) (string, bool) {
+		rest := strings.TrimPrefix(name, prefix)
+		return rest, name != dir && strings.HasPrefix(name, prefix) && !strings.Contains(rest, "/")
+	}
+
+	entries = make([]Entry, 0)
+	for name := range s.dirs {
+		if base, ok := child(name); ok {
+			entries = append(entries, Entry{Name: base, Dir: true})
+		}
+	}
+	for name, meta := range s.files {
+		if base, ok := child(name); ok && meta.State == StateCommitted {
+			entries = append(entries, Entry{Name: base, Size: meta.Size, Fragments: len(meta.Blocks)})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return
+}
+
+// FileOfBlock returns the path of the file a block belongs to.
+func (s *Store) FileOfBlock(blockId string) (name string, found bool) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	name, found = s.blocks[blockId]
+	return
+}
@@ -0,0 +1,183 @@
+package metadata
+
+import (
+	"go.uber.org/zap"
+	"reflect"
+	"testing"
+)
+
+func TestStore_Namespace(t *testing.T) {
+	type step func(s *Store) error
+	createFile := func(name string, blockId string) step {
+		return func(s *Store) error {
+			return s.CreateFile(name, 10, 10, Redundancy{ReplicationFactor: 1}, []string{blockId}, "", "")
+		}
+	}
+	commit := func(name string) step {
+		return func(s *Store) error { return s.Commit(name, "") }
+	}
+	tests := []struct {
+		name     string
+		steps    []step
+		wantErr  error
+		snapshot bool
+		dir      string
+		want     []Entry
+	}{
+		{
+			name: "Test mkdir creates the directories above",
+			steps: []step{
+				func(s *Store) error { return s.Mkdir("/a/b/") },
+			},
+			dir:  "a",
+			want: []Entry{{Name: "b", Dir: true}},
+		},
+		{
+			name: "Test mkdir over a file",
+			steps: []step{
+				createFile("a", "0a"),
+				func(s *Store) error { return s.Mkdir("a") },
+			},
+			wantErr: ErrExists,
+		},
+		{
+			name: "Test create a file below a file",
+			steps: []step{
+				createFile("a", "0a"),
+				createFile("a/b", "0b"),
+			},
+			wantErr: ErrNotDir,
+		},
+		{
+			name: "Test rmdir of a directory that is not empty",
+			steps: []step{
+				createFile("a/b", "0b"),
+				func(s *Store) error { return s.Rmdir("a") },
+			},
+			wantErr: ErrNotEmpty,
+		},
+		{
+			name: "Test rmdir",
+			steps: []step{
+				func(s *Store) error { return s.Mkdir("a/b") },
+				func(s *Store) error { return s.Rmdir("a/b") },
+			},
+			dir:  "a",
+			want: []Entry{},
+		},
+		{
+			name: "Test rename a file",
+			steps: []step{
+				createFile("a/b", "0b"),
+				commit("a/b"),
+				func(s *Store) error { return s.Mkdir("c") },
+				func(s *Store) error { return s.Rename("a/b", "c/d") },
+			},
+			dir:  "c",
+			want: []Entry{{Name: "d", Size: 10, Fragments: 1}},
+		},
+		{
+			name: "Test rename a directory",
+			steps: []step{
+				createFile("a/b/c", "0c"),
+				commit("a/b/c"),
+				func(s *Store) error { return s.Rename("a", "x") },
+			},
+			snapshot: true,
+			dir:      "x/b",
+			want:     []Entry{{Name: "c", Size: 10, Fragments: 1}},
+		},
+		{
+			name: "Test rename a file that is being uploaded",
+			steps: []step{
+				createFile("a", "0a"),
+				func(s *Store) error { return s.Rename("a", "b") },
+			},
+			wantErr: ErrBusy,
+		},
+		{
+			name: "Test rename a directory into itself",
+			steps: []step{
+				func(s *Store) error { return s.Mkdir("a") },
+				func(s *Store) error { return s.Rename("a", "a/b") },
+			},
+			wantErr: ErrInvalidPath,
+		},
+		{
+			name: "Test rename into a directory that does not exist",
+			steps: []step{
+				createFile("a", "0a"),
+				commit("a"),
+				func(s *Store) error { return s.Rename("a", "b/a") },
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "Test list leaves out uploads",
+			steps: []step{
+				createFile("a", "0a"),
+				commit("a"),
+				createFile("b", "0b"),
+				func(s *Store) error { return s.Mkdir("c") },
+			},
+			want: []Entry{{Name: "a", Size: 10, Fragments: 1}, {Name: "c", Dir: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := Open(dir, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			for _, st := range tt.steps {
+				err = st(s)
+			}
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				s.Close()
+				return
+			}
+			if tt.snapshot {
+				s.Snapshot()
+			}
+			s.Close()
+
+			//the namespace must come back the same from the snapshot or the log
+			reopened, err := Open(dir, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer reopened.Close()
+
+			got, err := reopened.List(tt.dir)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_FileOfBlock(t *testing.T) {
+	s, err := Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+
+	s.CreateFile("a/b", 10, 10, Redundancy{ReplicationFactor: 1}, []string{"0b"}, "", "")
+	s.Commit("a/b", "")
+	s.Rename("a", "c")
+
+	if name, found := s.FileOfBlock("0b"); !found || name != "c/b" {
+		t.Errorf("FileOfBlock() = %q, %v, want %q", name, found, "c/b")
+	}
+	if err = s.CreateFile("d", 10, 10, Redundancy{ReplicationFactor: 1}, []string{"0b"}, "", ""); err == nil {
+		t.Errorf("CreateFile() with a block id in use succeeded")
+	}
+}
@@ -0,0 +1,124 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"path/filepath"
+)
+
+var ErrOutOfSequence = errors.New("record does not follow the last one applied")
+
+// Replicator ships the records the active controller commits to its standbys.
+type Replicator interface {
+	// Term returns the term records are committed in, and fails when they may not be committed at all. It is
+	// called with the store locked, before the record is written.
+	Term() (uint64, error)
+	// Replicate queues a record for the standbys. It is called with the store locked, once the record is in
+	// the local log, in the order records are committed, and does not block.
+	Replicate(rec *Record)
+	// Replicated waits for the standbys to apply the record seq, and fails if they do not. It is called
+	// without the lock.
+	Replicated(seq uint64) error
+}
+
+// SetReplicator has every record committed from now on shipped by r. nil stops shipping them.
+func (s *Store) SetReplicator(r Replicator) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.replicator = r
+}
+
+// Seq returns the sequence number of the last record applied.
+func (s *Store) Seq() uint64 {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.seq
+}
+
+// LastRecord returns the term and the sequence number of the last record applied. Of two stores, the one
+// whose last record is of a later term, or of the same term and later, holds every record the other may have
+// had acknowledged.
+func (s *Store) LastRecord() (term uint64, seq uint64) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.term, s.seq
+}
+
+// Dir returns the directory the store keeps its files in.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// ApplyRecord writes a record the active controller committed to the log and applies it. Records already
+// applied are skipped, and a record that leaves a gap fails with ErrOutOfSequence. It is not replicated
+// further.
+func (s *Store) ApplyRecord(rec *Record) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if rec.Seq <= s.seq {
+		return
+	}
+	if rec.Seq != s.seq+1 {
+		return ErrOutOfSequence
+	}
+
+	err = s.wal.append(rec)
+	if err != nil {
+		s.logger.Error("Error writing to the metadata log", zap.Error(err))
+		return
+	}
+
+	s.seq, s.term = rec.Seq, rec.Term
+	s.apply(rec)
+	return
+}
+
+// Export returns the whole state of the store, in the form Restore takes, and the sequence number it is at.
+func (s *Store) Export() (data []byte, seq uint64, err error) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	data, err = json.Marshal(&snapshot{Seq: s.seq, Term: s.term, Files: s.files, Dirs: s.dirs})
+	return data, s.seq, err
+}
+
+// Restore replaces the state of the store with one Export returned, and makes it the snapshot on disk. The
+// records in the log, which may not agree with it, are dropped.
+func (s *Store) Restore(data []byte) (err error) {
+
+	snap := &snapshot{}
+	err = json.Unmarshal(data, snap)
+	if err != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err = writeJSON(filepath.Join(s.dir, snapshotFile), snap)
+	if err != nil {
+		return
+	}
+	err = s.wal.truncate()
+	if err != nil {
+		return
+	}
+
+	s.load(snap)
+	for name, meta := range s.files {
+		s.makeParents(name, meta.Created)
+	}
+
+	s.logger.Info("Metadata restored", zap.Uint64("seq", s.seq), zap.Int("files", len(s.files)))
+	return
+}
@@ -0,0 +1,198 @@
+package metadata
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"reflect"
+	"testing"
+)
+
+type recorder struct {
+	term    uint64
+	records []*Record
+	//what Term and Replicated fail with
+	termErr error
+	ackErr  error
+}
+
+func (r *recorder) Term() (uint64, error) {
+	return r.term, r.termErr
+}
+
+func (r *recorder) Replicate(rec *Record) {
+	r.records = append(r.records, rec)
+}
+
+func (r *recorder) Replicated(seq uint64) error {
+	return r.ackErr
+}
+
+func TestStore_ApplyRecord(t *testing.T) {
+	active, err := Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer active.Close()
+	shipped := &recorder{term: 3}
+	active.SetReplicator(shipped)
+
+	active.CreateFile("dir/file", 10, 5, Redundancy{ReplicationFactor: 2}, []string{"b0", "b1"}, "upload", "alice")
+	active.AddReplica("dir/file", "b0", "node1")
+	active.AddReplica("dir/file", "b1", "node2")
+	active.Commit("dir/file", "9f86d0")
+	active.Rename("dir", "other")
+	if len(shipped.records) != 5 {
+		t.Fatalf("%d records shipped, want 5", len(shipped.records))
+	}
+
+	dir := t.TempDir()
+	standby, err := Open(dir, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := standby.ApplyRecord(shipped.records[1]); err != ErrOutOfSequence {
+		t.Errorf("ApplyRecord() of a gap error = %v, want %v", err, ErrOutOfSequence)
+	}
+	for _, rec := range shipped.records {
+		if err := standby.ApplyRecord(rec); err != nil {
+			t.Fatalf("ApplyRecord(%d) error = %v", rec.Seq, err)
+		}
+	}
+	//records arrive again when the active controller retries
+	if err := standby.ApplyRecord(shipped.records[2]); err != nil {
+		t.Errorf("ApplyRecord() of an applied record error = %v", err)
+	}
+	standby.Close()
+
+	reopened, err := Open(dir, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer reopened.Close()
+
+	want, _ := active.GetFile("other/file")
+	got, found := reopened.GetFile("other/file")
+	if !found {
+		t.Fatalf("replicated file not found after replay")
+	}
+	if !got.Created.Equal(want.Created) || !got.Updated.Equal(want.Updated) {
+		t.Errorf("GetFile() created %v updated %v, want %v and %v", got.Created, got.Updated, want.Created, want.Updated)
+	}
+	//times read back from the log are in another location and carry no monotonic clock reading
+	got.Created, got.Updated = want.Created, want.Updated
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFile() = %+v, want %+v", got, want)
+	}
+	if term, seq := reopened.LastRecord(); term != 3 || seq != active.Seq() {
+		t.Errorf("LastRecord() = %d, %d, want 3, %d", term, seq, active.Seq())
+	}
+}
+
+func TestStore_commitReplicated(t *testing.T) {
+	errNotActive := errors.New("not active")
+	errNoMajority := errors.New("no majority")
+
+	tests := []struct {
+		name    string
+		shipped *recorder
+		wantErr error
+		//whether the record is in the local log whatever the standbys did
+		wantLogged bool
+	}{
+		{
+			name:       "Test replicated",
+			shipped:    &recorder{term: 2},
+			wantLogged: true,
+		},
+		{
+			name:    "Test not active",
+			shipped: &recorder{termErr: errNotActive},
+			wantErr: errNotActive,
+		},
+		{
+			name:       "Test not acknowledged",
+			shipped:    &recorder{term: 2, ackErr: errNoMajority},
+			wantErr:    errNoMajority,
+			wantLogged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := Open(t.TempDir(), zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer store.Close()
+			store.SetReplicator(tt.shipped)
+
+			if err := store.Mkdir("dir"); err != tt.wantErr {
+				t.Errorf("Mkdir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if logged := store.Seq() == 1; logged != tt.wantLogged {
+				t.Errorf("record logged = %v, want %v", logged, tt.wantLogged)
+			}
+			if shipped := len(tt.shipped.records) == 1; shipped != tt.wantLogged {
+				t.Errorf("record shipped = %v, want %v", shipped, tt.wantLogged)
+			}
+			//the lock is held again once the standbys were waited on
+			if err := store.Mkdir("other"); tt.wantErr == nil && err != nil {
+				t.Errorf("Mkdir() after a replicated record error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_Restore(t *testing.T) {
+	active, err := Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer active.Close()
+	active.CreateFile("a/file", 10, 10, Redundancy{ReplicationFactor: 1}, []string{"b0"}, "", "")
+	active.Commit("a/file", "")
+	active.Mkdir("empty")
+
+	//a standby that was cut off from the active controller has records of its own
+	dir := t.TempDir()
+	standby, err := Open(dir, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	standby.CreateFile("stray", 10, 10, Redundancy{ReplicationFactor: 1}, []string{"b9"}, "", "")
+	standby.AddReplica("stray", "b9", "node1")
+	standby.AddReplica("stray", "b9", "node2")
+	standby.Mkdir("x/y")
+
+	data, seq, err := active.Export()
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if err := standby.Restore(data); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	standby.Close()
+
+	reopened, err := Open(dir, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer reopened.Close()
+
+	if reopened.Seq() != seq {
+		t.Errorf("Seq() = %d, want %d", reopened.Seq(), seq)
+	}
+	if _, found := reopened.GetFile("stray"); found {
+		t.Errorf("file of the standby survived the restore")
+	}
+	if _, found := reopened.FileOfBlock("b9"); found {
+		t.Errorf("block of the standby survived the restore")
+	}
+	got, err := reopened.List("")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []Entry{{Name: "a", Dir: true}, {Name: "empty", Dir: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+}
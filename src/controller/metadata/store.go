@@ -0,0 +1,598 @@
+package metadata
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrNotUploading = errors.New("file is not being uploaded")
+var ErrBlockInUse = errors.New("block id already in use")
+
+const walFile = "wal.log"
+const snapshotFile = "snapshot.json"
+
+// Redundancy is how a file survives the loss of nodes: every fragment replicated ReplicationFactor times,
+// or, when DataShards is set, erasure coded into DataShards data and ParityShards parity shards stored once each.
+type Redundancy struct {
+	ReplicationFactor int `json:"replication_factor,omitempty"`
+	DataShards        int `json:"data_shards,omitempty"`
+	ParityShards      int `json:"parity_shards,omitempty"`
+}
+
+func (r Redundancy) ErasureCoded() bool {
+	return r.DataShards > 0
+}
+
+// FileState is where a file is in its lifecycle. Only committed files are visible to clients.
+type FileState string
+
+const (
+	//the PUT was planned, no fragment has been reported yet
+	StatePlanned FileState = "planned"
+	//fragments are being reported, the client has not committed the file
+	StateUploading FileState = "uploading"
+	//the client stored every fragment and committed the file
+	StateCommitted FileState = "committed"
+	//the file's fragments are being removed
+	StateDeleting FileState = "deleting"
+)
+
+// FileMeta is everything the controller knows about a file: file -> fragment -> replica node ids.
+// Name is the file's path in the namespace. Every fragment is stored on the nodes under a block id the
+// controller generated, fragment i of the file is block Blocks[i], and Fragments is keyed by block id.
+type FileMeta struct {
+	Name         string    `json:"name"`
+	Blocks       []string  `json:"blocks,omitempty"`
+	Size         int64     `json:"size"`
+	ChunkSize    int64     `json:"chunk_size"`
+	NumFragments int       `json:"num_fragments"`
+	State        FileState `json:"state"`
+	Created      time.Time `json:"created"`
+	//when the state last changed
+	Updated time.Time `json:"updated"`
+	//identifies the PUT that stored the file, a client resumes the upload by it
+	UploadId  string              `json:"upload_id,omitempty"`
+	Fragments map[string][]string `json:"fragments"`
+	//the user that stored the file, and the checksum of the whole file as its client computed it
+	Owner    string `json:"owner,omitempty"`
+	Checksum string `json:"checksum,omitempty"`
+	Redundancy
+}
+
+func (f *FileMeta) copy() *FileMeta {
+	c := *f
+	c.Blocks = append([]string(nil), f.Blocks...)
+	c.Fragments = make(map[string][]string, len(f.Fragments))
+	for frag, nodes := range f.Fragments {
+		c.Fragments[frag] = append([]string(nil), nodes...)
+	}
+	return &c
+}
+
+// Store keeps the controller's file metadata on local disk. Every change is appended to a write-ahead log,
+// and the log is periodically folded into a snapshot.
+type Store struct {
+	dir   string
+	files map[string]*FileMeta
+	dirs  map[string]*DirMeta
+	//block id -> path of the file, rebuilt from files when the store is loaded
+	blocks map[string]string
+	wal    *wal
+	seq    uint64
+	//the term of the last record applied
+	term uint64
+
+	//ships every record committed to the standby controllers, nil without them
+	replicator Replicator
+
+	logger *zap.Logger
+	mutex  *sync.RWMutex
+}
+
+type snapshot struct {
+	Seq   uint64               `json:"seq"`
+	Term  uint64               `json:"term,omitempty"`
+	Files map[string]*FileMeta `json:"files"`
+	Dirs  map[string]*DirMeta  `json:"dirs,omitempty"`
+}
+
+// Open loads the latest snapshot from dir and replays the write-ahead log on top of it.
+func Open(dir string, logger *zap.Logger) (store *Store, err error) {
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return
+	}
+
+	store = &Store{
+		dir:    dir,
+		files:  make(map[string]*FileMeta),
+		dirs:   make(map[string]*DirMeta),
+		blocks: make(map[string]string),
+		logger: logger,
+		mutex:  &sync.RWMutex{},
+	}
+
+	snap := &snapshot{}
+	found, err := readJSON(filepath.Join(dir, snapshotFile), snap)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		store.load(snap)
+	}
+
+	records, err := readWAL(filepath.Join(dir, walFile))
+	if err != nil {
+		return nil, err
+	}
+
+	replayed := 0
+	for _, rec := range records {
+		// records already folded into the snapshot survive if we crashed before truncating the log
+		if rec.Seq <= store.seq {
+			continue
+		}
+		store.apply(rec)
+		store.seq, store.term = rec.Seq, rec.Term
+		replayed++
+	}
+
+	//files recorded before there were directories may have slashes in their names
+	for name, meta := range store.files {
+		store.makeParents(name, meta.Created)
+	}
+
+	store.wal, err = openWAL(filepath.Join(dir, walFile))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Metadata store loaded", zap.String("dir", dir), zap.Int("files", len(store.files)), zap.Int("replayed", replayed))
+	return
+}
+
+// load replaces what the store holds with a snapshot. Callers hold the write lock.
+func (s *Store) load(snap *snapshot) {
+
+	s.seq, s.term = snap.Seq, snap.Term
+	s.files = snap.Files
+	if s.files == nil {
+		s.files = make(map[string]*FileMeta)
+	}
+	s.dirs = snap.Dirs
+	if s.dirs == nil {
+		s.dirs = make(map[string]*DirMeta)
+	}
+	s.blocks = make(map[string]string)
+	for _, meta := range s.files {
+		//files recorded before uploads were committed were visible as soon as they were reported
+		if meta.State == "" {
+			meta.State = StateCommitted
+		}
+		s.addBlocks(meta)
+	}
+}
+
+// addBlocks indexes the blocks of a file. Callers hold the write lock.
+func (s *Store) addBlocks(meta *FileMeta) {
+
+	if meta.Blocks == nil {
+		fragments := make([]string, 0, len(meta.Fragments))
+		for frag := range meta.Fragments {
+			fragments = append(fragments, frag)
+		}
+		meta.Blocks = legacyBlocks(fragments)
+	}
+	for _, id := range meta.Blocks {
+		s.blocks[id] = meta.Name
+	}
+}
+
+// legacyBlocks orders the fragments of a file recorded before block lists were kept. Their names end with
+// _<N>, the position of the fragment in the file.
+func legacyBlocks(fragments []string) (blocks []string) {
+
+	position := func(frag string) int {
+		n, _ := strconv.Atoi(frag[strings.LastIndex(frag, "_")+1:])
+		return n
+	}
+
+	blocks = append([]string{}, fragments...)
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return position(blocks[i]) < position(blocks[j])
+	})
+	return
+}
+
+func (s *Store) apply(rec *Record) {
+
+	switch rec.Op {
+	case OpCreate:
+		blocks := rec.Blocks
+		if blocks == nil {
+			blocks = legacyBlocks(rec.Fragments)
+		}
+		meta := &FileMeta{
+			Name:         rec.File,
+			Blocks:       blocks,
+			Size:         rec.Size,
+			ChunkSize:    rec.ChunkSize,
+			NumFragments: len(blocks),
+			UploadId:     rec.UploadId,
+			State:        StatePlanned,
+			Created:      rec.Time,
+			Updated:      rec.Time,
+			Fragments:    make(map[string][]string),
+			Redundancy:   rec.Redundancy,
+			Owner:        rec.Owner,
+		}
+		for _, id := range blocks {
+			meta.Fragments[id] = make([]string, 0)
+		}
+		s.files[rec.File] = meta
+		s.addBlocks(meta)
+		s.makeParents(rec.File, rec.Time)
+
+	case OpAddReplica:
+		meta, ok := s.files[rec.File]
+		if !ok {
+			//logs written before every block belonged to a planned file adopted the ones they did not know
+			meta = &FileMeta{Name: rec.File, State: StateCommitted, Created: rec.Time, Updated: rec.Time, Blocks: []string{}, Fragments: make(map[string][]string)}
+			s.files[rec.File] = meta
+			s.makeParents(rec.File, rec.Time)
+		}
+		if _, known := meta.Fragments[rec.Fragment]; !known {
+			meta.Blocks = legacyBlocks(append(meta.Blocks, rec.Fragment))
+			meta.NumFragments = len(meta.Blocks)
+			s.blocks[rec.Fragment] = meta.Name
+		}
+		if meta.State == StatePlanned {
+			meta.State = StateUploading
+			meta.Updated = rec.Time
+		}
+		if !contains(meta.Fragments[rec.Fragment], rec.NodeId) {
+			meta.Fragments[rec.Fragment] = append(meta.Fragments[rec.Fragment], rec.NodeId)
+		}
+
+	case OpRemoveReplica:
+		if meta, ok := s.files[rec.File]; ok {
+			if nodes, ok := meta.Fragments[rec.Fragment]; ok {
+				meta.Fragments[rec.Fragment] = remove(nodes, rec.NodeId)
+			}
+		}
+
+	case OpCommit, OpComplete:
+		if meta, ok := s.files[rec.File]; ok {
+			meta.State = StateCommitted
+			meta.Updated = rec.Time
+			meta.Checksum = rec.Checksum
+		}
+
+	case OpResume:
+		if meta, ok := s.files[rec.File]; ok {
+			meta.Updated = rec.Time
+		}
+
+	case OpDeleting:
+		if meta, ok := s.files[rec.File]; ok {
+			meta.State = StateDeleting
+			meta.Updated = rec.Time
+		}
+
+	case OpDelete:
+		if meta, ok := s.files[rec.File]; ok {
+			for _, id := range meta.Blocks {
+				delete(s.blocks, id)
+			}
+			delete(s.files, rec.File)
+		}
+
+	case OpMkdir:
+		s.makeParents(rec.File, rec.Time)
+		s.dirs[rec.File] = &DirMeta{Name: rec.File, Created: rec.Time}
+
+	case OpRmdir:
+		delete(s.dirs, rec.File)
+
+	case OpRename:
+		s.rename(rec.File, rec.Dest)
+
+	case OpReplicas:
+		for _, change := range rec.Replicas {
+			op := OpAddReplica
+			if change.Removed {
+				op = OpRemoveReplica
+			}
+			s.apply(&Record{Op: op, Time: rec.Time, File: change.File, Fragment: change.Fragment, NodeId: change.NodeId})
+		}
+	}
+}
+
+// commit writes the record to the log before applying it in memory. With standbys, it then waits for them
+// to apply it, and fails if they do not. Callers hold the write lock, which is released while the standbys are
+// waited on, so nothing the callers read before committing may be relied on after.
+func (s *Store) commit(rec *Record) (err error) {
+
+	replicator := s.replicator
+	if replicator != nil {
+		rec.Term, err = replicator.Term()
+		if err != nil {
+			return
+		}
+	}
+
+	rec.Seq = s.seq + 1
+	if rec.Time.IsZero() {
+		rec.Time = time.Now()
+	}
+
+	err = s.wal.append(rec)
+	if err != nil {
+		s.logger.Error("Error writing to the metadata log", zap.Error(err))
+		return
+	}
+
+	s.seq, s.term = rec.Seq, rec.Term
+	s.apply(rec)
+	if replicator == nil {
+		return
+	}
+
+	//a slow standby holds up the client that changed the metadata, not everyone reading it
+	replicator.Replicate(rec)
+	s.mutex.Unlock()
+	defer s.mutex.Lock()
+	return replicator.Replicated(rec.Seq)
+}
+
+// CreateFile records a newly planned file of owner, whose fragments are stored as blocks, in order. The
+// directories above it are created if they do not exist.
+func (s *Store) CreateFile(name string, size int64, chunkSize int64, redundancy Redundancy, blocks []string, uploadId string, owner string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err = s.checkCreate(name)
+	if err != nil {
+		return
+	}
+	for _, id := range blocks {
+		if _, ok := s.blocks[id]; ok {
+			return ErrBlockInUse
+		}
+	}
+
+	return s.commit(&Record{Op: OpCreate, File: name, Size: size, ChunkSize: chunkSize, Redundancy: redundancy, Blocks: blocks, UploadId: uploadId, Owner: owner})
+}
+
+func (s *Store) AddReplica(file string, frag string, nodeId string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	//a block is only ever recorded for the file it was planned for
+	meta, ok := s.files[file]
+	if !ok {
+		return ErrNotFound
+	}
+	if _, known := meta.Fragments[frag]; !known {
+		return ErrNotFound
+	}
+	if contains(meta.Fragments[frag], nodeId) {
+		return
+	}
+
+	return s.commit(&Record{Op: OpAddReplica, File: file, Fragment: frag, NodeId: nodeId})
+}
+
+func (s *Store) RemoveReplica(file string, frag string, nodeId string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	meta, ok := s.files[file]
+	if !ok || !contains(meta.Fragments[frag], nodeId) {
+		return
+	}
+
+	return s.commit(&Record{Op: OpRemoveReplica, File: file, Fragment: frag, NodeId: nodeId})
+}
+
+// UpdateReplicas records the replicas nodes gained and lost in a single record. The changes the metadata
+// already reflects are left out, as are replicas gained of blocks no file was planned with.
+func (s *Store) UpdateReplicas(changes []ReplicaChange) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var needed []ReplicaChange
+	for _, change := range changes {
+		meta, ok := s.files[change.File]
+		if !ok {
+			continue
+		}
+		nodes, known := meta.Fragments[change.Fragment]
+		if known && contains(nodes, change.NodeId) == change.Removed {
+			needed = append(needed, change)
+		}
+	}
+	if len(needed) == 0 {
+		return
+	}
+
+	return s.commit(&Record{Op: OpReplicas, Replicas: needed})
+}
+
+// Commit makes an upload visible and records the checksum of the whole file. It fails for a file that is not
+// being uploaded.
+func (s *Store) Commit(file string, checksum string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	meta, ok := s.files[file]
+	if !ok || meta.State == StateDeleting {
+		return ErrNotUploading
+	}
+	if meta.State == StateCommitted {
+		return
+	}
+
+	return s.commit(&Record{Op: OpCommit, File: file, Checksum: checksum})
+}
+
+// Resume picks an abandoned upload up again so it is not collected for a while. It fails unless the file
+// is still being uploaded under uploadId.
+func (s *Store) Resume(file string, uploadId string) (meta *FileMeta, err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	m, ok := s.files[file]
+	if !ok || uploadId == "" || m.UploadId != uploadId || (m.State != StatePlanned && m.State != StateUploading) {
+		return nil, ErrNotUploading
+	}
+
+	err = s.commit(&Record{Op: OpResume, File: file})
+	if err != nil {
+		return
+	}
+	return m.copy(), nil
+}
+
+// MarkDeleting hides a file while its fragments are removed.
+func (s *Store) MarkDeleting(file string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	meta, ok := s.files[file]
+	if !ok || meta.State == StateDeleting {
+		return
+	}
+
+	return s.commit(&Record{Op: OpDeleting, File: file})
+}
+
+func (s *Store) DeleteFile(file string) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.files[file]; !ok {
+		return
+	}
+
+	return s.commit(&Record{Op: OpDelete, File: file})
+}
+
+// GetFile returns a copy of the metadata of a file.
+func (s *Store) GetFile(name string) (meta *FileMeta, found bool) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	m, found := s.files[name]
+	if !found {
+		return nil, false
+	}
+	return m.copy(), true
+}
+
+// State returns the state of a file.
+func (s *Store) State(name string) (state FileState, found bool) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	meta, found := s.files[name]
+	if !found {
+		return "", false
+	}
+	return meta.State, true
+}
+
+// FileNames returns the names of all files in the store, sorted.
+func (s *Store) FileNames() (names []string) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	names = make([]string, 0, len(s.files))
+	for name := range s.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// Files returns a copy of every file's metadata.
+func (s *Store) Files() (files map[string]*FileMeta) {
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	files = make(map[string]*FileMeta, len(s.files))
+	for name, meta := range s.files {
+		files[name] = meta.copy()
+	}
+	return
+}
+
+// Snapshot writes the current state to disk and truncates the write-ahead log.
+func (s *Store) Snapshot() (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snap := &snapshot{Seq: s.seq, Term: s.term, Files: s.files, Dirs: s.dirs}
+	err = writeJSON(filepath.Join(s.dir, snapshotFile), snap)
+	if err != nil {
+		s.logger.Error("Error writing metadata snapshot", zap.Error(err))
+		return
+	}
+
+	err = s.wal.truncate()
+	if err != nil {
+		s.logger.Error("Error truncating the metadata log", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Metadata snapshot written", zap.Uint64("seq", s.seq), zap.Int("files", len(s.files)))
+	return
+}
+
+func (s *Store) Close() (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.wal.close()
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(list []string, s string) []string {
+	kept := make([]string, 0, len(list))
+	for _, v := range list {
+		if v != s {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,234 @@
+package metadata
+
+import (
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStore_Replay(t *testing.T) {
+	type step func(s *Store)
+	tests := []struct {
+		name     string
+		steps    []step
+		snapshot bool
+		want     map[string][]string
+		state    FileState
+	}{
+		{
+			name: "Test replay from log",
+			steps: []step{
+				func(s *Store) {
+					s.CreateFile("file", 10, 5, Redundancy{ReplicationFactor: 2}, []string{"file_0", "file_1"}, "", "")
+				},
+				func(s *Store) { s.AddReplica("file", "file_0", "node1") },
+				func(s *Store) { s.AddReplica("file", "file_1", "node2") },
+				func(s *Store) { s.AddReplica("file", "file_1", "node3") },
+				func(s *Store) { s.RemoveReplica("file", "file_1", "node2") },
+				func(s *Store) { s.Commit("file", "") },
+			},
+			want:  map[string][]string{"file_0": {"node1"}, "file_1": {"node3"}},
+			state: StateCommitted,
+		},
+		{
+			name: "Test replay from snapshot and log",
+			steps: []step{
+				func(s *Store) {
+					s.CreateFile("file", 10, 5, Redundancy{ReplicationFactor: 2}, []string{"file_0", "file_1"}, "", "")
+				},
+				func(s *Store) { s.AddReplica("file", "file_0", "node1") },
+				func(s *Store) { s.Snapshot() },
+				func(s *Store) { s.AddReplica("file", "file_1", "node2") },
+			},
+			want:  map[string][]string{"file_0": {"node1"}, "file_1": {"node2"}},
+			state: StateUploading,
+		},
+		{
+			name: "Test replay replicas changed together",
+			steps: []step{
+				func(s *Store) {
+					s.CreateFile("file", 10, 5, Redundancy{ReplicationFactor: 2}, []string{"file_0", "file_1"}, "", "")
+				},
+				func(s *Store) { s.AddReplica("file", "file_1", "node2") },
+				func(s *Store) {
+					s.UpdateReplicas([]ReplicaChange{
+						{File: "file", Fragment: "file_0", NodeId: "node1"},
+						{File: "file", Fragment: "file_1", NodeId: "node3"},
+						{File: "file", Fragment: "file_1", NodeId: "node2", Removed: true},
+						{File: "file", Fragment: "other_0", NodeId: "node1"},
+						{File: "other", Fragment: "other_0", NodeId: "node1"},
+					})
+				},
+			},
+			want:  map[string][]string{"file_0": {"node1"}, "file_1": {"node3"}},
+			state: StateUploading,
+		},
+		{
+			name: "Test upload deleted before its commit",
+			steps: []step{
+				func(s *Store) {
+					s.CreateFile("file", 10, 5, Redundancy{ReplicationFactor: 2}, []string{"file_0", "file_1"}, "", "")
+				},
+				func(s *Store) { s.AddReplica("file", "file_0", "node1") },
+				func(s *Store) { s.MarkDeleting("file") },
+				func(s *Store) { s.Commit("file", "") },
+			},
+			want:  map[string][]string{"file_0": {"node1"}, "file_1": nil},
+			state: StateDeleting,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := Open(dir, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			for _, st := range tt.steps {
+				st(s)
+			}
+			s.Close()
+
+			reopened, err := Open(dir, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer reopened.Close()
+
+			meta, found := reopened.GetFile("file")
+			if !found {
+				t.Fatalf("file not found after replay")
+			}
+			if !reflect.DeepEqual(meta.Fragments, tt.want) {
+				t.Errorf("Fragments = %v, want %v", meta.Fragments, tt.want)
+			}
+			if meta.Size != 10 || meta.ChunkSize != 5 || meta.NumFragments != 2 {
+				t.Errorf("got size %d chunk size %d fragments %d", meta.Size, meta.ChunkSize, meta.NumFragments)
+			}
+			if meta.State != tt.state {
+				t.Errorf("State = %v, want %v", meta.State, tt.state)
+			}
+		})
+	}
+}
+
+func TestStore_TornLogAndStaleRecords(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	s.CreateFile("file", 10, 10, Redundancy{ReplicationFactor: 3}, []string{"file_0"}, "", "")
+	s.AddReplica("file", "file_0", "node1")
+
+	//keep a copy of the log, as if we crashed between writing the snapshot and truncating the log
+	logCopy, _ := os.ReadFile(filepath.Join(dir, walFile))
+	s.Snapshot()
+	s.DeleteFile("file")
+	s.Close()
+
+	stale := append(logCopy, []byte(`{"seq":4,"op":"add_rep`)...)
+	log, _ := os.ReadFile(filepath.Join(dir, walFile))
+	os.WriteFile(filepath.Join(dir, walFile), append(stale[:len(logCopy)], append(log, stale[len(logCopy):]...)...), 0644)
+
+	reopened, err := Open(dir, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer reopened.Close()
+
+	if _, found := reopened.GetFile("file"); found {
+		t.Errorf("deleted file came back after replay")
+	}
+}
+
+func TestStore_Resume(t *testing.T) {
+	tests := []struct {
+		name     string
+		uploadId string
+		commit   bool
+		wantErr  bool
+	}{
+		{name: "Test resume with the upload id", uploadId: "upload"},
+		{name: "Test resume with another upload id", uploadId: "other", wantErr: true},
+		{name: "Test resume without an upload id", uploadId: "", wantErr: true},
+		{name: "Test resume after the commit", uploadId: "upload", commit: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Open(t.TempDir(), zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer s.Close()
+			s.CreateFile("file", 10, 5, Redundancy{ReplicationFactor: 2}, []string{"file_0", "file_1"}, "upload", "")
+			s.AddReplica("file", "file_0", "node1")
+			if tt.commit {
+				s.Commit("file", "")
+			}
+
+			meta, err := s.Resume("file", tt.uploadId)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (meta.Size != 10 || meta.ChunkSize != 5 || meta.UploadId != "upload") {
+				t.Errorf("Resume() = %+v", meta)
+			}
+		})
+	}
+}
+
+func TestStore_Blocks(t *testing.T) {
+	tests := []struct {
+		name string
+		//a log written by an older controller, nil to create the file through the store
+		log  string
+		want []string
+	}{
+		{
+			name: "Test blocks keep the order they were planned in",
+			want: []string{"c3", "a1", "b2"},
+		},
+		{
+			name: "Test fragments of a legacy log are ordered by their number",
+			log:  `{"seq":1,"op":"create","file":"file","size":10,"chunk_size":1,"fragments":["file_1","file_10","file_0","file_2"]}` + "\n",
+			want: []string{"file_0", "file_1", "file_2", "file_10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.log != "" {
+				os.WriteFile(filepath.Join(dir, walFile), []byte(tt.log), 0644)
+			} else {
+				s, err := Open(dir, zap.NewNop())
+				if err != nil {
+					t.Fatalf("Open() error = %v", err)
+				}
+				s.CreateFile("file", 10, 4, Redundancy{ReplicationFactor: 1}, tt.want, "", "")
+				s.Close()
+			}
+
+			reopened, err := Open(dir, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer reopened.Close()
+
+			meta, found := reopened.GetFile("file")
+			if !found {
+				t.Fatalf("file not found after replay")
+			}
+			if !reflect.DeepEqual(meta.Blocks, tt.want) {
+				t.Errorf("Blocks = %v, want %v", meta.Blocks, tt.want)
+			}
+			for _, id := range tt.want {
+				if name, ok := reopened.FileOfBlock(id); !ok || name != "file" {
+					t.Errorf("FileOfBlock(%q) = %q, %v, want %q", id, name, ok, "file")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package metadata
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+const (
+	OpCreate        = "create"
+	OpAddReplica    = "add_replica"
+	OpRemoveReplica = "remove_replica"
+	OpResume        = "resume"
+	OpCommit        = "commit"
+	OpDeleting      = "deleting"
+	OpDelete        = "delete"
+	//written before uploads were committed by the client, replayed as a commit
+	OpComplete = "complete"
+	OpMkdir    = "mkdir"
+	OpRmdir    = "rmdir"
+	OpRename   = "rename"
+	//the replicas a round of block reports added and removed, applied together
+	OpReplicas = "replicas"
+)
+
+// Record is a single entry of the write-ahead log.
+type Record struct {
+	Seq uint64 `json:"seq"`
+	//the term of the active controller that committed it, 0 without a group of controllers
+	Term uint64    `json:"term,omitempty"`
+	Op   string    `json:"op"`
+	Time time.Time `json:"time"`
+	File string    `json:"file"`
+	//where a rename moves File to
+	Dest      string `json:"dest,omitempty"`
+	Fragment  string `json:"fragment,omitempty"`
+	NodeId    string `json:"node_id,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	ChunkSize int64  `json:"chunk_size,omitempty"`
+	//the fragments of a file created before block lists were recorded, in no particular order
+	Fragments []string        `json:"fragments,omitempty"`
+	Blocks    []string        `json:"blocks,omitempty"`
+	UploadId  string          `json:"upload_id,omitempty"`
+	Owner     string          `json:"owner,omitempty"`
+	Checksum  string          `json:"checksum,omitempty"`
+	Replicas  []ReplicaChange `json:"replicas,omitempty"`
+	Redundancy
+}
+
+// ReplicaChange is a replica of a fragment a node gained, or lost if Removed is set.
+type ReplicaChange struct {
+	File     string `json:"file"`
+	Fragment string `json:"fragment"`
+	NodeId   string `json:"node_id"`
+	Removed  bool   `json:"removed,omitempty"`
+}
+
+type wal struct {
+	path string
+	file *os.File
+}
+
+func openWAL(path string) (w *wal, err error) {
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+
+	return &wal{path: path, file: file}, nil
+}
+
+// append writes one JSON record per line and syncs it to disk before returning.
+func (w *wal) append(rec *Record) (err error) {
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+
+	_, err = w.file.Write(append(line, '\n'))
+	if err != nil {
+		return
+	}
+
+	return w.file.Sync()
+}
+
+func (w *wal) truncate() (err error) {
+
+	err = w.file.Truncate(0)
+	if err != nil {
+		return
+	}
+
+	return w.file.Sync()
+}
+
+func (w *wal) close() error {
+	return w.file.Close()
+}
+
+// readWAL returns the records in the log. A torn last line, left by a crash mid-write, is dropped.
+func readWAL(path string) (records []*Record, err error) {
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		rec := &Record{}
+		if errU := json.Unmarshal(scanner.Bytes(), rec); errU != nil {
+			break
+		}
+		records = append(records, rec)
+	}
+
+	return records, scanner.Err()
+}
+
+func readJSON(path string, v interface{}) (found bool, err error) {
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, v)
+	return err == nil, err
+}
+
+// writeJSON replaces the file atomically, so a crash never leaves a half written snapshot behind.
+func writeJSON(path string, v interface{}) (err error) {
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if errC := file.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		return
+	}
+
+	return os.Rename(tmp, path)
+}
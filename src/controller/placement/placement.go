@@ -0,0 +1,68 @@
+package placement
+
+// Node is what placement knows about a storage node.
+type Node struct {
+	ID string
+	//failure domain the node shares with others, such as its rack. Nodes without one are a domain of their own.
+	Domain    string
+	FreeSpace int64
+	//requests the node has served, for policies that balance load
+	Requests int32
+}
+
+func (n Node) domain() string {
+	if n.Domain == "" {
+		return n.ID
+	}
+	return n.Domain
+}
+
+// Policy orders the candidate nodes for the replicas of a fragment, best first.
+type Policy interface {
+	Order(fragment string, candidates []Node) []Node
+}
+
+// Place picks up to n of the candidates to hold new replicas of a fragment that already has replicas on holders.
+// Replicas are spread over as many failure domains as there are: a node is only picked from a domain holding
+// more replicas than another domain with a free candidate if there is no other choice. Among the nodes of the
+// least used domains, the policy's order decides.
+func Place(policy Policy, fragment string, n int, holders []Node, candidates []Node) (chosen []Node) {
+
+	used := make(map[string]int)
+	held := make(map[string]bool)
+	for _, holder := range holders {
+		used[holder.domain()]++
+		held[holder.ID] = true
+	}
+
+	free := make([]Node, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !held[candidate.ID] {
+			free = append(free, candidate)
+			held[candidate.ID] = true
+		}
+	}
+
+	ordered := policy.Order(fragment, free)
+	taken := make([]bool, len(ordered))
+
+	for len(chosen) < n {
+		best := -1
+		for i, node := range ordered {
+			if taken[i] {
+				continue
+			}
+			if best == -1 || used[node.domain()] < used[ordered[best].domain()] {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+
+		taken[best] = true
+		chosen = append(chosen, ordered[best])
+		used[ordered[best].domain()]++
+	}
+	return
+}
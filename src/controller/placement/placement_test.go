@@ -0,0 +1,173 @@
+package placement
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlace(t *testing.T) {
+	tests := []struct {
+		name       string
+		n          int
+		holders    []Node
+		candidates []Node
+		want       []string
+	}{
+		{
+			name: "Test replicas spread over racks",
+			n:    3,
+			candidates: []Node{
+				{ID: "a1", Domain: "a", FreeSpace: 50},
+				{ID: "a2", Domain: "a", FreeSpace: 40},
+				{ID: "b1", Domain: "b", FreeSpace: 30},
+				{ID: "c1", Domain: "c", FreeSpace: 20},
+			},
+			want: []string{"a1", "b1", "c1"},
+		},
+		{
+			name: "Test more replicas than racks",
+			n:    3,
+			candidates: []Node{
+				{ID: "a1", Domain: "a", FreeSpace: 50},
+				{ID: "a2", Domain: "a", FreeSpace: 40},
+				{ID: "b1", Domain: "b", FreeSpace: 30},
+			},
+			want: []string{"a1", "b1", "a2"},
+		},
+		{
+			name:    "Test re-replication avoids the holders' rack",
+			n:       1,
+			holders: []Node{{ID: "a1", Domain: "a"}, {ID: "b1", Domain: "b"}},
+			candidates: []Node{
+				{ID: "a1", Domain: "a", FreeSpace: 50},
+				{ID: "a2", Domain: "a", FreeSpace: 40},
+				{ID: "b2", Domain: "b", FreeSpace: 30},
+				{ID: "c1", Domain: "c", FreeSpace: 10},
+			},
+			want: []string{"c1"},
+		},
+		{
+			name: "Test nodes without a rack are a domain of their own",
+			n:    2,
+			candidates: []Node{
+				{ID: "a1", Domain: "a", FreeSpace: 50},
+				{ID: "a2", Domain: "a", FreeSpace: 40},
+				{ID: "x", FreeSpace: 10},
+			},
+			want: []string{"a1", "x"},
+		},
+		{
+			name:       "Test not enough candidates",
+			n:          3,
+			holders:    []Node{{ID: "a1"}},
+			candidates: []Node{{ID: "a1"}, {ID: "a2"}},
+			want:       []string{"a2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, node := range Place(MostFreeSpace{}, "file_0", tt.n, tt.holders, tt.candidates) {
+				got = append(got, node.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Place() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Order(t *testing.T) {
+	candidates := []Node{
+		{ID: "c", FreeSpace: 30, Requests: 1},
+		{ID: "a", FreeSpace: 10, Requests: 5},
+		{ID: "b", FreeSpace: 30, Requests: 1},
+		{ID: "d", FreeSpace: 20, Requests: 0},
+	}
+	tests := []struct {
+		name   string
+		policy Policy
+		want   [][]string
+	}{
+		{
+			name:   "Test most free space",
+			policy: MostFreeSpace{},
+			want:   [][]string{{"b", "c", "d", "a"}, {"b", "c", "d", "a"}},
+		},
+		{
+			name:   "Test least loaded",
+			policy: LeastLoaded{},
+			want:   [][]string{{"d", "b", "c", "a"}, {"d", "b", "c", "a"}},
+		},
+		{
+			name:   "Test round robin",
+			policy: NewRoundRobin(1),
+			want:   [][]string{{"b", "c", "d", "a"}, {"c", "d", "a", "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, want := range tt.want {
+				var got []string
+				for _, node := range tt.policy.Order("file_0", candidates) {
+					got = append(got, node.ID)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Order() = %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicy_Seeded(t *testing.T) {
+	candidates := []Node{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	reversed := []Node{{ID: "e"}, {ID: "d"}, {ID: "c"}, {ID: "b"}, {ID: "a"}}
+
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			first, err := New(name, 42)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			second, _ := New(name, 42)
+
+			for _, fragment := range []string{"file_0", "file_1", "file_2"} {
+				got := first.Order(fragment, candidates)
+				want := second.Order(fragment, reversed)
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Order(%s) with the same seed = %v and %v", fragment, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConsistentHash_Order(t *testing.T) {
+	policy := NewConsistentHash(7)
+	candidates := []Node{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+
+	for _, fragment := range []string{"file_0", "file_1", "file_2", "file_3"} {
+		ordered := policy.Order(fragment, candidates)
+
+		//without its first node, a fragment keeps the order of the others
+		var rest []Node
+		for _, node := range candidates {
+			if node.ID != ordered[0].ID {
+				rest = append(rest, node)
+			}
+		}
+		if got := policy.Order(fragment, rest); !reflect.DeepEqual(got, ordered[1:]) {
+			t.Errorf("Order(%s) without %s = %v, want %v", fragment, ordered[0].ID, got, ordered[1:])
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("no-such-policy", 0); err == nil {
+		t.Errorf("New() of an unknown policy returned no error")
+	}
+	if _, err := New(DEFAULT_POLICY, 0); err != nil {
+		t.Errorf("New() of the default policy error = %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package placement
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// byID sorts a copy of the candidates by ID. Candidates come from map iteration, so policies that do not
+// order by a property of the nodes start from this to give the same order for the same seed.
+func byID(candidates []Node) []Node {
+
+	ordered := append([]Node(nil), candidates...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].ID < ordered[j].ID
+	})
+	return ordered
+}
+
+// MostFreeSpace prefers the nodes with the most free space.
+type MostFreeSpace struct{}
+
+func (MostFreeSpace) Order(fragment string, candidates []Node) []Node {
+
+	ordered := byID(candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].FreeSpace > ordered[j].FreeSpace
+	})
+	return ordered
+}
+
+// LeastLoaded prefers the nodes that have served the fewest requests, then the ones with the most free space.
+type LeastLoaded struct{}
+
+func (LeastLoaded) Order(fragment string, candidates []Node) []Node {
+
+	ordered := byID(candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Requests != ordered[j].Requests {
+			return ordered[i].Requests < ordered[j].Requests
+		}
+		return ordered[i].FreeSpace > ordered[j].FreeSpace
+	})
+	return ordered
+}
+
+// RoundRobin starts every placement one node further along the candidates, so consecutive fragments land on
+// consecutive nodes.
+type RoundRobin struct {
+	mutex sync.Mutex
+	next  int
+}
+
+// NewRoundRobin starts the rotation at an offset taken from seed.
+func NewRoundRobin(seed int64) *RoundRobin {
+	return &RoundRobin{next: int(uint64(seed) % 1024)}
+}
+
+func (r *RoundRobin) Order(fragment string, candidates []Node) []Node {
+
+	ordered := byID(candidates)
+	if len(ordered) == 0 {
+		return ordered
+	}
+
+	r.mutex.Lock()
+	start := r.next % len(ordered)
+	r.next++
+	r.mutex.Unlock()
+
+	return append(ordered[start:], ordered[:start]...)
+}
+
+// ConsistentHash places nodes and fragments on a hash ring, and orders the nodes clockwise from the fragment.
+// A fragment keeps its nodes when others join or leave, except for the ones that moved.
+type ConsistentHash struct {
+	seed int64
+}
+
+func NewConsistentHash(seed int64) *ConsistentHash {
+	return &ConsistentHash{seed: seed}
+}
+
+func (c *ConsistentHash) hash(key string) uint64 {
+
+	h := fnv.New64a()
+	binary.Write(h, binary.BigEndian, c.seed)
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
+func (c *ConsistentHash) Order(fragment string, candidates []Node) []Node {
+
+	position := c.hash(fragment)
+	ordered := byID(candidates)
+	distance := make(map[string]uint64, len(ordered))
+	for _, node := range ordered {
+		//wraps around the ring
+		distance[node.ID] = c.hash(node.ID) - position
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return distance[ordered[i].ID] < distance[ordered[j].ID]
+	})
+	return ordered
+}
+
+// Random orders the candidates at random.
+type Random struct {
+	mutex sync.Mutex
+	rand  *rand.Rand
+}
+
+func NewRandom(seed int64) *Random {
+	return &Random{rand: rand.New(rand.NewSource(seed))}
+}
+
+func (r *Random) Order(fragment string, candidates []Node) []Node {
+
+	ordered := byID(candidates)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.rand.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	return ordered
+}
@@ -0,0 +1,43 @@
+package placement
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DEFAULT_POLICY is used when the Controller is not told which policy to place fragments with.
+const DEFAULT_POLICY = "random"
+
+var policies = map[string]func(seed int64) Policy{
+	"most-free-space": func(seed int64) Policy { return MostFreeSpace{} },
+	"least-loaded":    func(seed int64) Policy { return LeastLoaded{} },
+	"round-robin":     func(seed int64) Policy { return NewRoundRobin(seed) },
+	"consistent-hash": func(seed int64) Policy { return NewConsistentHash(seed) },
+	"random":          func(seed int64) Policy { return NewRandom(seed) },
+}
+
+// New returns the policy registered under name. Policies built with the same seed place fragments the same way.
+func New(name string, seed int64) (Policy, error) {
+
+	policy, ok := policies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown placement policy %q, choose one of %s", name, strings.Join(Names(), ", "))
+	}
+	return policy(seed), nil
+}
+
+// Register adds a policy that New can build by name. It is meant to be called before the Controller starts.
+func Register(name string, policy func(seed int64) Policy) {
+	policies[name] = policy
+}
+
+// Names lists the registered policies.
+func Names() (names []string) {
+
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
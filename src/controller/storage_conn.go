@@ -1,21 +1,28 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"go.uber.org/zap"
 	"net"
 	"src/controller/storage_handler"
 	storageNodeMessages "src/messages/controller_storage"
 	storageNodeProto3 "src/proto/controller_storage"
+	"sync"
+	"time"
 )
 
-func acceptStorageNodeConnections(listener1 net.Listener, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+func acceptStorageNodeConnections(ctx context.Context, listener1 net.Listener, spokeHandler *storage_handler.StorageNodeHandler, logger *zap.Logger) {
 	for {
 		if conn, err := listener1.Accept(); err == nil {
 
 			msgHandler := storageNodeMessages.NewMessageHandler(conn)
-			go handleStorageNode(msgHandler, spokeHandler, logger)
+			//a node heartbeats every HEARTBEAT_INTERVAL, one that stays silent for longer than ACCEPTED_DELAY is gone
+			msgHandler.SetTimeouts(ACCEPTED_DELAY*time.Second, SESSION_WRITE_TIMEOUT*time.Second)
+			go handleStorageNode(ctx, msgHandler, spokeHandler, logger)
 
+		} else if ctx.Err() != nil {
+			return
 		} else {
 
 			logger.Error(err.Error())
@@ -24,11 +31,28 @@ func acceptStorageNodeConnections(listener1 net.Listener, spokeHandler *storage_
 
 }
 
-func handleStorageNode(msgHandler *storageNodeMessages.MessageHandler, spoke *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+// handleStorageNode serves a node's session until its connection breaks. The node introduces itself on it,
+// sends its heartbeats and acknowledges the commands pushed to it.
+func handleStorageNode(ctx context.Context, msgHandler *storageNodeMessages.MessageHandler, spoke *storage_handler.StorageNodeHandler, logger *zap.Logger) {
 	defer msgHandler.Close()
+	defer closeOnDone(ctx, msgHandler.Close)()
 	proto := storageNodeProto3.NewProtoHandler(msgHandler)
+	session := storageNodeProto3.NewSession(proto, COMMAND_TIMEOUT*time.Second)
+	defer session.Close()
+
+	var sessionNode string
+	defer func() {
+		if sessionNode != "" {
+			spoke.RemoveSession(sessionNode, session)
+		}
+	}()
+
 	for {
-		wrapper, _ := proto.MsgHandler().ClientRequestReceive()
+		wrapper, err := proto.MsgHandler().ClientRequestReceive()
+		if err != nil {
+			logger.Info("Storage node session closed", zap.String("nodeId", sessionNode), zap.Error(err))
+			return
+		}
 
 		switch wrapper.StorageNodeMessage.(type) {
 
@@ -74,7 +98,10 @@ func handleStorageNode(msgHandler *storageNodeMessages.MessageHandler, spoke *st
 					*/
 				}
 
-				return
+				//commands for the node are pushed on the session it introduced itself on
+				sessionNode = nodeId
+				spoke.SetSession(nodeId, session)
+
 			case "heartbeat":
 				//TODO: trim this down later
 				Req := ReqHandler.(*storageNodeProto3.Request)
@@ -103,60 +130,135 @@ func handleStorageNode(msgHandler *storageNodeMessages.MessageHandler, spoke *st
 						proto.HandleHeartbeatMiss(nodeId)
 					} else {
 						logger.Sugar().Info("Received hb from:", nodeId)
-						//TODO: handle replication
-						if spoke.ReplicaRequired(nodeId) {
-							nodesProto := make([]*storageNodeProto3.FragmentDistribution, 0)
-							p, err := spoke.FillFilesToReplicate(nodeId, nodesProto)
-							nodesProto = p
-
-							logger.Sugar().Info("Nodes to replicate: ", nodesProto)
-							if err != nil {
-								logger.Error(err.Error())
-							} else {
-
-								spoke.RemoveReplicaRequired(nodeId)
-								logger.Sugar().Info("Sending replication request to node \n", nodeId)
-								proto.HandleReplicationRequest(nodesProto, nodeId)
-							}
-
-						}
 
 						go spoke.ResetTimer(nodeId)
-						go func() {
-							if Req == nil {
-								logger.Error("Request is nil")
-							} else {
-								err := spoke.UpdateNodeStats(Req)
-								if err != nil {
-								}
-							}
-
-						}()
+
+						//block report deltas build on each other, so they are applied in the order they arrive
+						err := spoke.UpdateNodeStats(Req)
+						if err == storage_handler.ErrReportGap {
+							logger.Info("Block report out of sequence, asking for a full one", zap.String("nodeId", nodeId))
+							proto.HandleBlockReportRequest()
+						} else if err != nil {
+							logger.Error("Error updating node stats", zap.String("nodeId", nodeId), zap.Error(err))
+						}
 					}
 				}
 
-				return
 			case "fileCorruption":
-				Req := ReqHandler.(*storageNodeProto3.Request)
-				nodeId := Req.GetNodeId()
-				fileName := Req.CorruptedFile()
-				logger.Sugar().Info("File %s is corrupted on node %s\n", fileName, nodeId)
-				nodes := spoke.HasFile(fileName, nodeId)
-				nodesProto := make([]*storageNodeProto3.Node, len(nodes))
-				spoke.FillNodes(nodes, nodesProto)
-
-				//print nodes
-				for _, node := range nodesProto {
-					logger.Sugar().Info("Node: ", node)
-				}
-
-				proto.HandleFileCorruptionResponse(nodesProto, Req)
+				//the other holders are asked to verify their copies, which must not hold up this session
+				go handleFileCorruption(proto, ReqHandler.(*storageNodeProto3.Request), spoke, logger)
 
+			case "ack":
+				session.Acknowledge(ReqHandler.(*storageNodeProto3.Ack))
 			}
 
 		case nil:
-			return
+			logger.Warn("Unknown message on storage node session", zap.String("nodeId", sessionNode))
 		}
 
 	}
 }
+
+// handleFileCorruption tells a node that reported a corrupt fragment where to fetch a good copy. Only the
+// holders whose own copy verifies against its checksum are sent.
+func handleFileCorruption(proto *storageNodeProto3.ProtoHandler, Req *storageNodeProto3.Request, spoke *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+
+	nodeId := Req.GetNodeId()
+	fileName := Req.CorruptedFile()
+	logger.Sugar().Infof("File %s is corrupted on node %s", fileName, nodeId)
+
+	nodes := verifiedHolders(fileName, spoke.HasFile(fileName, nodeId), spoke, logger)
+	nodesProto := make([]*storageNodeProto3.Node, len(nodes))
+	spoke.FillNodes(nodes, nodesProto)
+
+	//print nodes
+	for _, node := range nodesProto {
+		logger.Sugar().Info("Node: ", node)
+	}
+
+	proto.HandleFileCorruptionResponse(nodesProto, Req)
+}
+
+// verifiedHolders pushes a verify command for the fragment to every node holding it, and keeps the ones that acknowledge a good copy.
+func verifiedHolders(fragment string, nodes []storage_handler.Node, spoke *storage_handler.StorageNodeHandler, logger *zap.Logger) (verified []storage_handler.Node) {
+
+	valid := make([]bool, len(nodes))
+	var wg sync.WaitGroup
+
+	for i := range nodes {
+		session := spoke.Session(nodes[i].GetID())
+		if session == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, session *storageNodeProto3.Session) {
+			defer wg.Done()
+
+			ack, err := session.Verify([]string{fragment})
+			if err != nil {
+				logger.Error("Failed to verify fragment", zap.String("fragment", fragment), zap.String("nodeId", nodes[i].GetID()), zap.Error(err))
+				return
+			}
+			valid[i] = ack.Success
+		}(i, session)
+	}
+	wg.Wait()
+
+	for i, node := range nodes {
+		if valid[i] {
+			verified = append(verified, node)
+		}
+	}
+	return
+}
+
+// pushRepairs sends the replication and reconstruction work found by indexing to the nodes that have to do
+// it, without waiting for their next heartbeat. Work for a node without a session is left for the next round.
+func pushRepairs(spoke *storage_handler.StorageNodeHandler, logger *zap.Logger) {
+
+	for _, node := range spoke.GetStorageNodes() {
+		nodeId := node.GetID()
+		session := spoke.Session(nodeId)
+		if session == nil {
+			continue
+		}
+
+		if spoke.ReplicaRequired(nodeId) {
+			nodesProto, err := spoke.FillFilesToReplicate(nodeId, nil)
+			if err != nil {
+				logger.Error(err.Error())
+			} else {
+				spoke.RemoveReplicaRequired(nodeId)
+				logger.Sugar().Info("Sending replication request to node ", nodeId)
+				go logCommand("replication", nodeId, logger, func() (*storageNodeProto3.Ack, error) {
+					return session.Replicate(nodesProto)
+				})
+			}
+		}
+
+		if spoke.ReconstructionRequired(nodeId) {
+			shards := spoke.FillShardsToReconstruct(nodeId)
+			spoke.RemoveReconstructionRequired(nodeId)
+			logger.Sugar().Info("Sending reconstruction request to node ", nodeId)
+			go logCommand("reconstruction", nodeId, logger, func() (*storageNodeProto3.Ack, error) {
+				return session.Reconstruct(shards)
+			})
+		}
+	}
+}
+
+// logCommand runs a command and logs how the node acknowledged it. Anything it failed on is found again by the next indexing.
+func logCommand(command string, nodeId string, logger *zap.Logger, run func() (*storageNodeProto3.Ack, error)) {
+
+	ack, err := run()
+	if err != nil {
+		logger.Error("Command failed", zap.String("command", command), zap.String("nodeId", nodeId), zap.Error(err))
+		return
+	}
+	if !ack.Success {
+		logger.Warn("Command partly failed", zap.String("command", command), zap.String("nodeId", nodeId), zap.Strings("failed", ack.Failed))
+		return
+	}
+	logger.Info("Command done", zap.String("command", command), zap.String("nodeId", nodeId))
+}
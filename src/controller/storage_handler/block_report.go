@@ -0,0 +1,78 @@
+package storage_handler
+
+import (
+	"errors"
+	"src/proto/controller_storage"
+	"time"
+)
+
+// NEW_FILE_WINDOW is how long after a block report added a file it counts as new, and is not re-replicated.
+const NEW_FILE_WINDOW = time.Minute
+
+// ErrReportGap is returned for a block report that does not follow the last one applied for the node.
+// The node has to send a full report next.
+var ErrReportGap = errors.New("block report out of sequence")
+
+// applyBlockReport updates the files of a node and the Index with a block report. A full report replaces
+// what the node was known to hold, a delta is only applied on top of the report numbered just before it.
+// Callers hold the write lock.
+func (sh *StorageNodeHandler) applyBlockReport(node *Node, report controller_storage.BlockReport) (err error) {
+
+	if !report.Full && (!node.reported || report.Seq != node.reportSeq+1) {
+		return ErrReportGap
+	}
+
+	now := time.Now()
+	added := report.Added
+	removed := report.Removed
+
+	if report.Full {
+		known := node.files
+		node.files = make(map[string]time.Time, len(report.Files))
+		added = nil
+		removed = nil
+
+		for _, f := range report.Files {
+			since, ok := known[f]
+			if !ok {
+				//what the node held when it first reported is not new
+				if node.reported {
+					since = now
+				}
+				added = append(added, f)
+			}
+			node.files[f] = since
+		}
+		for f := range known {
+			if _, ok := node.files[f]; !ok {
+				removed = append(removed, f)
+			}
+		}
+	} else {
+		for _, f := range added {
+			if _, ok := node.files[f]; !ok {
+				node.files[f] = now
+			}
+		}
+		for _, f := range removed {
+			delete(node.files, f)
+		}
+	}
+
+	for _, f := range added {
+		//the space it took is now part of the free space the node reports
+		delete(sh.reservations[node.ID], f)
+		if fileName, ok := sh.fileOfBlock(f); ok {
+			sh.Index.addReplica(fileName, f, node.ID)
+		}
+	}
+	for _, f := range removed {
+		if fileName, ok := sh.fileOfBlock(f); ok {
+			sh.Index.removeReplica(fileName, f, node.ID)
+		}
+	}
+
+	node.reported = true
+	node.reportSeq = report.Seq
+	return
+}
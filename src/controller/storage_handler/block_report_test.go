@@ -0,0 +1,91 @@
+package storage_handler
+
+import (
+	"go.uber.org/zap"
+	"reflect"
+	"src/controller/metadata"
+	"src/proto/controller_storage"
+	"testing"
+)
+
+func TestStorageNodeHandler_applyBlockReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		reports   []controller_storage.BlockReport
+		wantErr   error
+		wantFiles []string
+		wantIndex map[string]map[string][]string
+	}{
+		{
+			name: "Test full report",
+			reports: []controller_storage.BlockReport{
+				{Seq: 1, Full: true, Files: []string{"file_0", "file_0.checksum", "file_1"}},
+			},
+			wantFiles: []string{"file_0", "file_0.checksum", "file_1"},
+			wantIndex: map[string]map[string][]string{"file": {"file_0": {"node1"}, "file_1": {"node1"}}},
+		},
+		{
+			name: "Test deltas applied in sequence",
+			reports: []controller_storage.BlockReport{
+				{Seq: 1, Full: true, Files: []string{"file_0"}},
+				{Seq: 2, Added: []string{"file_1", "other_0"}},
+				{Seq: 3, Removed: []string{"file_0"}},
+			},
+			wantFiles: []string{"file_1", "other_0"},
+			wantIndex: map[string]map[string][]string{"file": {"file_1": {"node1"}}, "other": {"other_0": {"node1"}}},
+		},
+		{
+			name: "Test delta before any full report",
+			reports: []controller_storage.BlockReport{
+				{Seq: 4, Added: []string{"file_0"}},
+			},
+			wantErr:   ErrReportGap,
+			wantFiles: []string{},
+			wantIndex: map[string]map[string][]string{},
+		},
+		{
+			name: "Test delta out of sequence",
+			reports: []controller_storage.BlockReport{
+				{Seq: 1, Full: true, Files: []string{"file_0"}},
+				{Seq: 3, Added: []string{"file_1"}},
+			},
+			wantErr:   ErrReportGap,
+			wantFiles: []string{"file_0"},
+			wantIndex: map[string]map[string][]string{"file": {"file_0": {"node1"}}},
+		},
+		{
+			name: "Test full report replaces earlier files",
+			reports: []controller_storage.BlockReport{
+				{Seq: 1, Full: true, Files: []string{"file_0", "file_1"}},
+				{Seq: 7, Full: true, Files: []string{"file_1", "file_2"}},
+			},
+			wantFiles: []string{"file_1", "file_2"},
+			wantIndex: map[string]map[string][]string{"file": {"file_1": {"node1"}, "file_2": {"node1"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.SetMetadataStore(storeOf(t, nil), 0)
+			sh.RecordFile("file", 30, 10, metadata.Redundancy{ReplicationFactor: 1}, []string{"file_0", "file_1", "file_2"}, "", "")
+			sh.RecordFile("other", 10, 10, metadata.Redundancy{ReplicationFactor: 1}, []string{"other_0"}, "", "")
+			node := &Node{ID: "node1", files: fileSet()}
+			sh.spokeMap["node1"] = node
+
+			var err error
+			for _, report := range tt.reports {
+				err = sh.applyBlockReport(node, report)
+			}
+
+			if err != tt.wantErr {
+				t.Errorf("applyBlockReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := node.GetAllFiles(); !reflect.DeepEqual(got, tt.wantFiles) {
+				t.Errorf("GetAllFiles() = %v, want %v", got, tt.wantFiles)
+			}
+			if got := sh.Index.GetFileMap(); !reflect.DeepEqual(got, tt.wantIndex) {
+				t.Errorf("fileMap = %v, want %v", got, tt.wantIndex)
+			}
+		})
+	}
+}
@@ -1,11 +1,11 @@
 package storage_handler
 
 import (
-	"errors"
 	"go.uber.org/zap"
-	"math/rand"
-	"regexp"
+	"src/controller/metadata"
+	"src/controller/placement"
 	"src/proto/controller_storage"
+	"time"
 )
 
 type Index struct {
@@ -16,6 +16,9 @@ type Index struct {
 	//map: node id -> slice of file fragments that need to be replicated by it
 	replicasNeeded map[string][]string
 
+	//map: node id -> slice of erasure coded shards it needs to rebuild
+	shardsNeeded map[string][]string
+
 	logger *zap.Logger
 	//IndexMutex *sync.RWMutex
 }
@@ -26,6 +29,7 @@ func NewIndex(logger *zap.Logger) (index *Index) {
 		//IndexMutex:     &sync.RWMutex{},
 		logger:         logger,
 		replicasNeeded: make(map[string][]string),
+		shardsNeeded:   make(map[string][]string),
 	}
 	return
 }
@@ -54,39 +58,32 @@ func (sh *StorageNodeHandler) FillFilesToReplicate(id string, p []*controller_st
 			Nodes:    make([]*controller_storage.Node, 0),
 		})
 
-		fileName := regexp.MustCompile(`_\d+$`).ReplaceAllString(f, "")
+		fileName, _ := sh.fileOfBlock(f)
 
 		nodesWithFile := sh.Index.GetFileMap()[fileName][f]
-		if len(sh.spokeMap) < 3 {
-			err = errors.New("not enough nodes to replicate")
-			return
-		}
 
-		spokes := make([]string, 0)
-		for k := range sh.spokeMap {
-			spokes = append(spokes, k)
+		//with fewer live nodes than the replication factor, replicate to as many as there are
+		target := sh.replicationFactor(fileName)
+		if len(sh.spokeMap) < target {
+			sh.logger.Warn("Not enough nodes to meet the replication factor", zap.String("fragment", f), zap.Int("replication factor", target))
+			target = len(sh.spokeMap)
 		}
 
-		//shuffle the spokes
-
-		rand.Shuffle(len(spokes), func(i, j int) { spokes[i], spokes[j] = spokes[j], spokes[i] })
-
-		count := 0
-		for i := 0; i < len(spokes); i++ {
-			if !contains(nodesWithFile, spokes[i]) {
-				//TODO: Error here. might need to restructure the code
-				proto[index].Nodes = append(proto[index].Nodes, &controller_storage.Node{
-					ID:   spokes[i],
-					Host: sh.spokeMap[spokes[i]].host,
-				})
-
-				count++
-				if len(nodesWithFile)+count == 3 {
-					break
-				}
+		//new replicas go to other failure domains than the ones holding the fragment where possible
+		holders := make([]placement.Node, 0, len(nodesWithFile))
+		for _, nodeId := range nodesWithFile {
+			if node, ok := sh.spokeMap[nodeId]; ok {
+				holders = append(holders, node.Placement())
 			}
 		}
 
+		for _, node := range placement.Place(sh.policy, f, target-len(holders), holders, sh.placementCandidates(nil)) {
+			proto[index].Nodes = append(proto[index].Nodes, &controller_storage.Node{
+				ID:   node.ID,
+				Host: sh.spokeMap[node.ID].host,
+			})
+		}
+
 		index++
 	}
 
@@ -109,33 +106,165 @@ func (i *Index) GetFileMap() map[string]map[string][]string {
 	return i.fileMap
 }
 
-func (sh *StorageNodeHandler) ConcurrentIndexing() {
+// LocateFragments resolves every fragment of a file to the nodes holding it. The Index is consulted first,
+// then the latest heartbeat listings are used to pick up fragments written since the last indexing run.
+// Replicas on nodes that are no longer registered are returned as bare nodes carrying only their ID.
+func (sh *StorageNodeHandler) LocateFragments(fileName string) (fragMap map[string][]*Node) {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	fragMap = make(map[string][]*Node)
+	seen := make(map[string]map[string]bool)
+
+	add := func(frag string, node *Node) {
+		if _, ok := seen[frag]; !ok {
+			seen[frag] = make(map[string]bool)
+		}
+		if seen[frag][node.ID] {
+			return
+		}
+		seen[frag][node.ID] = true
+		fragMap[frag] = append(fragMap[frag], node)
+	}
+
+	addIDs := func(fragMap map[string][]string) {
+		for frag, nodeIDs := range fragMap {
+			for _, id := range nodeIDs {
+				if node, ok := sh.spokeMap[id]; ok {
+					add(frag, node)
+				} else {
+					add(frag, &Node{ID: id})
+				}
+			}
+		}
+	}
+
+	addIDs(sh.Index.fileMap[fileName])
+	if sh.meta != nil {
+		if meta, ok := sh.meta.GetFile(fileName); ok {
+			addIDs(meta.Fragments)
+		}
+	}
+
+	for _, node := range sh.spokeMap {
+		for f := range node.files {
+			if name, ok := sh.fileOfBlock(f); ok && name == fileName {
+				add(f, node)
+			}
+		}
+	}
+
+	if len(fragMap) == 0 {
+		return nil
+	}
+
+	return
+}
+
+// RemoveFragment forgets a fragment replica on a node, so it is not served before the node's next heartbeat.
+func (sh *StorageNodeHandler) RemoveFragment(nodeId string, frag string) {
 
 	sh.mutex.Lock()
-	defer sh.mutex.Unlock()
-	//TODO: might cause nil pointer dereference
-	sh.Index.fileMap = nil
-	sh.Index.fileMap = make(map[string]map[string][]string)
+	if node, ok := sh.spokeMap[nodeId]; ok {
+		delete(node.files, frag)
+	}
 
-	sh.Index.replicasNeeded = nil
-	sh.Index.replicasNeeded = make(map[string][]string)
+	fileName, ok := sh.fileOfBlock(frag)
+	if ok {
+		sh.Index.removeReplica(fileName, frag, nodeId)
+	}
+	sh.mutex.Unlock()
+
+	//only a block of a file in the metadata store has one
+	if ok {
+		sh.meta.RemoveReplica(fileName, frag, nodeId)
+	}
+}
+
+// addReplica records that a node holds a fragment, unless it is already known to.
+func (i *Index) addReplica(fileName string, frag string, nodeId string) {
+
+	if _, ok := i.fileMap[fileName]; !ok {
+		i.fileMap[fileName] = make(map[string][]string)
+	}
+	for _, id := range i.fileMap[fileName][frag] {
+		if id == nodeId {
+			return
+		}
+	}
+	i.fileMap[fileName][frag] = append(i.fileMap[fileName][frag], nodeId)
+}
+
+func (i *Index) removeReplica(fileName string, frag string, nodeId string) {
+
+	if fragMap, ok := i.fileMap[fileName]; ok {
+		fragMap[frag] = removeString(fragMap[frag], nodeId)
+		if len(fragMap[frag]) == 0 {
+			delete(fragMap, frag)
+		}
+		if len(fragMap) == 0 {
+			delete(i.fileMap, fileName)
+		}
+	}
+}
+
+func removeString(list []string, s string) []string {
+	kept := make([]string, 0, len(list))
+	for _, v := range list {
+		if v != s {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+func (sh *StorageNodeHandler) ConcurrentIndexing() {
 
+	sh.mutex.RLock()
+	reported := make(map[string]map[string][]string)
 	newFiles := make(map[string]bool)
 
 	for _, node := range sh.spokeMap {
-		//if the file name ends with _x, then it is a file fragment
-		for _, f := range node.allFiles {
+		//blocks no file was planned with are left out
+		for f, added := range node.files {
 
-			if isFileFragment(f) {
-				sh.updateFileMap(f, node.ID)
+			if fileName, ok := sh.fileOfBlock(f); ok {
+				addToFileMap(reported, fileName, f, node.ID)
+			}
+
+			//a fragment still being replicated by its PUT is not short of replicas yet
+			if !added.IsZero() && time.Since(added) < NEW_FILE_WINDOW {
+				newFiles[f] = true
 			}
 		}
 
-		for _, newFile := range node.newFiles {
-			newFiles[newFile] = true
+	}
+
+	var changes []metadata.ReplicaChange
+	if sh.meta != nil {
+		changes = sh.reconcileMetadata(reported)
+	}
+	sh.mutex.RUnlock()
+
+	//the heartbeats only tell us what the live nodes hold, the metadata store is the source of truth. It is
+	//committed to without the lock, so a standby that is slow to apply it does not hold up the nodes.
+	if len(changes) != 0 {
+		err := sh.meta.UpdateReplicas(changes)
+		if err != nil {
+			sh.logger.Warn("Error recording the replicas reported", zap.Error(err))
 		}
+	}
 
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	sh.Index.fileMap = reported
+	if sh.meta != nil {
+		sh.Index.fileMap = sh.liveFileMap()
 	}
+	sh.Index.replicasNeeded = make(map[string][]string)
+	sh.Index.shardsNeeded = make(map[string][]string)
 
 	//print the file map
 	sh.logger.Info("The new files are:", zap.Any("fileMap", newFiles))
@@ -152,9 +281,13 @@ func (sh *StorageNodeHandler) replicaCountCheck(newFiles map[string]bool) {
 		return
 	}
 	for fileName, fragMap := range sh.Index.fileMap {
+		//an upload still writes its replicas, and a file being deleted loses them on purpose
+		if !sh.committed(fileName) {
+			continue
+		}
 		for fragment, nodeIDs := range fragMap {
 
-			if len(nodeIDs) < 3 && !newFiles[fragment] {
+			if len(nodeIDs) != 0 && len(nodeIDs) < sh.replicationFactor(fileName) && !newFiles[fragment] {
 
 				sh.logger.Warn("Replica count not met", zap.String("fragment: ", fragment))
 				//TODO: send a message to the node to replicate the file
@@ -169,39 +302,222 @@ func (sh *StorageNodeHandler) replicaCountCheck(newFiles map[string]bool) {
 
 		}
 	}
+
+	//a lost shard has no replica to copy, it is rebuilt from the rest of its group instead
+	if sh.meta != nil {
+		sh.shardCountCheck()
+	}
 }
 
-func (sh *StorageNodeHandler) updateFileMap(f string, nodeID string) {
+// shardCountCheck schedules every shard of an erasure coded file that no live node holds to be rebuilt.
+// A shard is rebuilt on a node that holds no other shard of its group, so the group keeps surviving the
+// loss of as many nodes as it has parity shards. Callers hold the write lock.
+func (sh *StorageNodeHandler) shardCountCheck() {
+
+	for name, meta := range sh.meta.Files() {
+		if !meta.ErasureCoded() || meta.State != metadata.StateCommitted {
+			continue
+		}
+
+		layout := erasureLayout(meta)
+		for group := 0; group < layout.Groups(); group++ {
+
+			holders := make(map[string]bool)
+			missing := make([]string, 0)
+			for shard := 0; shard < layout.Shards(); shard++ {
+				shardName := layout.Block(group, shard)
+				nodeIDs := sh.Index.fileMap[name][shardName]
+				if len(nodeIDs) == 0 {
+					missing = append(missing, shardName)
+				}
+				for _, id := range nodeIDs {
+					holders[id] = true
+				}
+			}
+
+			if len(missing) == 0 {
+				continue
+			}
+			if layout.Shards()-len(missing) < layout.DataShards {
+				sh.logger.Error("Too many shards lost to rebuild", zap.String("file", name), zap.Int("group", group), zap.Int("missing", len(missing)))
+				continue
+			}
+
+			for _, shardName := range missing {
+				target, ok := sh.rebuildTarget(shardName, holders)
+				if !ok {
+					sh.logger.Warn("No node left to rebuild shard on", zap.String("shard", shardName))
+					break
+				}
+				sh.logger.Warn("Shard lost, rebuilding", zap.String("shard", shardName), zap.String("nodeId", target))
+				holders[target] = true
+				sh.Index.shardsNeeded[target] = append(sh.Index.shardsNeeded[target], shardName)
+			}
+		}
+	}
+}
 
-	pattern := regexp.MustCompile(`_\d+$`)
+// rebuildTarget picks a live node to rebuild a lost shard on. It holds no shard of the group, and is in a
+// failure domain holding as few of them as possible.
+func (sh *StorageNodeHandler) rebuildTarget(shard string, holders map[string]bool) (id string, ok bool) {
 
-	//find the file name
-	fileName := pattern.ReplaceAllString(f, "")
+	group := make([]placement.Node, 0, len(holders))
+	for nodeId := range holders {
+		if node, live := sh.spokeMap[nodeId]; live {
+			group = append(group, node.Placement())
+		}
+	}
 
-	if _, ok := sh.Index.fileMap[fileName]; !ok {
-		sh.Index.fileMap[fileName] = make(map[string][]string)
+	chosen := placement.Place(sh.policy, shard, 1, group, sh.placementCandidates(holders))
+	if len(chosen) == 0 {
+		return "", false
 	}
+	return chosen[0].ID, true
+}
 
-	if _, ok := sh.Index.fileMap[fileName][f]; !ok {
-		sh.Index.fileMap[fileName][f] = make([]string, 0)
+// placementCandidates describes the live nodes not in exclude to the placement policy, with the space
+// reserved by pending uploads taken off their free space.
+func (sh *StorageNodeHandler) placementCandidates(exclude map[string]bool) (candidates []placement.Node) {
+
+	now := time.Now()
+	candidates = make([]placement.Node, 0, len(sh.spokeMap))
+	for nodeId, node := range sh.spokeMap {
+		if !exclude[nodeId] {
+			candidate := node.Placement()
+			candidate.FreeSpace -= sh.reserved(nodeId, now)
+			candidates = append(candidates, candidate)
+		}
 	}
+	return
+}
 
-	sh.Index.fileMap[fileName][f] = append(sh.Index.fileMap[fileName][f], nodeID)
+func (sh *StorageNodeHandler) ReconstructionRequired(nodeId string) (needed bool) {
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
 
+	_, needed = sh.Index.shardsNeeded[nodeId]
+	return
 }
 
-func isFileFragment(f string) bool {
+// FillShardsToReconstruct lists the shards a node has to rebuild, each with the live nodes holding the rest of its group.
+func (sh *StorageNodeHandler) FillShardsToReconstruct(id string) (shards []*controller_storage.ShardReconstruction) {
 
-	//check if the file name ends with _x, where x is a number
-	//if it does, then it is a file fragment
-	//if it doesn't, then it is a file
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
 
-	pattern := regexp.MustCompile(`_\d+$`)
+	shards = make([]*controller_storage.ShardReconstruction, 0)
+	for _, shardName := range sh.Index.shardsNeeded[id] {
 
-	if pattern.MatchString(f) {
-		return true
+		fileName, ok := sh.fileOfBlock(shardName)
+		if !ok {
+			continue
+		}
+		meta, ok := sh.meta.GetFile(fileName)
+		if !ok {
+			continue
+		}
+
+		layout := erasureLayout(meta)
+		index, ok := layout.Position(shardName)
+		if !ok {
+			continue
+		}
+		group, _ := layout.Locate(index)
+
+		reconstruction := &controller_storage.ShardReconstruction{
+			Shard:   shardName,
+			Layout:  layout,
+			Sources: make([]*controller_storage.ShardSource, 0),
+		}
+		for shard := 0; shard < layout.Shards(); shard++ {
+			source := layout.Block(group, shard)
+			for _, nodeId := range sh.Index.fileMap[fileName][source] {
+				if node, ok := sh.spokeMap[nodeId]; ok {
+					reconstruction.Sources = append(reconstruction.Sources, &controller_storage.ShardSource{
+						Shard: source,
+						Node:  &controller_storage.Node{ID: node.ID, Host: node.host, Port: node.openPort},
+					})
+					break
+				}
+			}
+		}
+		shards = append(shards, reconstruction)
 	}
+	return
+}
 
-	return false
+func (sh *StorageNodeHandler) RemoveReconstructionRequired(id string) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
 
+	delete(sh.Index.shardsNeeded, id)
+}
+
+// addToFileMap records that a node holds a fragment of a file in fileMap.
+func addToFileMap(fileMap map[string]map[string][]string, fileName string, f string, nodeID string) {
+
+	if _, ok := fileMap[fileName]; !ok {
+		fileMap[fileName] = make(map[string][]string)
+	}
+
+	fileMap[fileName][f] = append(fileMap[fileName][f], nodeID)
+
+}
+
+// reconcileMetadata returns the changes that merge the heartbeat view in reported into the metadata store:
+// the replicas reported, and the ones recorded that live nodes no longer report. Replicas on nodes that have
+// not re-registered since the controller started are kept through the grace period. Callers hold the lock.
+func (sh *StorageNodeHandler) reconcileMetadata(reported map[string]map[string][]string) (changes []metadata.ReplicaChange) {
+
+	//only blocks the store knows were indexed, so every one has its file
+	reportedBy := make(map[string]map[string]bool)
+	for fileName, fragMap := range reported {
+		for frag, nodeIDs := range fragMap {
+			reportedBy[frag] = make(map[string]bool)
+			for _, id := range nodeIDs {
+				reportedBy[frag][id] = true
+				changes = append(changes, metadata.ReplicaChange{File: fileName, Fragment: frag, NodeId: id})
+			}
+		}
+	}
+
+	graceOver := time.Since(sh.started) > sh.metaGrace
+	for name, meta := range sh.meta.Files() {
+		for frag, nodeIDs := range meta.Fragments {
+			for _, id := range nodeIDs {
+				_, live := sh.spokeMap[id]
+				if reportedBy[frag][id] || (!live && !graceOver) {
+					continue
+				}
+				sh.logger.Info("Replica no longer present", zap.String("fragment", frag), zap.String("nodeId", id))
+				changes = append(changes, metadata.ReplicaChange{File: name, Fragment: frag, NodeId: id, Removed: true})
+			}
+		}
+	}
+	return
+}
+
+// liveFileMap is the Index.fileMap of the metadata store, restricted to live nodes. Callers hold the lock.
+func (sh *StorageNodeHandler) liveFileMap() (fileMap map[string]map[string][]string) {
+
+	fileMap = make(map[string]map[string][]string)
+	for name, meta := range sh.meta.Files() {
+		for frag, nodeIDs := range meta.Fragments {
+			liveIDs := make([]string, 0)
+			for _, id := range nodeIDs {
+				if _, ok := sh.spokeMap[id]; ok {
+					liveIDs = append(liveIDs, id)
+				}
+			}
+			if len(liveIDs) == 0 {
+				continue
+			}
+			if _, ok := fileMap[name]; !ok {
+				fileMap[name] = make(map[string][]string)
+			}
+			fileMap[name][frag] = liveIDs
+		}
+	}
+	return
 }
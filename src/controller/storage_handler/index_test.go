@@ -3,8 +3,12 @@ package storage_handler
 import (
 	"go.uber.org/zap"
 	"reflect"
+	"sort"
+	"src/controller/metadata"
+	"strconv"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestStorageNodeHandler_ConcurrentIndexing(t *testing.T) {
@@ -171,69 +175,241 @@ func TestStorageNodeHandler_ConcurrentIndexing1(t *testing.T) {
 	}
 }
 
-func Test_isFileFragment(t *testing.T) {
-	type args struct {
-		f string
+func TestIndex_GetFileMap(t *testing.T) {
+	type fields struct {
+		fileMap map[string]map[string][]string
+		logger  *zap.Logger
+		mutex   *sync.RWMutex
 	}
 	tests := []struct {
-		name string
-		args args
-		want bool
+		name   string
+		fields fields
+		want   map[string]map[string][]string
 	}{
 		// TODO: Add test cases.
 		{
-			name: "Test isFileFragment",
+			name: "Test GetFileMap",
+			fields: fields{
+				fileMap: map[string]map[string][]string{"file1": map[string][]string{"node1": []string{"node1", "node2"}}},
+			},
+			want: map[string]map[string][]string{"file1": map[string][]string{"node1": []string{"node1", "node2"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Index{
+				fileMap: tt.fields.fileMap,
+				logger:  tt.fields.logger,
+			}
+			if got := i.GetFileMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFileMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorageNodeHandler_LocateFragments(t *testing.T) {
+	type fields struct {
+		spokeMap map[string]*Node
+		Index    *Index
+	}
+	type args struct {
+		fileName string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   map[string][]string
+	}{
+		{
+			name: "Test indexed and heartbeat fragments",
+			fields: fields{
+				spokeMap: map[string]*Node{
+					"node1": {ID: "node1", files: fileSet("file_0", "file_0.checksum", "filename_0")},
+					"node2": {ID: "node2", files: fileSet("file_1")},
+				},
+				Index: &Index{
+					fileMap: map[string]map[string][]string{"file": {"file_0": {"node1", "node3"}}},
+				},
+			},
 			args: args{
-				f: "file1",
+				fileName: "file",
 			},
-			want: false,
+			want: map[string][]string{"file_0": {"node1", "node3"}, "file_1": {"node2"}},
 		},
 		{
-			name: "Test isFileFragment",
+			name: "Test missing file",
+			fields: fields{
+				spokeMap: map[string]*Node{
+					"node1": {ID: "node1", files: fileSet("file_0")},
+				},
+				Index: &Index{fileMap: map[string]map[string][]string{}},
+			},
 			args: args{
-				f: "file1_1",
+				fileName: "other",
 			},
-			want: true,
+			want: nil,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := isFileFragment(tt.args.f); got != tt.want {
-				t.Errorf("isFileFragment() = %v, want %v", got, tt.want)
+			sh := &StorageNodeHandler{
+				spokeMap: tt.fields.spokeMap,
+				Index:    tt.fields.Index,
+				meta:     storeOf(t, map[string][]string{"file": {"file_0", "file_1"}, "filename": {"filename_0"}}),
+				logger:   zap.NewNop(),
+				mutex:    &sync.RWMutex{},
+			}
+			got := sh.LocateFragments(tt.args.fileName)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("LocateFragments() = %v, want nil", got)
+				}
+				return
+			}
+			gotIDs := make(map[string][]string)
+			for frag, nodes := range got {
+				for _, node := range nodes {
+					gotIDs[frag] = append(gotIDs[frag], node.GetID())
+				}
+				sort.Strings(gotIDs[frag])
+			}
+			if !reflect.DeepEqual(gotIDs, tt.want) {
+				t.Errorf("LocateFragments() = %v, want %v", gotIDs, tt.want)
 			}
 		})
 	}
 }
 
-func TestIndex_GetFileMap(t *testing.T) {
-	type fields struct {
-		fileMap map[string]map[string][]string
-		logger  *zap.Logger
-		mutex   *sync.RWMutex
+func TestStorageNodeHandler_RemoveFragment(t *testing.T) {
+	sh := &StorageNodeHandler{
+		spokeMap: map[string]*Node{
+			"node1": {ID: "node1", files: fileSet("file_0", "file_1")},
+		},
+		Index: &Index{
+			fileMap: map[string]map[string][]string{"file": {"file_0": {"node1"}}},
+		},
+		meta:   storeOf(t, map[string][]string{"file": {"file_0", "file_1"}}),
+		logger: zap.NewNop(),
+		mutex:  &sync.RWMutex{},
+	}
+
+	sh.RemoveFragment("node1", "file_0")
+
+	if got := sh.spokeMap["node1"].GetAllFiles(); !reflect.DeepEqual(got, []string{"file_1"}) {
+		t.Errorf("GetAllFiles() = %v, want %v", got, []string{"file_1"})
 	}
+	if _, ok := sh.Index.GetFileMap()["file"]; ok {
+		t.Errorf("file still indexed after its last replica was removed")
+	}
+}
+
+func TestStorageNodeHandler_shardCountCheck(t *testing.T) {
 	tests := []struct {
-		name   string
-		fields fields
-		want   map[string]map[string][]string
+		name     string
+		spokeMap map[string]*Node
+		want     map[string][]string
 	}{
-		// TODO: Add test cases.
 		{
-			name: "Test GetFileMap",
-			fields: fields{
-				fileMap: map[string]map[string][]string{"file1": map[string][]string{"node1": []string{"node1", "node2"}}},
+			name: "Test no shard lost",
+			spokeMap: map[string]*Node{
+				"node1": {ID: "node1", files: fileSet("file_0")},
+				"node2": {ID: "node2", files: fileSet("file_1")},
+				"node3": {ID: "node3", files: fileSet("file_2")},
 			},
-			want: map[string]map[string][]string{"file1": map[string][]string{"node1": []string{"node1", "node2"}}},
+			want: map[string][]string{},
+		},
+		{
+			name: "Test lost shard rebuilt on a node outside the group",
+			spokeMap: map[string]*Node{
+				"node1": {ID: "node1", files: fileSet("file_0"), freeSpace: 10},
+				"node2": {ID: "node2", files: fileSet("file_1"), freeSpace: 30},
+				"node4": {ID: "node4", freeSpace: 20},
+			},
+			want: map[string][]string{"node4": {"file_2"}},
+		},
+		{
+			name: "Test too many shards lost",
+			spokeMap: map[string]*Node{
+				"node1": {ID: "node1", files: fileSet("file_0")},
+				"node4": {ID: "node4"},
+				"node5": {ID: "node5"},
+			},
+			want: map[string][]string{},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			i := &Index{
-				fileMap: tt.fields.fileMap,
-				logger:  tt.fields.logger,
+			store, err := metadata.Open(t.TempDir(), zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
 			}
-			if got := i.GetFileMap(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetFileMap() = %v, want %v", got, tt.want)
+			defer store.Close()
+
+			store.CreateFile("file", 20, 20, metadata.Redundancy{ReplicationFactor: 1, DataShards: 2, ParityShards: 1}, []string{"file_0", "file_1", "file_2"}, "", "")
+			for i, id := range []string{"node1", "node2", "node3"} {
+				store.AddReplica("file", "file_"+strconv.Itoa(i), id)
+			}
+			store.Commit("file", "")
+
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.spokeMap = tt.spokeMap
+			sh.SetMetadataStore(store, 0)
+			sh.ConcurrentIndexing()
+
+			if !reflect.DeepEqual(sh.Index.shardsNeeded, tt.want) {
+				t.Errorf("shardsNeeded = %v, want %v", sh.Index.shardsNeeded, tt.want)
 			}
 		})
 	}
 }
+
+func TestStorageNodeHandler_reconcileMetadata(t *testing.T) {
+	store := storeOf(t, map[string][]string{"file": {"file_0", "file_1"}})
+	store.AddReplica("file", "file_0", "node1")
+	seq := store.Seq()
+
+	sh := NewStorageNodeHandler(zap.NewNop())
+	sh.spokeMap = map[string]*Node{
+		"node1": {ID: "node1", files: fileSet()},
+		"node2": {ID: "node2", files: fileSet("file_0", "file_1")},
+	}
+	sh.SetMetadataStore(store, 0)
+	sh.ConcurrentIndexing()
+
+	meta, _ := store.GetFile("file")
+	want := map[string][]string{"file_0": {"node2"}, "file_1": {"node2"}}
+	if !reflect.DeepEqual(meta.Fragments, want) {
+		t.Errorf("Fragments = %v, want %v", meta.Fragments, want)
+	}
+	//the replicas gained and lost are committed together, not one record each
+	if got := store.Seq() - seq; got != 1 {
+		t.Errorf("records committed = %d, want 1", got)
+	}
+	if !reflect.DeepEqual(sh.Index.GetFileMap()["file"], want) {
+		t.Errorf("fileMap = %v, want %v", sh.Index.GetFileMap()["file"], want)
+	}
+}
+
+// storeOf returns a metadata store with files planned on their blocks, closed when the test ends.
+func storeOf(t *testing.T, files map[string][]string) *metadata.Store {
+	store, err := metadata.Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	for name, blocks := range files {
+		store.CreateFile(name, 0, 0, metadata.Redundancy{ReplicationFactor: 1}, blocks, "", "")
+	}
+	return store
+}
+
+// fileSet is what a node holding files has after its first full block report.
+func fileSet(files ...string) map[string]time.Time {
+	set := make(map[string]time.Time)
+	for _, f := range files {
+		set[f] = time.Time{}
+	}
+	return set
+}
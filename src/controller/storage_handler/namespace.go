@@ -0,0 +1,174 @@
+package storage_handler
+
+import (
+	"errors"
+	"path"
+	"sort"
+	"src/controller/metadata"
+	"strings"
+)
+
+// ErrNoMetadata is returned by directory operations when the controller runs without a metadata store.
+var ErrNoMetadata = errors.New("the controller keeps no metadata")
+
+// CheckNewFile reports why a PUT could not create a file at name, nil if it can.
+func (sh *StorageNodeHandler) CheckNewFile(name string) error {
+
+	if sh.meta == nil {
+		return nil
+	}
+	return sh.meta.CheckCreate(name)
+}
+
+// Mkdir creates a directory in the namespace, and the directories above it that do not exist yet.
+func (sh *StorageNodeHandler) Mkdir(dir string) error {
+
+	if sh.meta == nil {
+		return ErrNoMetadata
+	}
+	return sh.meta.Mkdir(dir)
+}
+
+// Rmdir removes an empty directory from the namespace.
+func (sh *StorageNodeHandler) Rmdir(dir string) error {
+
+	if sh.meta == nil {
+		return ErrNoMetadata
+	}
+	return sh.meta.Rmdir(dir)
+}
+
+// Rename moves a file, or a directory with everything below it. The fragments keep their block ids, so
+// nothing is sent to the storage nodes.
+func (sh *StorageNodeHandler) Rename(from string, to string) (err error) {
+
+	if sh.meta == nil {
+		return ErrNoMetadata
+	}
+	err = sh.meta.Rename(from, to)
+	if err != nil {
+		return
+	}
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	from, to = metadata.CleanPath(from), metadata.CleanPath(to)
+	moved := func(name string) (string, bool) {
+		if name != from && !strings.HasPrefix(name, from+"/") {
+			return "", false
+		}
+		return to + strings.TrimPrefix(name, from), true
+	}
+
+	for name, fragMap := range sh.Index.fileMap {
+		if dest, ok := moved(name); ok {
+			delete(sh.Index.fileMap, name)
+			//a heartbeat may have indexed replicas under the new name since the store committed it
+			for frag, nodeIDs := range fragMap {
+				for _, id := range nodeIDs {
+					sh.Index.addReplica(dest, frag, id)
+				}
+			}
+		}
+	}
+	for name, factor := range sh.replication {
+		if dest, ok := moved(name); ok {
+			delete(sh.replication, name)
+			sh.replication[dest] = factor
+		}
+	}
+	for name := range sh.files {
+		if dest, ok := moved(name); ok {
+			delete(sh.files, name)
+			sh.files[dest] = dest
+		}
+	}
+	return
+}
+
+// ListEntry is a file or directory of a listing. For a file, UnderReplicated tells whether any of its
+// fragments has fewer replicas on live nodes than its replication factor.
+type ListEntry struct {
+	metadata.Entry
+	UnderReplicated bool
+}
+
+// ListOptions narrow a listing down to a page of the entries whose name matches a pattern.
+type ListOptions struct {
+	//a glob the name of an entry matches, or a prefix of it when it holds none of *, ? and [
+	Pattern string
+	//the listing carries on after the entry of this name, the token returned with the previous page
+	PageToken string
+	//the most entries a page holds, 0 for all of them
+	Limit int
+}
+
+// matches reports whether an entry named name is listed.
+func (opts ListOptions) matches(name string) (bool, error) {
+
+	if !strings.ContainsAny(opts.Pattern, "*?[") {
+		return strings.HasPrefix(name, opts.Pattern), nil
+	}
+	return path.Match(opts.Pattern, name)
+}
+
+// ListDir returns a page of what is directly in a directory, sorted by name, and the token of the next page,
+// empty on the last one. Without a metadata store the namespace is flat, and the root holds every file the
+// live nodes reported.
+func (sh *StorageNodeHandler) ListDir(dir string, opts ListOptions) (page []ListEntry, nextPageToken string, err error) {
+
+	entries, err := sh.listDir(dir)
+	if err != nil {
+		return
+	}
+
+	page = make([]ListEntry, 0)
+	for _, entry := range entries {
+		matched, errM := opts.matches(entry.Name)
+		if errM != nil {
+			return nil, "", errM
+		}
+		if !matched || (opts.PageToken != "" && entry.Name <= opts.PageToken) {
+			continue
+		}
+		if opts.Limit > 0 && len(page) == opts.Limit {
+			nextPageToken = page[len(page)-1].Name
+			break
+		}
+		page = append(page, ListEntry{Entry: entry})
+	}
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	prefix := metadata.CleanPath(dir)
+	if prefix != "" {
+		prefix += "/"
+	}
+	for i := range page {
+		if !page[i].Dir {
+			page[i].UnderReplicated = sh.underReplicated(prefix + page[i].Name)
+		}
+	}
+	return
+}
+
+func (sh *StorageNodeHandler) listDir(dir string) (entries []metadata.Entry, err error) {
+
+	if sh.meta != nil {
+		return sh.meta.List(dir)
+	}
+	if metadata.CleanPath(dir) != "" {
+		return nil, metadata.ErrNotFound
+	}
+
+	entries = make([]metadata.Entry, 0)
+	for name := range sh.ExtractFiles(sh.FindAllFiles(sh.logger), sh.logger) {
+		entries = append(entries, metadata.Entry{Name: name})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return
+}
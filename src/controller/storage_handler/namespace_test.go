@@ -0,0 +1,95 @@
+package storage_handler
+
+import (
+	"go.uber.org/zap"
+	"path"
+	"reflect"
+	"src/controller/metadata"
+	"testing"
+)
+
+func TestStorageNodeHandler_ListDir(t *testing.T) {
+
+	store, err := metadata.Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	sh := NewStorageNodeHandler(zap.NewNop())
+	sh.SetMetadataStore(store, 0)
+	sh.spokeMap["node1"] = &Node{ID: "node1"}
+	sh.spokeMap["node2"] = &Node{ID: "node2"}
+
+	replicated := metadata.Redundancy{ReplicationFactor: 2}
+	sh.RecordFile("dir/a.txt", 15, 10, replicated, []string{"a0", "a1"}, "upload", "alice")
+	sh.RecordFile("dir/b.txt", 5, 10, replicated, []string{"b0"}, "upload", "alice")
+	sh.RecordFile("dir/c.log", 5, 10, replicated, []string{"c0"}, "upload", "alice")
+	for _, name := range []string{"dir/a.txt", "dir/b.txt", "dir/c.log"} {
+		sh.CommitFile(name, "")
+	}
+	if err := sh.Mkdir("dir/sub"); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+	sh.Index.fileMap["dir/a.txt"] = map[string][]string{"a0": {"node1", "node2"}, "a1": {"node1", "gone"}}
+	sh.Index.fileMap["dir/b.txt"] = map[string][]string{"b0": {"node1", "node2"}}
+	sh.Index.fileMap["dir/c.log"] = map[string][]string{"c0": {"node2", "node1"}}
+
+	a := ListEntry{Entry: metadata.Entry{Name: "a.txt", Size: 15, Fragments: 2}, UnderReplicated: true}
+	b := ListEntry{Entry: metadata.Entry{Name: "b.txt", Size: 5, Fragments: 1}}
+	c := ListEntry{Entry: metadata.Entry{Name: "c.log", Size: 5, Fragments: 1}}
+	sub := ListEntry{Entry: metadata.Entry{Name: "sub", Dir: true}}
+
+	tests := []struct {
+		name     string
+		opts     ListOptions
+		want     []ListEntry
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name: "Test whole directory",
+			want: []ListEntry{a, b, c, sub},
+		},
+		{
+			name: "Test prefix",
+			opts: ListOptions{Pattern: "b"},
+			want: []ListEntry{b},
+		},
+		{
+			name: "Test glob",
+			opts: ListOptions{Pattern: "*.txt"},
+			want: []ListEntry{a, b},
+		},
+		{
+			name:     "Test first page",
+			opts:     ListOptions{Limit: 2},
+			want:     []ListEntry{a, b},
+			wantNext: "b.txt",
+		},
+		{
+			name: "Test last page",
+			opts: ListOptions{PageToken: "b.txt", Limit: 2},
+			want: []ListEntry{c, sub},
+		},
+		{
+			name:    "Test bad pattern",
+			opts:    ListOptions{Pattern: "[a"},
+			wantErr: path.ErrBadPattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next, err := sh.ListDir("/dir/", tt.opts)
+			if err != tt.wantErr {
+				t.Fatalf("ListDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) || next != tt.wantNext {
+				t.Errorf("ListDir() = %v, %q, want %v, %q", got, next, tt.want, tt.wantNext)
+			}
+		})
+	}
+}
@@ -4,24 +4,35 @@ import (
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
+	"sort"
+	"src/controller/metadata"
+	"src/controller/placement"
+	"src/erasure"
 	"src/proto/controller_storage"
-	"strings"
 	"sync"
 	"time"
 )
 
+// DEFAULT_REPLICATION_FACTOR is used for files that were stored without one.
+const DEFAULT_REPLICATION_FACTOR = 3
+
 type Node struct {
 	ID       string
 	openPort string
 	host     string
+	rack     string
 
 	LastHeartbeat    time.Time
 	MissedHeartbeats int
 
 	freeSpace            int64
 	numRequestsProcessed int32
-	newFiles             []string
-	allFiles             []string
+
+	//file -> when a block report added it, zero for files the node held when it first reported
+	files map[string]time.Time
+	//sequence number of the last block report applied, reported is false until a full one was
+	reportSeq uint64
+	reported  bool
 }
 
 // create Getters and Setters for the Node struct
@@ -38,6 +49,28 @@ func (n *Node) GetAddress() string {
 	return n.host
 }
 
+func (n *Node) GetRack() string {
+	return n.rack
+}
+
+// FailureDomain is what the node may fail together with: its rack, or its host if it did not declare one.
+func (n *Node) FailureDomain() string {
+	if n.rack != "" {
+		return n.rack
+	}
+	return n.host
+}
+
+// Placement describes the node to a placement policy.
+func (n *Node) Placement() placement.Node {
+	return placement.Node{
+		ID:        n.ID,
+		Domain:    n.FailureDomain(),
+		FreeSpace: n.freeSpace,
+		Requests:  n.numRequestsProcessed,
+	}
+}
+
 func (n *Node) GetLastHeartbeat() time.Time {
 	return n.LastHeartbeat
 }
@@ -54,18 +87,24 @@ func (n *Node) GetNumRequestsProcessed() int32 {
 	return n.numRequestsProcessed
 }
 
-func (n *Node) GetNewFiles() []string {
-	return n.newFiles
-}
+func (n *Node) GetAllFiles() (files []string) {
 
-func (n *Node) GetAllFiles() []string {
-	return n.allFiles
+	files = make([]string, 0, len(n.files))
+	for f := range n.files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return
 }
 
 func (n *Node) SetFreeSpace(f int64) {
 	n.freeSpace = f
 }
 
+func (n *Node) SetRack(rack string) {
+	n.rack = rack
+}
+
 type ClientSafeNode struct {
 	nodeId   string
 	openPort string
@@ -77,6 +116,27 @@ type StorageNodeHandler struct {
 	files    map[string]string
 	Index    *Index
 
+	//node id -> the node's open session, commands are pushed on it
+	sessions map[string]*controller_storage.Session
+
+	//persistent file metadata, nil when the controller runs without a metadata dir. Block ids are opaque, so
+	//only the store ties the blocks the nodes report to their files, and no file is planned without it
+	meta      *metadata.Store
+	metaGrace time.Duration
+	started   time.Time
+
+	//orders the nodes new replicas and rebuilt shards may go on
+	policy placement.Policy
+
+	//node id -> fragment -> space a pending upload reserved for it
+	reservations       map[string]map[string]reservation
+	reservationTimeout time.Duration
+
+	//file name -> replication factor, and the limits a PUT may ask for
+	replication        map[string]int
+	defaultReplication int
+	maxReplication     int
+
 	totalStorage int64
 	logger       *zap.Logger
 	mutex        *sync.RWMutex
@@ -86,8 +146,18 @@ func NewStorageNodeHandler(logger *zap.Logger) (newSH *StorageNodeHandler) {
 	newSH = &StorageNodeHandler{
 		spokeMap: make(map[string]*Node),
 		files:    make(map[string]string),
+		sessions: make(map[string]*controller_storage.Session),
 		logger:   logger,
 		mutex:    &sync.RWMutex{},
+		started:  time.Now(),
+		policy:   placement.NewRandom(time.Now().UnixNano()),
+
+		reservations:       make(map[string]map[string]reservation),
+		reservationTimeout: DEFAULT_RESERVATION_TIMEOUT,
+
+		replication:        make(map[string]int),
+		defaultReplication: DEFAULT_REPLICATION_FACTOR,
+		maxReplication:     DEFAULT_REPLICATION_FACTOR,
 
 		Index: NewIndex(logger),
 	}
@@ -95,22 +165,183 @@ func NewStorageNodeHandler(logger *zap.Logger) (newSH *StorageNodeHandler) {
 	return
 }
 
-func (sh *StorageNodeHandler) GetFileName(fragID string) string {
+// SetPlacementPolicy sets the policy used to place new fragments and the replicas and shards that replace lost ones.
+func (sh *StorageNodeHandler) SetPlacementPolicy(policy placement.Policy) {
+	sh.policy = policy
+}
+
+func (sh *StorageNodeHandler) PlacementPolicy() placement.Policy {
+	return sh.policy
+}
+
+// SetReplicationLimits sets the replication factor used when a PUT does not ask for one, and the largest one it may ask for.
+func (sh *StorageNodeHandler) SetReplicationLimits(defaultFactor int, maxFactor int) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	sh.defaultReplication = defaultFactor
+	sh.maxReplication = maxFactor
+}
+
+// ResolveReplicationFactor turns the factor requested by a PUT into the one used, 0 meaning the default.
+func (sh *StorageNodeHandler) ResolveReplicationFactor(requested int) (factor int, err error) {
 
-	lastUnderscore := strings.LastIndex(fragID, "_")
-	if lastUnderscore == -1 {
-		// If there is no underscore, return the whole string
-		return fragID
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	if requested == 0 {
+		return sh.defaultReplication, nil
+	}
+	if requested < 0 || requested > sh.maxReplication {
+		return 0, fmt.Errorf("replication factor must be between 1 and %d", sh.maxReplication)
 	}
-	return fragID[:lastUnderscore]
+	return requested, nil
+}
+
+// ResolveErasureCoding checks the data and parity shard counts requested by a PUT.
+func (sh *StorageNodeHandler) ResolveErasureCoding(dataShards int, parityShards int) (redundancy metadata.Redundancy, err error) {
+
+	err = erasure.Layout{DataShards: dataShards, ParityShards: parityShards}.Validate()
+	if err != nil {
+		return
+	}
+
+	//every shard is stored once, a lost one is rebuilt from the rest of its group
+	return metadata.Redundancy{ReplicationFactor: 1, DataShards: dataShards, ParityShards: parityShards}, nil
+}
+
+// ErasureLayout returns how an erasure coded file is cut into shards. ok is false for replicated files.
+func (sh *StorageNodeHandler) ErasureLayout(fileName string) (layout erasure.Layout, ok bool) {
+
+	if sh.meta == nil {
+		return
+	}
+
+	meta, found := sh.meta.GetFile(fileName)
+	if !found || !meta.ErasureCoded() {
+		return
+	}
+
+	return erasureLayout(meta), true
+}
+
+func erasureLayout(meta *metadata.FileMeta) erasure.Layout {
+	return erasure.Layout{
+		DataShards:   meta.DataShards,
+		ParityShards: meta.ParityShards,
+		ChunkSize:    meta.ChunkSize,
+		FileSize:     meta.Size,
+		Blocks:       meta.Blocks,
+	}
+}
+
+// ReplicationFactor returns the number of replicas every fragment of the file should have.
+func (sh *StorageNodeHandler) ReplicationFactor(fileName string) int {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	return sh.replicationFactor(fileName)
+}
+
+// replicationFactor is ReplicationFactor for callers that hold the lock.
+func (sh *StorageNodeHandler) replicationFactor(fileName string) int {
+
+	if factor, ok := sh.replication[fileName]; ok && factor > 0 {
+		return factor
+	}
+	return sh.defaultReplication
+}
+
+// SetMetadataStore attaches the persistent metadata store and seeds the Index from it.
+// Replicas on nodes that have not re-registered within grace of the controller starting are dropped.
+func (sh *StorageNodeHandler) SetMetadataStore(store *metadata.Store, grace time.Duration) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	sh.meta = store
+	sh.metaGrace = grace
+
+	for name, meta := range store.Files() {
+		sh.Index.fileMap[name] = meta.Fragments
+		if meta.ReplicationFactor > 0 {
+			sh.replication[name] = meta.ReplicationFactor
+		}
+	}
+}
+
+// RecordFile persists a newly planned file of owner, whose fragments are stored as blocks, in order. It fails
+// without a metadata store, which the files of the blocks could not be found in after a restart, and with
+// metadata.ErrExists if another plan for the file was recorded first.
+func (sh *StorageNodeHandler) RecordFile(fileName string, fileSize int64, chunkSize int64, redundancy metadata.Redundancy, blocks []string, uploadId string, owner string) (err error) {
+
+	if sh.meta == nil {
+		return ErrNoMetadata
+	}
+
+	err = sh.meta.CreateFile(fileName, fileSize, chunkSize, redundancy, blocks, uploadId, owner)
+	if err != nil {
+		return
+	}
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	sh.replication[fileName] = redundancy.ReplicationFactor
+	return
+}
+
+// Blocks returns the block ids the fragments of a file are stored as, fragment i being block i.
+func (sh *StorageNodeHandler) Blocks(fileName string) []string {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	if sh.meta != nil {
+		if meta, found := sh.meta.GetFile(fileName); found {
+			return meta.Blocks
+		}
+	}
+	return nil
+}
+
+// ForgetFile drops a file from the Index and the metadata store once all of its replicas are gone.
+func (sh *StorageNodeHandler) ForgetFile(fileName string) (err error) {
+
+	sh.mutex.Lock()
+	delete(sh.Index.fileMap, fileName)
+	delete(sh.files, fileName)
+	delete(sh.replication, fileName)
+	sh.releaseReservations(fileName)
+	sh.mutex.Unlock()
+
+	if sh.meta != nil {
+		err = sh.meta.DeleteFile(fileName)
+	}
+	return
+}
+
+// fileOfBlock returns the path of the file a block belongs to. found is false for a block no file was
+// planned with, such as what a node holds that is not a block at all. Callers hold the lock.
+func (sh *StorageNodeHandler) fileOfBlock(blockId string) (name string, found bool) {
+
+	if sh.meta == nil {
+		return "", false
+	}
+	return sh.meta.FileOfBlock(blockId)
 }
 
 func (sh *StorageNodeHandler) ExtractFiles(fileFragments []string, logger *zap.Logger) (files map[string]bool) {
 
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
 	files = make(map[string]bool)
 	for _, f := range fileFragments {
-		fileName := sh.GetFileName(f)
-		files[fileName] = true
+		if fileName, ok := sh.fileOfBlock(f); ok {
+			files[fileName] = true
+		}
 	}
 
 	return
@@ -123,8 +354,8 @@ func (sh *StorageNodeHandler) FindAllFiles(logger *zap.Logger) (files []string)
 	logger.Info("Finding all files")
 	files = make([]string, 0)
 	for _, node := range sh.spokeMap {
-		for _, f := range node.allFiles {
-			if isFileFragment(f) {
+		for f := range node.files {
+			if name, ok := sh.fileOfBlock(f); ok && sh.committed(name) {
 				files = append(files, f)
 			}
 		}
@@ -143,13 +374,17 @@ func (sh *StorageNodeHandler) FindFiles(file string, logger *zap.Logger) (fileMa
 	defer sh.mutex.RUnlock()
 
 	logger.Info("Finding files", zap.String("file", file))
+	if !sh.committed(file) {
+		logger.Info("File is not committed", zap.String("file", file))
+		return nil
+	}
 
 	// map: file fragment -> list of nodes that have the file fragment
 	//spokemap: nodeID -> node struct
 	fileMap = make(map[string][]*Node)
 	for _, node := range sh.spokeMap {
-		for _, f := range node.allFiles {
-			if fileFragmentFound(file, f) {
+		for f := range node.files {
+			if name, ok := sh.fileOfBlock(f); ok && name == file {
 				if _, ok := fileMap[f]; !ok {
 					fileMap[f] = make([]*Node, 0)
 				}
@@ -166,38 +401,23 @@ func (sh *StorageNodeHandler) FindFiles(file string, logger *zap.Logger) (fileMa
 	return
 }
 
-func fileFragmentFound(file string, f string) bool {
-
-	if len(f) < len(file) {
-		return false
-	} else {
-
-		//check if the f contains the term checksum
-		if strings.Contains(f, "checksum") {
-			return false
-		}
-		return file == f[:len(file)]
-	}
-
-}
-
+// UpdateNodeStats records a heartbeat and applies its block report. It returns ErrReportGap if the report
+// does not follow the last one applied for the node.
 func (sh *StorageNodeHandler) UpdateNodeStats(Req *controller_storage.Request) (err error) {
 	sh.mutex.Lock()
 	defer sh.mutex.Unlock()
 
 	nodeId := Req.GetNodeId()
-	node := sh.spokeMap[nodeId]
+	node, ok := sh.spokeMap[nodeId]
+	if !ok {
+		return errors.New("node doesn't exist")
+	}
 
 	node.freeSpace = Req.GetNodeFreeSpace()
 	sh.logger.Info("Updating node stats", zap.String("nodeId", nodeId), zap.Int64("freeSpace", node.freeSpace))
 	node.numRequestsProcessed = Req.GetNodeNumRequestsProcessed()
 
-	node.newFiles = Req.GetNewFiles()
-
-	//TODO: sh.updateNodeFiles(files, node)
-	node.allFiles = Req.GetAllFiles()
-
-	return
+	return sh.applyBlockReport(node, Req.GetBlockReport())
 }
 
 func (sh *StorageNodeHandler) Add(Req *controller_storage.Request) (err error) {
@@ -209,8 +429,10 @@ func (sh *StorageNodeHandler) Add(Req *controller_storage.Request) (err error) {
 		ID:               Req.GetNodeId(),
 		openPort:         Req.GetNodePort(),
 		host:             Req.GetNodeHost(),
+		rack:             Req.GetNodeRack(),
 		LastHeartbeat:    time.Now(),
 		MissedHeartbeats: 0,
+		files:            make(map[string]time.Time),
 	}
 
 	sh.spokeMap[Req.GetNodeId()] = node
@@ -218,6 +440,35 @@ func (sh *StorageNodeHandler) Add(Req *controller_storage.Request) (err error) {
 	return
 }
 
+// SetSession records the session a node introduced itself on, replacing the one of an earlier connection.
+func (sh *StorageNodeHandler) SetSession(nodeId string, session *controller_storage.Session) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	sh.sessions[nodeId] = session
+}
+
+// Session returns the node's open session, or nil if it has none.
+func (sh *StorageNodeHandler) Session(nodeId string) *controller_storage.Session {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	return sh.sessions[nodeId]
+}
+
+// RemoveSession forgets a closed session, unless the node has already reconnected on a new one.
+func (sh *StorageNodeHandler) RemoveSession(nodeId string, session *controller_storage.Session) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	if sh.sessions[nodeId] == session {
+		delete(sh.sessions, nodeId)
+	}
+}
+
 func (sh *StorageNodeHandler) FileExists(fileName string) (found bool, err error) {
 
 	sh.mutex.RLock()
@@ -226,6 +477,10 @@ func (sh *StorageNodeHandler) FileExists(fileName string) (found bool, err error
 	if _, ok := sh.files[fileName]; ok {
 		return true, nil
 	}
+
+	if sh.meta != nil {
+		_, found = sh.meta.GetFile(fileName)
+	}
 	return
 }
 
@@ -294,6 +549,7 @@ func (sh *StorageNodeHandler) Delete(nodeId string) (err error) {
 	}
 
 	delete(sh.spokeMap, nodeId)
+	delete(sh.reservations, nodeId)
 	fmt.Println("Map size shrunk to: ", len(sh.spokeMap))
 	return
 
@@ -347,10 +603,8 @@ func (sh *StorageNodeHandler) HasFile(name string, id string) (nodes []Node) {
 	for _, node := range sh.spokeMap {
 		if node.ID != id {
 
-			for _, file := range node.allFiles {
-				if file == name {
-					nodes = append(nodes, *node)
-				}
+			if _, ok := node.files[name]; ok {
+				nodes = append(nodes, *node)
 			}
 		}
 	}
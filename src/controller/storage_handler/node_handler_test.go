@@ -2,9 +2,11 @@ package storage_handler
 
 import (
 	"go.uber.org/zap"
+	"src/controller/metadata"
 	"src/proto/controller_storage"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestStorageNodeHandler_FillNodes(t *testing.T) {
@@ -65,47 +67,162 @@ func TestStorageNodeHandler_FillNodes(t *testing.T) {
 	}
 }
 
-func Test_fileFragmentFound(t *testing.T) {
-	type args struct {
-		file string
-		f    string
+func TestStorageNodeHandler_fileOfBlock(t *testing.T) {
+	tests := []struct {
+		name      string
+		block     string
+		want      string
+		wantFound bool
+	}{
+		{name: "Test block of a file", block: "0f3a", want: "logs/log", wantFound: true},
+		{name: "Test block of a file whose name ends with a number", block: "9c2e", want: "logs/log_2023", wantFound: true},
+		{name: "Test block of a renamed file", block: "b71d", want: "archive/data_7", wantFound: true},
+		{name: "Test block unknown to the store", block: "data_7_2"},
+	}
+
+	store, err := metadata.Open(t.TempDir(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	store.CreateFile("logs/log", 10, 5, metadata.Redundancy{ReplicationFactor: 1}, []string{"1c07", "0f3a"}, "", "")
+	store.CreateFile("logs/log_2023", 5, 5, metadata.Redundancy{ReplicationFactor: 1}, []string{"9c2e"}, "", "")
+	store.CreateFile("data_7", 20, 5, metadata.Redundancy{ReplicationFactor: 1}, []string{"4e90", "d2a8", "77f1", "b71d"}, "", "")
+	store.Commit("data_7", "")
+	store.Mkdir("archive")
+	store.Rename("data_7", "archive/data_7")
+
+	sh := NewStorageNodeHandler(zap.NewNop())
+	sh.SetMetadataStore(store, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := sh.fileOfBlock(tt.block)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("fileOfBlock() = %q, %v, want %q, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestStorageNodeHandler_RecordFile(t *testing.T) {
+
+	//the blocks of a file planned without a store could not be tied back to it after a restart
+	sh := NewStorageNodeHandler(zap.NewNop())
+	err := sh.RecordFile("file", 10, 10, metadata.Redundancy{ReplicationFactor: 1}, []string{"1c07"}, "upload", "")
+	if err != ErrNoMetadata {
+		t.Errorf("RecordFile() without a store error = %v, want %v", err, ErrNoMetadata)
 	}
+	if _, found := sh.fileOfBlock("1c07"); found {
+		t.Errorf("block of a file that was not recorded has a file")
+	}
+	if got := sh.Blocks("file"); got != nil {
+		t.Errorf("Blocks() = %v, want nil", got)
+	}
+}
+
+func TestStorageNodeHandler_ResolveReplicationFactor(t *testing.T) {
 	tests := []struct {
-		name string
-		args args
-		want bool
+		name      string
+		requested int
+		want      int
+		wantErr   bool
 	}{
-		// TODO: Add test cases.
-		{
-			name: "test 1",
-			args: args{
-				file: "file",
-				f:    "file_1",
-			},
-			want: true,
-		},
-		{
-			name: "test 2",
-			args: args{
-				file: "file",
-				f:    "file.txt_1.checksum",
-			},
-			want: false,
-		},
-		{
-			name: "test 3",
-			args: args{
-				file: "file_2",
-				f:    "file_2_2",
-			},
-			want: true,
-		},
+		{name: "Test default", requested: 0, want: 3},
+		{name: "Test single replica", requested: 1, want: 1},
+		{name: "Test maximum", requested: 5, want: 5},
+		{name: "Test above maximum", requested: 6, wantErr: true},
+		{name: "Test negative", requested: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.SetReplicationLimits(3, 5)
+
+			got, err := sh.ResolveReplicationFactor(tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveReplicationFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveReplicationFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// stalled is a group of controllers whose standbys apply a record only once release is closed.
+type stalled struct {
+	waiting chan struct{}
+	release chan struct{}
+}
+
+func (s *stalled) Term() (uint64, error) { return 1, nil }
+
+func (s *stalled) Replicate(rec *metadata.Record) {}
+
+func (s *stalled) Replicated(seq uint64) error {
+	select {
+	case s.waiting <- struct{}{}:
+	default:
+	}
+	<-s.release
+	return nil
+}
+
+func TestStorageNodeHandler_commitOutsideLock(t *testing.T) {
+	tests := []struct {
+		name      string
+		committed bool
+		op        func(sh *StorageNodeHandler)
+	}{
+		{name: "Test CommitFile", op: func(sh *StorageNodeHandler) { sh.CommitFile("file", "") }},
+		{name: "Test StartDelete", op: func(sh *StorageNodeHandler) { sh.StartDelete("file") }},
+		{name: "Test ResumeUpload", op: func(sh *StorageNodeHandler) { sh.ResumeUpload("file", "upload") }},
+		{name: "Test ForgetFile", op: func(sh *StorageNodeHandler) { sh.ForgetFile("file") }},
+		{name: "Test RemoveFragment", op: func(sh *StorageNodeHandler) { sh.RemoveFragment("node1", "file_0") }},
+		{name: "Test Rename", committed: true, op: func(sh *StorageNodeHandler) { sh.Rename("file", "moved") }},
+		{name: "Test ConcurrentIndexing", op: func(sh *StorageNodeHandler) { sh.ConcurrentIndexing() }},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := fileFragmentFound(tt.args.file, tt.args.f); got != tt.want {
-				t.Errorf("fileFragmentFound() = %v, want %v", got, tt.want)
+			store := storeOf(t, nil)
+			store.CreateFile("file", 10, 10, metadata.Redundancy{ReplicationFactor: 1}, []string{"file_0"}, "upload", "")
+			store.AddReplica("file", "file_0", "node1")
+			if tt.committed {
+				store.Commit("file", "")
+			}
+			standbys := &stalled{waiting: make(chan struct{}, 1), release: make(chan struct{})}
+			store.SetReplicator(standbys)
+
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.SetMetadataStore(store, 0)
+			//node1 no longer reports its replica
+			sh.spokeMap["node1"] = &Node{ID: "node1", files: fileSet()}
+
+			done := make(chan struct{})
+			go func() {
+				tt.op(sh)
+				close(done)
+			}()
+			select {
+			case <-standbys.waiting:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no change committed")
 			}
+
+			locked := make(chan struct{})
+			go func() {
+				sh.SetReservationTimeout(time.Minute)
+				close(locked)
+			}()
+			select {
+			case <-locked:
+			case <-time.After(time.Second):
+				t.Errorf("the handler stays locked while the standbys apply the change")
+			}
+
+			close(standbys.release)
+			<-done
+			<-locked
 		})
 	}
 }
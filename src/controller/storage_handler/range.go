@@ -0,0 +1,82 @@
+package storage_handler
+
+import (
+	"errors"
+)
+
+// ErrInvalidRange is returned for a range that does not start inside the file, or for a file whose layout
+// the metadata store does not know.
+var ErrInvalidRange = errors.New("invalid range")
+
+// Range is a run of bytes of a file or fragment.
+type Range struct {
+	Offset int64
+	Length int64
+}
+
+// FileRange is the part of a file a range read covers, and the part of each replicated fragment it needs.
+// Erasure coded files have no fragment ranges: their shards are read whole so missing ones can be rebuilt.
+type FileRange struct {
+	Range
+	Fragments map[string]Range
+}
+
+// SelectRange narrows the fragments of a file down to those holding bytes [offset, offset+length) of it. A
+// length of 0 reads up to the end of the file. Erasure coded files keep every shard of the groups the range
+// overlaps.
+func (sh *StorageNodeHandler) SelectRange(fileName string, fileMap map[string][]*Node, offset int64, length int64) (selected map[string][]*Node, fileRange *FileRange, err error) {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	if sh.meta == nil {
+		return nil, nil, ErrInvalidRange
+	}
+	meta, found := sh.meta.GetFile(fileName)
+	if !found || meta.ChunkSize <= 0 {
+		return nil, nil, ErrInvalidRange
+	}
+	if offset < 0 || length < 0 || offset >= meta.Size {
+		return nil, nil, ErrInvalidRange
+	}
+
+	end := meta.Size
+	//offset+length would overflow for a length that means the rest of the file
+	if length > 0 && length < end-offset {
+		end = offset + length
+	}
+	fileRange = &FileRange{Range: Range{Offset: offset, Length: end - offset}, Fragments: make(map[string]Range)}
+	selected = make(map[string][]*Node)
+
+	first, last := int(offset/meta.ChunkSize), int((end-1)/meta.ChunkSize)
+	for i := first; i <= last; i++ {
+
+		if meta.ErasureCoded() {
+			layout := erasureLayout(meta)
+			for shard := 0; shard < layout.Shards(); shard++ {
+				name := layout.Block(i, shard)
+				if nodes, ok := fileMap[name]; ok {
+					selected[name] = nodes
+				}
+			}
+			continue
+		}
+
+		if i >= len(meta.Blocks) {
+			return nil, nil, ErrInvalidRange
+		}
+		name := meta.Blocks[i]
+		start, stop := int64(i)*meta.ChunkSize, int64(i+1)*meta.ChunkSize
+		if start < offset {
+			start = offset
+		}
+		if stop > end {
+			stop = end
+		}
+		fileRange.Fragments[name] = Range{Offset: start - int64(i)*meta.ChunkSize, Length: stop - start}
+		if nodes, ok := fileMap[name]; ok {
+			selected[name] = nodes
+		}
+	}
+	return
+}
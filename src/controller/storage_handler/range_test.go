@@ -0,0 +1,124 @@
+package storage_handler
+
+import (
+	"go.uber.org/zap"
+	"math"
+	"reflect"
+	"sort"
+	"src/controller/metadata"
+	"testing"
+)
+
+func TestStorageNodeHandler_SelectRange(t *testing.T) {
+	replicated := metadata.Redundancy{ReplicationFactor: 1}
+	coded := metadata.Redundancy{DataShards: 2, ParityShards: 1}
+	tests := []struct {
+		name          string
+		redundancy    metadata.Redundancy
+		fragments     []string
+		offset        int64
+		length        int64
+		wantErr       error
+		wantSelected  []string
+		wantRange     Range
+		wantFragments map[string]Range
+	}{
+		{
+			name:         "Test range inside one fragment",
+			redundancy:   replicated,
+			fragments:    []string{"file_0", "file_1", "file_2"},
+			offset:       12,
+			length:       5,
+			wantSelected: []string{"file_1"},
+			wantRange:    Range{Offset: 12, Length: 5},
+			wantFragments: map[string]Range{
+				"file_1": {Offset: 2, Length: 5},
+			},
+		},
+		{
+			name:         "Test range across fragments up to the end",
+			redundancy:   replicated,
+			fragments:    []string{"file_0", "file_1", "file_2"},
+			offset:       8,
+			wantSelected: []string{"file_0", "file_1", "file_2"},
+			wantRange:    Range{Offset: 8, Length: 17},
+			wantFragments: map[string]Range{
+				"file_0": {Offset: 8, Length: 2},
+				"file_1": {Offset: 0, Length: 10},
+				"file_2": {Offset: 0, Length: 5},
+			},
+		},
+		{
+			name:         "Test length past the largest offset",
+			redundancy:   replicated,
+			fragments:    []string{"file_0", "file_1", "file_2"},
+			offset:       18,
+			length:       math.MaxInt64,
+			wantSelected: []string{"file_1", "file_2"},
+			wantRange:    Range{Offset: 18, Length: 7},
+			wantFragments: map[string]Range{
+				"file_1": {Offset: 8, Length: 2},
+				"file_2": {Offset: 0, Length: 5},
+			},
+		},
+		{
+			name:          "Test range of an erasure coded file",
+			redundancy:    coded,
+			fragments:     []string{"file_0", "file_1", "file_2", "file_3", "file_4", "file_5", "file_6", "file_7", "file_8"},
+			offset:        11,
+			length:        2,
+			wantSelected:  []string{"file_3", "file_4", "file_5"},
+			wantRange:     Range{Offset: 11, Length: 2},
+			wantFragments: map[string]Range{},
+		},
+		{
+			name:       "Test range past the end",
+			redundancy: replicated,
+			fragments:  []string{"file_0", "file_1", "file_2"},
+			offset:     25,
+			wantErr:    ErrInvalidRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := metadata.Open(t.TempDir(), zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer store.Close()
+
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.SetMetadataStore(store, 0)
+			sh.RecordFile("file", 25, 10, tt.redundancy, tt.fragments, "upload", "")
+
+			node := &Node{ID: "node1"}
+			fileMap := make(map[string][]*Node)
+			for _, frag := range tt.fragments {
+				fileMap[frag] = []*Node{node}
+			}
+
+			selected, fileRange, err := sh.SelectRange("file", fileMap, tt.offset, tt.length)
+			if err != tt.wantErr {
+				t.Fatalf("SelectRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := make([]string, 0, len(selected))
+			for frag := range selected {
+				got = append(got, frag)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.wantSelected) {
+				t.Errorf("SelectRange() selected = %v, want %v", got, tt.wantSelected)
+			}
+			if fileRange.Range != tt.wantRange {
+				t.Errorf("SelectRange() range = %v, want %v", fileRange.Range, tt.wantRange)
+			}
+			if !reflect.DeepEqual(fileRange.Fragments, tt.wantFragments) {
+				t.Errorf("SelectRange() fragments = %v, want %v", fileRange.Fragments, tt.wantFragments)
+			}
+		})
+	}
+}
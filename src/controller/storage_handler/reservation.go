@@ -0,0 +1,158 @@
+package storage_handler
+
+import (
+	"errors"
+	"time"
+)
+
+// DEFAULT_RESERVATION_TIMEOUT is how long the space planned for an upload stays reserved when it is not set.
+const DEFAULT_RESERVATION_TIMEOUT = 10 * time.Minute
+
+// ErrInsufficientSpace is returned when a plan needs more space on the nodes than is left once the
+// reservations of other pending uploads are taken off.
+var ErrInsufficientSpace = errors.New("not enough free space on the storage nodes")
+
+// Reservation is space a pending upload is going to take on a node for one fragment.
+type Reservation struct {
+	NodeId   string
+	Fragment string
+	Bytes    int64
+}
+
+type reservation struct {
+	file    string
+	bytes   int64
+	expires time.Time
+}
+
+// SetReservationTimeout sets how long planned space stays reserved if its fragments are never reported.
+func (sh *StorageNodeHandler) SetReservationTimeout(timeout time.Duration) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	sh.reservationTimeout = timeout
+}
+
+// Reserve holds the space a plan for fileName needs. Either all of it is reserved, or none of it and
+// ErrInsufficientSpace is returned if another upload took the space since the plan was made. A reservation
+// is released when its node reports the fragment, when the file is forgotten, or when it expires.
+func (sh *StorageNodeHandler) Reserve(fileName string, reservations []Reservation) (err error) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	now := time.Now()
+	sh.expireReservations(now)
+
+	needed := make(map[string]int64)
+	for _, r := range reservations {
+		needed[r.NodeId] += r.Bytes
+	}
+	for nodeId, bytes := range needed {
+		node, ok := sh.spokeMap[nodeId]
+		if !ok || node.freeSpace-sh.reserved(nodeId, now) < bytes {
+			return ErrInsufficientSpace
+		}
+	}
+
+	for _, r := range reservations {
+		if sh.reservations[r.NodeId] == nil {
+			sh.reservations[r.NodeId] = make(map[string]reservation)
+		}
+		sh.reservations[r.NodeId][r.Fragment] = reservation{
+			file:    fileName,
+			bytes:   r.Bytes,
+			expires: now.Add(sh.reservationTimeout),
+		}
+	}
+	return
+}
+
+// ReleaseReservations drops what is still reserved for a file.
+func (sh *StorageNodeHandler) ReleaseReservations(fileName string) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	sh.releaseReservations(fileName)
+}
+
+// ReleaseBlocks drops what is still reserved for the fragments stored under blocks. A plan that was not
+// recorded releases its own space this way, leaving what other plans for the same file reserved.
+func (sh *StorageNodeHandler) ReleaseBlocks(blocks []string) {
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	for nodeId, fragments := range sh.reservations {
+		for _, block := range blocks {
+			delete(fragments, block)
+		}
+		if len(fragments) == 0 {
+			delete(sh.reservations, nodeId)
+		}
+	}
+}
+
+// releaseReservations drops what is still reserved for a file. Callers hold the write lock.
+func (sh *StorageNodeHandler) releaseReservations(fileName string) {
+
+	for nodeId, fragments := range sh.reservations {
+		for fragment, r := range fragments {
+			if r.file == fileName {
+				delete(fragments, fragment)
+			}
+		}
+		if len(fragments) == 0 {
+			delete(sh.reservations, nodeId)
+		}
+	}
+}
+
+// expireReservations drops the reservations whose uploads took too long. Callers hold the write lock.
+func (sh *StorageNodeHandler) expireReservations(now time.Time) {
+
+	for nodeId, fragments := range sh.reservations {
+		for fragment, r := range fragments {
+			if !now.Before(r.expires) {
+				sh.logger.Sugar().Infof("Reservation for %s on node %s expired", fragment, nodeId)
+				delete(fragments, fragment)
+			}
+		}
+		if len(fragments) == 0 {
+			delete(sh.reservations, nodeId)
+		}
+	}
+}
+
+// reserved is the space held on a node by reservations that have not expired. Callers hold the lock.
+func (sh *StorageNodeHandler) reserved(nodeId string, now time.Time) (bytes int64) {
+
+	for _, r := range sh.reservations[nodeId] {
+		if now.Before(r.expires) {
+			bytes += r.bytes
+		}
+	}
+	return
+}
+
+// AvailableNodes returns copies of the live nodes to plan an upload on. Their free space is what the node
+// last reported, less what pending uploads reserved on it, and planning may change it without touching the nodes.
+func (sh *StorageNodeHandler) AvailableNodes() (nodes []*Node) {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	now := time.Now()
+	nodes = make([]*Node, 0, len(sh.spokeMap))
+	for nodeId, node := range sh.spokeMap {
+		nodes = append(nodes, &Node{
+			ID:                   node.ID,
+			openPort:             node.openPort,
+			host:                 node.host,
+			rack:                 node.rack,
+			freeSpace:            node.freeSpace - sh.reserved(nodeId, now),
+			numRequestsProcessed: node.numRequestsProcessed,
+		})
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package storage_handler
+
+import (
+	"go.uber.org/zap"
+	"src/proto/controller_storage"
+	"testing"
+	"time"
+)
+
+func TestStorageNodeHandler_Reserve(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       time.Duration
+		reserved      []Reservation
+		report        []string
+		forget        string
+		reservations  []Reservation
+		wantErr       error
+		wantAvailable int64
+	}{
+		{
+			name:          "Test plan fits",
+			timeout:       time.Minute,
+			reservations:  []Reservation{{NodeId: "node1", Fragment: "file_0", Bytes: 60}, {NodeId: "node1", Fragment: "file_1", Bytes: 40}},
+			wantAvailable: 0,
+		},
+		{
+			name:          "Test space reserved by another upload",
+			timeout:       time.Minute,
+			reserved:      []Reservation{{NodeId: "node1", Fragment: "other_0", Bytes: 60}},
+			reservations:  []Reservation{{NodeId: "node1", Fragment: "file_0", Bytes: 60}},
+			wantErr:       ErrInsufficientSpace,
+			wantAvailable: 40,
+		},
+		{
+			name:          "Test reservation released when the fragment is reported",
+			timeout:       time.Minute,
+			reserved:      []Reservation{{NodeId: "node1", Fragment: "other_0", Bytes: 60}},
+			report:        []string{"other_0"},
+			reservations:  []Reservation{{NodeId: "node1", Fragment: "file_0", Bytes: 60}},
+			wantAvailable: 40,
+		},
+		{
+			name:          "Test reservation released when the file is forgotten",
+			timeout:       time.Minute,
+			reserved:      []Reservation{{NodeId: "node1", Fragment: "other_0", Bytes: 60}},
+			forget:        "other",
+			reservations:  []Reservation{{NodeId: "node1", Fragment: "file_0", Bytes: 60}},
+			wantAvailable: 40,
+		},
+		{
+			name:         "Test expired reservation",
+			timeout:      0,
+			reserved:     []Reservation{{NodeId: "node1", Fragment: "other_0", Bytes: 60}},
+			reservations: []Reservation{{NodeId: "node1", Fragment: "file_0", Bytes: 60}},
+			//the new reservation expires at once too
+			wantAvailable: 100,
+		},
+		{
+			name:          "Test unknown node",
+			timeout:       time.Minute,
+			reservations:  []Reservation{{NodeId: "node2", Fragment: "file_0", Bytes: 1}},
+			wantErr:       ErrInsufficientSpace,
+			wantAvailable: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.SetReservationTimeout(tt.timeout)
+			node := &Node{ID: "node1", freeSpace: 100, files: fileSet()}
+			sh.spokeMap["node1"] = node
+
+			if err := sh.Reserve("other", tt.reserved); err != nil {
+				t.Fatalf("Reserve() of the other upload error = %v", err)
+			}
+			if tt.report != nil {
+				sh.applyBlockReport(node, controller_storage.BlockReport{Seq: 1, Full: true, Files: tt.report})
+			}
+			if tt.forget != "" {
+				sh.ForgetFile(tt.forget)
+			}
+
+			if err := sh.Reserve("file", tt.reservations); err != tt.wantErr {
+				t.Errorf("Reserve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := sh.AvailableNodes()[0].GetFreeSpace(); got != tt.wantAvailable {
+				t.Errorf("AvailableNodes() free space = %d, want %d", got, tt.wantAvailable)
+			}
+		})
+	}
+}
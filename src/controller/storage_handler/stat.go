@@ -0,0 +1,130 @@
+package storage_handler
+
+import (
+	"src/erasure"
+	"time"
+)
+
+// The health of a fragment, from how many of its replicas are on registered nodes.
+const (
+	FRAGMENT_HEALTHY          = "HEALTHY"
+	FRAGMENT_UNDER_REPLICATED = "UNDER_REPLICATED"
+	FRAGMENT_MISSING          = "MISSING"
+)
+
+// FileStat is what the controller knows about a committed file.
+type FileStat struct {
+	Name              string
+	Size              int64
+	ChunkSize         int64
+	ReplicationFactor int
+	//nil for a replicated file
+	Layout   *erasure.Layout
+	Created  time.Time
+	Owner    string
+	Checksum string
+	//every fragment of the file, in file order
+	Fragments []FragmentStat
+}
+
+// FragmentStat is a fragment of a file and the registered nodes the Index has a replica of it on.
+type FragmentStat struct {
+	Id       string
+	Index    int
+	Size     int64
+	Replicas []*Node
+	Health   string
+}
+
+// StatFile describes a committed file. Where its fragments are comes from the Index, so replicas stored since
+// the last indexing run are not counted yet.
+func (sh *StorageNodeHandler) StatFile(fileName string) (stat *FileStat, err error) {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	if !sh.committed(fileName) {
+		return nil, ErrFileNotFound
+	}
+
+	if sh.meta == nil {
+		return nil, ErrFileNotFound
+	}
+	meta, found := sh.meta.GetFile(fileName)
+	if !found {
+		return nil, ErrFileNotFound
+	}
+
+	stat = &FileStat{Name: fileName, ReplicationFactor: sh.replicationFactor(fileName)}
+	stat.Size, stat.ChunkSize = meta.Size, meta.ChunkSize
+	stat.Created, stat.Owner, stat.Checksum = meta.Created, meta.Owner, meta.Checksum
+	if meta.ErasureCoded() {
+		layout := erasureLayout(meta)
+		stat.Layout = &layout
+	}
+
+	replicas := sh.Index.fileMap[fileName]
+	for i, id := range meta.Blocks {
+		frag := FragmentStat{Id: id, Index: i, Size: stat.fragmentSize(i), Replicas: sh.liveReplicas(replicas[id])}
+
+		switch {
+		case len(frag.Replicas) == 0:
+			frag.Health = FRAGMENT_MISSING
+		case len(frag.Replicas) < stat.ReplicationFactor:
+			frag.Health = FRAGMENT_UNDER_REPLICATED
+		default:
+			frag.Health = FRAGMENT_HEALTHY
+		}
+		stat.Fragments = append(stat.Fragments, frag)
+	}
+	return
+}
+
+// liveReplicas returns the registered nodes among those the Index has a replica on. Replicas on nodes that
+// are gone are left in the Index until it is rebuilt. Callers hold the lock.
+func (sh *StorageNodeHandler) liveReplicas(nodeIds []string) (nodes []*Node) {
+
+	for _, nodeId := range nodeIds {
+		if node, ok := sh.spokeMap[nodeId]; ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return
+}
+
+// underReplicated reports whether a fragment of a file has fewer live replicas than the file's replication
+// factor. Callers hold the lock.
+func (sh *StorageNodeHandler) underReplicated(fileName string) bool {
+
+	if sh.meta == nil {
+		return false
+	}
+	meta, found := sh.meta.GetFile(fileName)
+	if !found {
+		return false
+	}
+
+	replicas := sh.Index.fileMap[fileName]
+	for _, id := range meta.Blocks {
+		if len(sh.liveReplicas(replicas[id])) < sh.replicationFactor(fileName) {
+			return true
+		}
+	}
+	return false
+}
+
+// fragmentSize returns the length of fragment i of the file, 0 if the layout of the file is not known.
+func (stat *FileStat) fragmentSize(i int) int64 {
+
+	if stat.Layout != nil {
+		return stat.Layout.ShardLength(stat.Layout.Locate(i))
+	}
+	if stat.ChunkSize <= 0 {
+		return 0
+	}
+	size := stat.Size - int64(i)*stat.ChunkSize
+	if size > stat.ChunkSize {
+		size = stat.ChunkSize
+	}
+	return size
+}
@@ -0,0 +1,89 @@
+package storage_handler
+
+import (
+	"go.uber.org/zap"
+	"src/controller/metadata"
+	"testing"
+)
+
+func TestStorageNodeHandler_StatFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		redundancy metadata.Redundancy
+		blocks     []string
+		replicas   map[string][]string
+		commit     bool
+		wantErr    error
+		wantSizes  []int64
+		wantHealth []string
+	}{
+		{
+			name:       "Test replicated file",
+			redundancy: metadata.Redundancy{ReplicationFactor: 2},
+			blocks:     []string{"c3", "a1", "b2"},
+			replicas:   map[string][]string{"c3": {"node1", "node2"}, "a1": {"node1", "gone"}},
+			commit:     true,
+			wantSizes:  []int64{10, 10, 5},
+			wantHealth: []string{FRAGMENT_HEALTHY, FRAGMENT_UNDER_REPLICATED, FRAGMENT_MISSING},
+		},
+		{
+			name:       "Test erasure coded file",
+			redundancy: metadata.Redundancy{ReplicationFactor: 1, DataShards: 2, ParityShards: 1},
+			blocks:     []string{"d0", "d1", "p0", "d2", "d3", "p1", "d4", "d5", "p2"},
+			replicas:   map[string][]string{"d0": {"node1"}, "d1": {"node2"}, "p0": {"node1"}},
+			commit:     true,
+			wantSizes:  []int64{5, 5, 5, 5, 5, 5, 3, 2, 3},
+			wantHealth: []string{FRAGMENT_HEALTHY, FRAGMENT_HEALTHY, FRAGMENT_HEALTHY, FRAGMENT_MISSING, FRAGMENT_MISSING, FRAGMENT_MISSING, FRAGMENT_MISSING, FRAGMENT_MISSING, FRAGMENT_MISSING},
+		},
+		{
+			name:       "Test file being uploaded",
+			redundancy: metadata.Redundancy{ReplicationFactor: 1},
+			blocks:     []string{"c3", "a1", "b2"},
+			wantErr:    ErrFileNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := metadata.Open(t.TempDir(), zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer store.Close()
+
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.SetMetadataStore(store, 0)
+			sh.spokeMap["node1"] = &Node{ID: "node1"}
+			sh.spokeMap["node2"] = &Node{ID: "node2"}
+			sh.RecordFile("file", 25, 10, tt.redundancy, tt.blocks, "upload", "alice")
+			if tt.commit {
+				sh.CommitFile("file", "9f86d0")
+			}
+			sh.Index.fileMap["file"] = tt.replicas
+
+			stat, err := sh.StatFile("file")
+			if err != tt.wantErr {
+				t.Fatalf("StatFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if stat.Owner != "alice" || stat.Checksum != "9f86d0" || stat.Size != 25 {
+				t.Errorf("StatFile() owner = %q, checksum = %q, size = %d", stat.Owner, stat.Checksum, stat.Size)
+			}
+			if len(stat.Fragments) != len(tt.blocks) {
+				t.Fatalf("StatFile() has %d fragments, want %d", len(stat.Fragments), len(tt.blocks))
+			}
+			for i, frag := range stat.Fragments {
+				if frag.Id != tt.blocks[i] || frag.Index != i {
+					t.Errorf("fragment %d is %s at %d, want %s", i, frag.Id, frag.Index, tt.blocks[i])
+				}
+				if frag.Size != tt.wantSizes[i] {
+					t.Errorf("%s size = %d, want %d", frag.Id, frag.Size, tt.wantSizes[i])
+				}
+				if frag.Health != tt.wantHealth[i] {
+					t.Errorf("%s health = %s, want %s", frag.Id, frag.Health, tt.wantHealth[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package storage_handler
+
+import (
+	"errors"
+	"src/controller/metadata"
+	"time"
+)
+
+var ErrFileNotFound = errors.New("file not found")
+
+// committed reports whether clients may see a file. Files the metadata store does not know were adopted
+// from block reports and are visible. Callers hold the lock.
+func (sh *StorageNodeHandler) committed(fileName string) bool {
+
+	if sh.meta == nil {
+		return true
+	}
+	state, found := sh.meta.State(fileName)
+	return !found || state == metadata.StateCommitted
+}
+
+// CommitFile makes an upload visible once the client stored every fragment of it, and records the checksum
+// of the whole file the client computed.
+func (sh *StorageNodeHandler) CommitFile(fileName string, checksum string) (err error) {
+
+	if sh.meta == nil {
+		return
+	}
+	err = sh.meta.Commit(fileName, checksum)
+	if err == metadata.ErrNotUploading {
+		return ErrFileNotFound
+	}
+	return
+}
+
+// StartDelete hides a file from clients while its fragments are removed.
+func (sh *StorageNodeHandler) StartDelete(fileName string) (err error) {
+
+	if sh.meta == nil {
+		return
+	}
+	return sh.meta.MarkDeleting(fileName)
+}
+
+// StaleUploads lists the files that were planned or uploading, or being deleted, for longer than timeout.
+// Their uploads were abandoned or their deletes failed, and their fragments are to be removed.
+func (sh *StorageNodeHandler) StaleUploads(timeout time.Duration) (files []string) {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	if sh.meta == nil {
+		return
+	}
+	for name, meta := range sh.meta.Files() {
+		if meta.State != metadata.StateCommitted && time.Since(meta.Updated) > timeout {
+			files = append(files, name)
+		}
+	}
+	return
+}
+
+// ResumeUpload returns the metadata of a file still being uploaded under uploadId, so the client can carry on
+// with the fragments it has not stored yet.
+func (sh *StorageNodeHandler) ResumeUpload(fileName string, uploadId string) (meta *metadata.FileMeta, ok bool) {
+
+	if sh.meta == nil {
+		return nil, false
+	}
+	meta, err := sh.meta.Resume(fileName, uploadId)
+	return meta, err == nil
+}
+
+// UploadId returns the id of the PUT that stored a file, empty if it is not known.
+func (sh *StorageNodeHandler) UploadId(fileName string) string {
+
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+
+	if sh.meta == nil {
+		return ""
+	}
+	meta, found := sh.meta.GetFile(fileName)
+	if !found {
+		return ""
+	}
+	return meta.UploadId
+}
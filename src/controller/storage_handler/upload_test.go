@@ -0,0 +1,70 @@
+package storage_handler
+
+import (
+	"go.uber.org/zap"
+	"reflect"
+	"src/controller/metadata"
+	"testing"
+	"time"
+)
+
+func TestStorageNodeHandler_CommitFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		commit      bool
+		deleting    bool
+		wantErr     error
+		wantVisible bool
+		wantStale   []string
+	}{
+		{
+			name:      "Test upload not committed",
+			wantStale: []string{"file"},
+		},
+		{
+			name:        "Test upload committed",
+			commit:      true,
+			wantVisible: true,
+		},
+		{
+			name:      "Test commit after the delete started",
+			deleting:  true,
+			commit:    true,
+			wantErr:   ErrFileNotFound,
+			wantStale: []string{"file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := metadata.Open(t.TempDir(), zap.NewNop())
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer store.Close()
+
+			sh := NewStorageNodeHandler(zap.NewNop())
+			sh.SetMetadataStore(store, 0)
+			sh.spokeMap["node1"] = &Node{ID: "node1", files: fileSet("file_0")}
+			sh.RecordFile("file", 10, 10, metadata.Redundancy{ReplicationFactor: 1}, []string{"file_0"}, "upload", "")
+
+			if tt.deleting {
+				sh.StartDelete("file")
+			}
+			if tt.commit {
+				if err := sh.CommitFile("file", ""); err != tt.wantErr {
+					t.Errorf("CommitFile() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+
+			if visible := sh.FindFiles("file", zap.NewNop()) != nil; visible != tt.wantVisible {
+				t.Errorf("FindFiles() found the file = %v, want %v", visible, tt.wantVisible)
+			}
+			if listed := sh.FindAllFiles(zap.NewNop()) != nil; listed != tt.wantVisible {
+				t.Errorf("FindAllFiles() listed the file = %v, want %v", listed, tt.wantVisible)
+			}
+			if got := sh.StaleUploads(-time.Second); !reflect.DeepEqual(got, tt.wantStale) {
+				t.Errorf("StaleUploads() = %v, want %v", got, tt.wantStale)
+			}
+		})
+	}
+}
@@ -0,0 +1,597 @@
+// Package dfs is a client for the DFS. It asks the Controller where fragments go or are, and moves them to and
+// from the Storage Nodes itself. Errors are returned, nothing is printed and the process is never exited.
+package dfs
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"src/erasure"
+	"src/file"
+	messages "src/messages/controller_client"
+	proto3 "src/proto/controller_client"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DEFAULT_CHUNK_SIZE is the size of the fragments a PUT splits a file into unless SetChunkSize was called.
+const DEFAULT_CHUNK_SIZE = 128000000
+
+// Client holds the address of the Controller and the settings of PUTs. It keeps no state between calls, so a
+// single Client can run several of them at once.
+type Client struct {
+	serverPort string
+	logger     *zap.Logger
+	//the controllers of a highly available group, and the one the client last reached
+	controllers     []string
+	controller      int
+	controllerMutex sync.Mutex
+
+	chunkSize         int64
+	minReplicas       int
+	replicationFactor int
+	dataShards        int
+	parityShards      int
+	//the user files are stored as, the one running the process unless SetOwner was called
+	owner string
+
+	//uploads of a PUT running at once, in total and to a single node
+	concurrency     int
+	nodeConcurrency int
+	nodeSlots       map[string]chan struct{}
+	slotMutex       sync.Mutex
+
+	connectTimeout time.Duration
+	readTimeout    time.Duration
+	writeTimeout   time.Duration
+}
+
+// transfer is the state of one PUT or GET.
+type transfer struct {
+	*Client
+	ctx  context.Context
+	file *file.FileHandler
+
+	//progress of the transfer, nil unless it can be resumed
+	journal *journal
+	//the PUT continues an interrupted one, fragments the nodes already hold were stored by it
+	resumed bool
+}
+
+// FileInfo describes a file stored in the DFS.
+type FileInfo struct {
+	Name string
+	//0 if the Controller does not know the layout of the file
+	Size              int64
+	ChunkSize         int64
+	Fragments         int
+	ReplicationFactor int
+	//nil for a replicated file
+	Erasure *erasure.Layout
+	//zero if the Controller does not know when the file was stored
+	Created time.Time
+	Owner   string
+	//SHA-256 of the whole file in hex, empty for files stored before it was recorded
+	Checksum string
+	//every fragment of the file in order
+	Layout []FragmentStat
+}
+
+// FragmentStat is a fragment of a file and the live nodes holding it.
+type FragmentStat struct {
+	Id       string
+	Index    int
+	Size     int64
+	Replicas []Replica
+	//HEALTHY, UNDER_REPLICATED with fewer replicas than the replication factor, or MISSING
+	Health string
+}
+
+// Entry is a file or directory in a directory of the DFS.
+type Entry struct {
+	//the last element of the path
+	Name string
+	Dir  bool
+	//set for files
+	Size      int64
+	Fragments int
+	//a fragment of the file has fewer replicas on live Storage Nodes than its replication factor
+	UnderReplicated bool
+}
+
+// ListOptions narrow a listing down to a page of the entries whose name matches a pattern.
+type ListOptions struct {
+	//a glob the name of an entry matches, or a prefix of it when it holds none of *, ? and [
+	Pattern string
+	//the token ListPage returned with the previous page, empty for the first one
+	PageToken string
+	//the most entries a page holds, 0 for all of them
+	Limit int
+}
+
+// NodeStat is a Storage Node and the free space it last reported.
+type NodeStat struct {
+	NodeId    string
+	DiskSpace int64
+}
+
+// NewClient returns a client of the Controller at serverPort (host:port). For a highly available group of
+// controllers serverPort lists all of them separated by commas, and requests go to whichever is active.
+// logger may be nil.
+func NewClient(serverPort string, logger *zap.Logger) (client *Client) {
+
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	client = &Client{
+		serverPort:  serverPort,
+		logger:      logger,
+		controllers: strings.Split(serverPort, ","),
+	}
+
+	return
+}
+
+// SetChunkSize sets the size of the fragments a PUT splits a file into. 0 uses DEFAULT_CHUNK_SIZE.
+func (c *Client) SetChunkSize(chunkSize int64) {
+	c.chunkSize = chunkSize
+}
+
+func (c *Client) SetMinReplicas(minReplicas int) {
+	c.minReplicas = minReplicas
+}
+
+func (c *Client) SetReplicationFactor(replicationFactor int) {
+	c.replicationFactor = replicationFactor
+}
+
+// SetErasureCoding stores files coded into groups of data and parity shards instead of replicating them.
+func (c *Client) SetErasureCoding(dataShards int, parityShards int) {
+	c.dataShards = dataShards
+	c.parityShards = parityShards
+}
+
+// SetOwner sets the user files are stored as.
+func (c *Client) SetOwner(owner string) {
+	c.owner = owner
+}
+
+// SetConcurrency sets how many fragments a PUT uploads at once, and how many of them may go to the same node.
+// 0 keeps the defaults.
+func (c *Client) SetConcurrency(concurrency int, nodeConcurrency int) {
+	c.concurrency = concurrency
+	c.nodeConcurrency = nodeConcurrency
+}
+
+// ownerName returns the user files are stored as.
+func (c *Client) ownerName() string {
+
+	if c.owner != "" {
+		return c.owner
+	}
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return ""
+}
+
+func (c *Client) newTransfer(ctx context.Context, dir string, name string) *transfer {
+
+	fileHandler := file.NewFileHandler(name)
+	//the file handler joins dir and name as they are
+	fileHandler.SetDir(filepath.Clean(dir) + string(filepath.Separator))
+	return &transfer{Client: c, ctx: ctx, file: fileHandler}
+}
+
+// call sends a request to the Controller and returns its response. The Controller answers one request per
+// connection, so every call dials a new one. It is closed early if ctx is done.
+func (c *Client) call(ctx context.Context, send func(proto *proto3.ProtoHandler)) (res proto3.ResponseInterface, err error) {
+
+	conn, release, err := c.dialController(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to the Controller at %s: %w", c.serverPort, err)
+	}
+	defer release()
+	defer conn.Close()
+
+	msgHandler := messages.NewMessageHandler(conn)
+	c.setTimeouts(msgHandler)
+	proto := proto3.NewProtoHandler(msgHandler, c.logger)
+	send(proto)
+
+	wrapper, err := msgHandler.ControllerResponseReceive()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading the Controller's response: %w", err)
+	}
+	if wrapper.ControllerMessage == nil {
+		return nil, fmt.Errorf("the Controller closed the connection without a response")
+	}
+	return proto.HandleControllerResponse(wrapper), nil
+}
+
+// Put stores what r holds as name. r is copied to a temporary file first, since fragments are read again when
+// a node fails and to encode parity. Use PutFile for a file on disk.
+func (c *Client) Put(ctx context.Context, name string, r io.Reader) (err error) {
+
+	dir, err := os.MkdirTemp("", "dfs-put-")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, name)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return
+	}
+	spool, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	_, err = io.Copy(spool, r)
+	if errC := spool.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		return
+	}
+
+	return c.PutFile(ctx, dir, name)
+}
+
+// PutFile stores the file name in dir under the same name, and commits it once every fragment was stored. If
+// an earlier PutFile of the same file was interrupted, only the fragments it did not store are uploaded.
+func (c *Client) PutFile(ctx context.Context, dir string, name string) (err error) {
+
+	t := c.newTransfer(ctx, dir, name)
+	info, err := os.Stat(filepath.Join(dir, name))
+	if err != nil {
+		return
+	}
+	t.file.SetFileSize(info.Size())
+
+	t.journal = openJournal(dir, name, "put")
+	uploadId := ""
+	if t.journal.matches(info) {
+		uploadId = t.journal.session()
+		c.logger.Info("Resuming upload", zap.String("uploadId", uploadId))
+	} else {
+		t.journal.reset(info)
+	}
+
+	chunkSize := c.chunkSize
+	if chunkSize <= 0 {
+		chunkSize = DEFAULT_CHUNK_SIZE
+	}
+	res, err := c.call(ctx, func(proto *proto3.ProtoHandler) {
+		proto.HandlePutRequest(name, info.Size(), chunkSize, c.replicationFactor, c.dataShards, c.parityShards, uploadId, c.ownerName())
+	})
+	if err != nil {
+		return
+	}
+	plan, ok := res.(*proto3.PlanResponse)
+	if !ok {
+		return ErrUnexpectedResponse
+	}
+	if plan.StatusCode != "OK" {
+		return &StatusError{Op: "put", Status: plan.StatusCode}
+	}
+
+	t.resumed = t.journal.begin(plan.UploadId)
+	failed := t.DispatchFile(plan)
+	if len(failed) > 0 {
+		return &DispatchError{Failed: failed}
+	}
+
+	return t.commit()
+}
+
+// commit makes a stored file visible, and records the checksum of the whole file with it.
+func (t *transfer) commit() (err error) {
+
+	sum, err := checksum(filepath.Join(t.file.Dir(), t.file.FileName()))
+	if err != nil {
+		return
+	}
+	res, err := t.call(t.ctx, func(proto *proto3.ProtoHandler) {
+		proto.HandleCommitRequest(t.file.FileName(), sum)
+	})
+	if err != nil {
+		return
+	}
+	commit, ok := res.(*proto3.CommitResponse)
+	if !ok {
+		return ErrUnexpectedResponse
+	}
+	if commit.StatusCode != "OK" {
+		return &StatusError{Op: "commit", Status: commit.StatusCode}
+	}
+
+	t.journal.remove()
+	t.logger.Info("File committed to the DFS")
+	return
+}
+
+// locate asks the Controller where the fragments holding r of a file are.
+func (c *Client) locate(ctx context.Context, name string, r Range) (res *proto3.FragLayoutResponse, err error) {
+
+	c.logger.Info("Handling GET request")
+	reply, err := c.call(ctx, func(proto *proto3.ProtoHandler) {
+		proto.HandleGetRequest(name, r.Offset, r.Length)
+	})
+	if err != nil {
+		return
+	}
+	res, ok := reply.(*proto3.FragLayoutResponse)
+	if !ok {
+		return nil, ErrUnexpectedResponse
+	}
+	if res.StatusCode != "OK" {
+		return nil, &StatusError{Op: "get", Status: res.StatusCode}
+	}
+	return
+}
+
+// Get writes the file name to w.
+func (c *Client) Get(ctx context.Context, name string, w io.Writer) error {
+	return c.GetRange(ctx, name, Range{}, w)
+}
+
+// GetRange writes r of the file name to w, in order. Only STREAM_WINDOW fragments are held in memory, and each
+// one is written once its checksum matched. The shards of an erasure coded file go through a temporary
+// directory, one group at a time, so missing ones can be rebuilt.
+func (c *Client) GetRange(ctx context.Context, name string, r Range, w io.Writer) (err error) {
+
+	res, err := c.locate(ctx, name, r)
+	if err != nil {
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "dfs-get-")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	return c.newTransfer(ctx, dir, name).writeOutput(res, w)
+}
+
+// GetFile writes r of the file name into dir, each fragment straight to its place, and returns the path of the
+// file written. It is dir/name, or dir/<name>.<start>-<end> for part of the file. The file is only moved into
+// place once every fragment matched its checksum.
+func (c *Client) GetFile(ctx context.Context, dir string, name string, r Range) (path string, err error) {
+
+	res, err := c.locate(ctx, name, r)
+	if err != nil {
+		return
+	}
+
+	path = filepath.Join(dir, name)
+	if res.RangeLength > 0 {
+		path = filepath.Join(dir, rangeFileName(name, res.RangeOffset, res.RangeLength))
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return
+	}
+	err = c.newTransfer(ctx, dir, name).writeOutputFile(res, path)
+	return
+}
+
+// Download fetches the fragments of the file name into dir and then combines them into dir/name. If an
+// earlier Download of the same file was interrupted, the fragments it fetched are kept.
+func (c *Client) Download(ctx context.Context, dir string, name string) (err error) {
+
+	res, err := c.locate(ctx, name, Range{})
+	if err != nil {
+		return
+	}
+
+	//a file in a directory of the DFS is fetched into the same directory under dir
+	err = os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+	if err != nil {
+		return
+	}
+
+	t := c.newTransfer(ctx, dir, name)
+	t.journal = openJournal(dir, name, "get")
+	t.journal.begin(res.UploadId)
+	return t.FetchFile(res)
+}
+
+// Stat describes the file name: its layout, who stored it and when, and where each of its fragments is.
+func (c *Client) Stat(ctx context.Context, name string) (info *FileInfo, err error) {
+
+	res, err := c.call(ctx, func(proto *proto3.ProtoHandler) {
+		proto.HandleStatRequest(name)
+	})
+	if err != nil {
+		return
+	}
+	stat, ok := res.(*proto3.StatResponse)
+	if !ok {
+		return nil, ErrUnexpectedResponse
+	}
+	if stat.StatusCode != "OK" {
+		return nil, &StatusError{Op: "stat", Status: stat.StatusCode}
+	}
+
+	info = &FileInfo{
+		Name:              stat.FileName,
+		Size:              stat.Size,
+		ChunkSize:         stat.ChunkSize,
+		Fragments:         int(stat.NumFragments),
+		ReplicationFactor: stat.ReplicationFactor,
+		Erasure:           stat.Erasure,
+		Owner:             stat.Owner,
+		Checksum:          stat.Checksum,
+		Layout:            make([]FragmentStat, 0, len(stat.Fragments)),
+	}
+	if stat.Created != 0 {
+		info.Created = time.UnixMilli(stat.Created)
+	}
+	for _, frag := range stat.Fragments {
+		fragStat := FragmentStat{Id: frag.FragmentId, Index: frag.Index, Size: frag.Size, Health: frag.Health}
+		for _, node := range frag.StorageNodes {
+			fragStat.Replicas = append(fragStat.Replicas, Replica{FragmentId: frag.FragmentId, NodeId: node.NodeId, Addr: node.Host + ":" + node.Port})
+		}
+		info.Layout = append(info.Layout, fragStat)
+	}
+	return
+}
+
+// List returns the files and directories directly in dir, directories first. The root directory is "" or "/".
+func (c *Client) List(ctx context.Context, dir string) (entries []Entry, err error) {
+
+	page, _, err := c.ListPage(ctx, dir, ListOptions{})
+	if err != nil {
+		return
+	}
+
+	entries = make([]Entry, 0, len(page))
+	for _, entry := range page {
+		if entry.Dir {
+			entries = append(entries, entry)
+		}
+	}
+	for _, entry := range page {
+		if !entry.Dir {
+			entries = append(entries, entry)
+		}
+	}
+	return
+}
+
+// ListPage returns a page of the files and directories directly in dir, sorted by name, and the token of the
+// next page, empty on the last one.
+func (c *Client) ListPage(ctx context.Context, dir string, opts ListOptions) (entries []Entry, nextPageToken string, err error) {
+
+	res, err := c.call(ctx, func(proto *proto3.ProtoHandler) {
+		proto.HandleLsRequest(dir, opts.Pattern, opts.PageToken, opts.Limit)
+	})
+	if err != nil {
+		return
+	}
+	ls, ok := res.(*proto3.LsResponse)
+	if !ok {
+		return nil, "", ErrUnexpectedResponse
+	}
+	if ls.StatusCode != "OK" {
+		return nil, "", &StatusError{Op: "list", Status: ls.StatusCode}
+	}
+
+	entries = make([]Entry, 0, len(ls.Entries))
+	for _, entry := range ls.Entries {
+		entries = append(entries, Entry{
+			Name:            entry.Name,
+			Dir:             entry.Dir,
+			Size:            entry.Size,
+			Fragments:       entry.Fragments,
+			UnderReplicated: entry.UnderReplicated,
+		})
+	}
+	return entries, ls.NextPageToken, nil
+}
+
+// Mkdir creates the directory path, and the directories above it that do not exist yet.
+func (c *Client) Mkdir(ctx context.Context, path string) error {
+	return c.namespace(ctx, "mkdir", func(proto *proto3.ProtoHandler) {
+		proto.HandleMkdirRequest(path)
+	})
+}
+
+// Rmdir removes the empty directory path.
+func (c *Client) Rmdir(ctx context.Context, path string) error {
+	return c.namespace(ctx, "rmdir", func(proto *proto3.ProtoHandler) {
+		proto.HandleRmdirRequest(path)
+	})
+}
+
+// Rename moves the file or directory from to to. No fragment is moved, only the Controller's metadata changes.
+func (c *Client) Rename(ctx context.Context, from string, to string) error {
+	return c.namespace(ctx, "rename", func(proto *proto3.ProtoHandler) {
+		proto.HandleRenameRequest(from, to)
+	})
+}
+
+// namespace sends a request answered with a NamespaceResponse.
+func (c *Client) namespace(ctx context.Context, op string, send func(*proto3.ProtoHandler)) error {
+
+	res, err := c.call(ctx, send)
+	if err != nil {
+		return err
+	}
+	ns, ok := res.(*proto3.NamespaceResponse)
+	if !ok {
+		return ErrUnexpectedResponse
+	}
+	if ns.StatusCode != "OK" {
+		return &StatusError{Op: op, Status: ns.StatusCode}
+	}
+	return nil
+}
+
+// Delete removes the file name from the DFS. If some of its replicas could not be removed the error is a
+// *DeleteError listing them.
+func (c *Client) Delete(ctx context.Context, name string) (err error) {
+
+	c.logger.Info("Handling DELETE request")
+	res, err := c.call(ctx, func(proto *proto3.ProtoHandler) {
+		proto.HandleDeleteRequest(name)
+	})
+	if err != nil {
+		return
+	}
+	deleteRes, ok := res.(*proto3.DeleteResponse)
+	if !ok {
+		return ErrUnexpectedResponse
+	}
+
+	switch {
+	case deleteRes.StatusCode == "OK":
+		return nil
+	case len(deleteRes.FailedReplicas) == 0:
+		return &StatusError{Op: "delete", Status: deleteRes.StatusCode}
+	}
+
+	failed := &DeleteError{Status: deleteRes.StatusCode}
+	for _, replica := range deleteRes.FailedReplicas {
+		failed.Failed = append(failed.Failed, Replica{
+			FragmentId: replica.FragmentId,
+			NodeId:     replica.NodeId,
+			Addr:       replica.Host + ":" + replica.Port,
+		})
+	}
+	return failed
+}
+
+// NodeStats returns the Storage Nodes of the cluster.
+func (c *Client) NodeStats(ctx context.Context) (nodes []NodeStat, err error) {
+
+	res, err := c.call(ctx, func(proto *proto3.ProtoHandler) {
+		proto.HandleNodeStatsRequest()
+	})
+	if err != nil {
+		return
+	}
+	stats, ok := res.(*proto3.NodeStats)
+	if !ok {
+		return nil, ErrUnexpectedResponse
+	}
+	if stats.StatusCode != "OK" {
+		return nil, &StatusError{Op: "node stats", Status: stats.StatusCode}
+	}
+
+	for _, node := range stats.Nodes {
+		nodes = append(nodes, NodeStat{NodeId: node.NodeId, DiskSpace: node.DiskSpace})
+	}
+	return
+}
@@ -0,0 +1,96 @@
+package dfs
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+const (
+	// DEFAULT_CONNECT_TIMEOUT is how long connecting to the Controller or a Storage Node may take.
+	DEFAULT_CONNECT_TIMEOUT = 10 * time.Second
+	// DEFAULT_READ_TIMEOUT is how long the client waits for the next message before it gives up on a connection.
+	DEFAULT_READ_TIMEOUT = 60 * time.Second
+	// DEFAULT_WRITE_TIMEOUT is how long sending a message may take.
+	DEFAULT_WRITE_TIMEOUT = 60 * time.Second
+)
+
+// timeoutSetter is the part of the message handlers the client sets deadlines on.
+type timeoutSetter interface {
+	SetTimeouts(read time.Duration, write time.Duration)
+}
+
+// SetTimeouts bounds connecting to the Controller and the Storage Nodes, and every read and write of a message
+// once connected. 0 keeps a default.
+func (c *Client) SetTimeouts(connect time.Duration, read time.Duration, write time.Duration) {
+	c.connectTimeout = connect
+	c.readTimeout = read
+	c.writeTimeout = write
+}
+
+func (c *Client) timeouts() (connect time.Duration, read time.Duration, write time.Duration) {
+
+	connect, read, write = c.connectTimeout, c.readTimeout, c.writeTimeout
+	if connect <= 0 {
+		connect = DEFAULT_CONNECT_TIMEOUT
+	}
+	if read <= 0 {
+		read = DEFAULT_READ_TIMEOUT
+	}
+	if write <= 0 {
+		write = DEFAULT_WRITE_TIMEOUT
+	}
+	return
+}
+
+// dial connects to addr and closes the connection when ctx is cancelled, which fails whatever is reading or
+// writing on it. release stops watching ctx, it is called once the connection is done with.
+func (c *Client) dial(ctx context.Context, addr string) (conn net.Conn, release func(), err error) {
+
+	connect, _, _ := c.timeouts()
+	dialer := net.Dialer{Timeout: connect}
+	conn, err = dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	return conn, func() { close(done) }, nil
+}
+
+// dialController connects to the Controller. Only the active controller of a highly available group accepts
+// connections, so every controller is tried in turn, from the one the client last reached.
+func (c *Client) dialController(ctx context.Context) (conn net.Conn, release func(), err error) {
+
+	c.controllerMutex.Lock()
+	first := c.controller
+	c.controllerMutex.Unlock()
+
+	for i := range c.controllers {
+		next := (first + i) % len(c.controllers)
+		conn, release, err = c.dial(ctx, c.controllers[next])
+		if err == nil {
+			c.controllerMutex.Lock()
+			c.controller = next
+			c.controllerMutex.Unlock()
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	return
+}
+
+// setTimeouts puts the read and write deadlines of the client on a message handler.
+func (c *Client) setTimeouts(handler timeoutSetter) {
+	_, read, write := c.timeouts()
+	handler.SetTimeouts(read, write)
+}
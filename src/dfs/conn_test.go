@@ -0,0 +1,107 @@
+package dfs
+
+import (
+	"context"
+	"net"
+	messagesStorage "src/messages/client_storage"
+	"testing"
+	"time"
+)
+
+func TestClient_dial(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	//the node accepts but never answers
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		read    time.Duration
+		cancel  time.Duration
+		wantMax time.Duration
+	}{
+		{name: "Test read deadline", read: 50 * time.Millisecond, cancel: time.Minute, wantMax: 5 * time.Second},
+		{name: "Test cancelled context", read: time.Minute, cancel: 50 * time.Millisecond, wantMax: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{}
+			c.SetTimeouts(0, tt.read, 0)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.cancel)
+			defer cancel()
+			conn, release, err := c.dial(ctx, listener.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer release()
+			defer conn.Close()
+
+			msgHandler := messagesStorage.NewMessageHandler(conn)
+			c.setTimeouts(msgHandler)
+
+			start := time.Now()
+			_, err = msgHandler.ServerResponseReceive()
+			if err == nil {
+				t.Errorf("ServerResponseReceive() error = nil, want one")
+			}
+			if elapsed := time.Since(start); elapsed > tt.wantMax {
+				t.Errorf("ServerResponseReceive() took %v, want less than %v", elapsed, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestClient_dialController(t *testing.T) {
+
+	//the first controller is down, the second one is active
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	downAddr := down.Addr().String()
+	down.Close()
+
+	active, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer active.Close()
+	go func() {
+		for {
+			conn, err := active.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	c := NewClient(downAddr+","+active.Addr().String(), nil)
+	for i := 0; i < 2; i++ {
+		conn, release, err := c.dialController(context.Background())
+		if err != nil {
+			t.Fatalf("dialController() error = %v", err)
+		}
+		if conn.RemoteAddr().String() != active.Addr().String() {
+			t.Errorf("dialController() connected to %s, want %s", conn.RemoteAddr(), active.Addr())
+		}
+		release()
+		conn.Close()
+		if c.controller != 1 {
+			t.Errorf("controller = %d after dialController(), want 1", c.controller)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package dfs
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	messagesStorage "src/messages/client_storage"
+	proto3Storage "src/proto/client_storage"
+	proto3 "src/proto/controller_client"
+	"strings"
+	"sync"
+)
+
+const (
+	// DEFAULT_CONCURRENCY is how many fragments a PUT uploads at once unless its config sets concurrency.
+	DEFAULT_CONCURRENCY = 10
+	// DEFAULT_NODE_CONCURRENCY is how many of those uploads may go to the same node at once.
+	DEFAULT_NODE_CONCURRENCY = 4
+)
+
+// progress aggregates the outcome of the fragments of a PUT as they finish.
+type progress struct {
+	mutex  sync.Mutex
+	total  int
+	stored int
+	bytes  int64
+	failed map[string]error
+}
+
+func (p *progress) record(frag proto3.FragmentInfo, err error) (stored int, bytes int64) {
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err != nil {
+		p.failed[frag.FragmentId] = err
+	} else {
+		p.stored++
+		p.bytes += frag.Size
+	}
+	return p.stored, p.bytes
+}
+
+// DispatchFile uploads the fragments of a plan, and returns the ones that could not be stored.
+func (t *transfer) DispatchFile(res proto3.ResponseInterface) (failed map[string]error) {
+
+	if res.(*proto3.PlanResponse).Erasure != nil {
+		return t.DispatchShards(res.(*proto3.PlanResponse))
+	}
+
+	fragments := res.(*proto3.PlanResponse).FragmentLayout
+	t.file.SetFragmentLayout(fragments)
+
+	failed = t.dispatchFragments(fragments)
+	t.logger.Info("All fragments dispatched")
+	return
+}
+
+// dispatchFragments uploads fragments in parallel, at most concurrency of them at once and at most
+// nodeConcurrency to the same node. Fragments the journal lists are skipped. It returns the fragments that
+// could not be stored on any of their nodes.
+func (t *transfer) dispatchFragments(fragments []proto3.FragmentInfo) (failed map[string]error) {
+
+	pending := make([]proto3.FragmentInfo, 0, len(fragments))
+	for _, frag := range fragments {
+		if t.journal.done(frag.FragmentId) {
+			t.logger.Sugar().Infof("Fragment %s was stored by an earlier attempt", frag.FragmentId)
+			continue
+		}
+		pending = append(pending, frag)
+	}
+
+	p := &progress{total: len(pending), failed: make(map[string]error)}
+	sem := make(chan struct{}, t.maxConcurrency())
+
+	var wg sync.WaitGroup
+	wg.Add(len(pending))
+
+	for _, frag := range pending {
+		go func(f proto3.FragmentInfo) {
+			sem <- struct{}{}
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := t.DispatchFragment(f)
+			if err == nil {
+				t.journal.markDone(f.FragmentId, "")
+			}
+			stored, bytes := p.record(f, err)
+			t.logger.Sugar().Infof("Stored %d of %d fragments, %d bytes", stored, p.total, bytes)
+		}(frag)
+	}
+
+	wg.Wait()
+	return p.failed
+}
+
+// DispatchFragment uploads a fragment through the first of its nodes that takes it. The error lists why each
+// node failed if none did.
+func (t *transfer) DispatchFragment(frag proto3.FragmentInfo) (err error) {
+
+	//a cancelled PUT starts no more uploads
+	if err = t.ctx.Err(); err != nil {
+		return
+	}
+	nodes := frag.StorageNodes
+	if len(nodes) == 0 {
+		return fmt.Errorf("no node was planned for %s", frag.FragmentId)
+	}
+
+	reasons := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		slot := t.nodeSlot(node.NodeId)
+		slot <- struct{}{}
+		err := t.DispatchToNode(frag, node)
+		<-slot
+
+		if err == proto3Storage.ErrFileExists && t.resumed {
+			t.logger.Sugar().Infof("Node %s holds %s from an earlier attempt", node.NodeId, frag.FragmentId)
+			return nil
+		}
+		if err == nil {
+			return nil
+		}
+		t.logger.Sugar().Error("There was an error dispatching to node: ", node.NodeId)
+		reasons = append(reasons, node.NodeId+": "+err.Error())
+	}
+
+	t.logger.Sugar().Errorf("Fragment %s could not be stored with enough replicas", frag.FragmentId)
+	return fmt.Errorf("none of the %d nodes of %s stored it (%s)", len(nodes), frag.FragmentId, strings.Join(reasons, "; "))
+}
+
+// nodeSlot returns the semaphore that caps the uploads going to a node at once.
+func (c *Client) nodeSlot(nodeId string) chan struct{} {
+
+	c.slotMutex.Lock()
+	defer c.slotMutex.Unlock()
+
+	if c.nodeSlots == nil {
+		c.nodeSlots = make(map[string]chan struct{})
+	}
+	slot, ok := c.nodeSlots[nodeId]
+	if !ok {
+		slot = make(chan struct{}, c.maxNodeConcurrency())
+		c.nodeSlots[nodeId] = slot
+	}
+	return slot
+}
+
+func (c *Client) maxConcurrency() int {
+	if c.concurrency <= 0 {
+		return DEFAULT_CONCURRENCY
+	}
+	return c.concurrency
+}
+
+func (c *Client) maxNodeConcurrency() int {
+	if c.nodeConcurrency <= 0 {
+		return DEFAULT_NODE_CONCURRENCY
+	}
+	return c.nodeConcurrency
+}
+
+func (t *transfer) DispatchToNode(frag proto3.FragmentInfo, node proto3.StorageNodeInfo) (err error) {
+
+	conn, release, err := t.dial(t.ctx, node.Host+":"+node.Port)
+	if err != nil {
+		t.logger.Error("There was an error connecting to the host.")
+		return
+	}
+	defer release()
+
+	msgHandler := messagesStorage.NewMessageHandler(conn)
+	t.setTimeouts(msgHandler)
+	proto := proto3Storage.NewProtoHandler(msgHandler, t.logger, t.file.Dir())
+	proto.SetFileHandler(t.file)
+	//a fragment cannot get more replicas than it was planned on, and an erasure coded shard is planned on one node
+	minReplicas := t.minReplicas
+	if minReplicas > len(frag.StorageNodes) {
+		minReplicas = len(frag.StorageNodes)
+	}
+	proto.SetMinReplicas(minReplicas)
+	//might break if too many goroutines are created
+
+	err = proto.HandleFilePutRequest(frag.FragmentId)
+	if err != nil {
+		t.logger.Error("There was an error handling the file put request.")
+		return
+	}
+
+	err = t.handleStorageResponse(proto)
+	return
+}
+
+func (t *transfer) handleStorageResponse(proto *proto3Storage.ProtoHandler) (err error) {
+
+	defer proto.MsgHandler().Close()
+	defer proto.AbortTransfer()
+
+	for {
+
+		wrapper, errR := proto.MsgHandler().ServerResponseReceive()
+		if t.ctx.Err() != nil {
+			return t.ctx.Err()
+		}
+		//the node closes the connection once it answered
+		if errR == io.EOF && !proto.TransferPending() {
+			return
+		}
+		if errR != nil {
+			t.logger.Error("There was an error receiving the server response.", zap.Error(errR))
+			return fmt.Errorf("receiving the node's response: %w", errR)
+		}
+
+		switch wrapper.Response.(type) {
+
+		default:
+			err = proto.HandleResponse(wrapper)
+			if err != nil {
+				return
+			}
+
+		case nil:
+			if proto.TransferPending() {
+				err = errors.New("connection closed before the transfer completed")
+			}
+			return
+		}
+	}
+}
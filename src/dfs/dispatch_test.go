@@ -0,0 +1,98 @@
+package dfs
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"net"
+	"reflect"
+	"sort"
+	proto3Storage "src/proto/client_storage"
+	proto3 "src/proto/controller_client"
+	"strconv"
+	"testing"
+)
+
+func TestClient_handleStorageResponse(t *testing.T) {
+	type fields struct {
+		serverPort string
+		logger     *zap.Logger
+	}
+	type args struct {
+		proto *proto3Storage.ProtoHandler
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+	}{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &transfer{Client: &Client{
+				serverPort: tt.fields.serverPort,
+				logger:     tt.fields.logger,
+			}}
+			c.handleStorageResponse(tt.args.proto)
+		})
+	}
+}
+
+func TestClient_dispatchFragments(t *testing.T) {
+	//a port nothing listens on, so every upload to it fails
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	closed := listener.Addr().(*net.TCPAddr)
+	listener.Close()
+	node := proto3.StorageNodeInfo{NodeId: "node1", Host: "127.0.0.1", Port: strconv.Itoa(closed.Port)}
+
+	tests := []struct {
+		name       string
+		fragments  []proto3.FragmentInfo
+		journaled  []string
+		wantFailed []string
+	}{
+		{
+			name: "Test every node fails",
+			fragments: []proto3.FragmentInfo{
+				{FragmentId: "file_0", StorageNodes: []proto3.StorageNodeInfo{node, node}},
+				{FragmentId: "file_1", StorageNodes: []proto3.StorageNodeInfo{node}},
+			},
+			wantFailed: []string{"file_0", "file_1"},
+		},
+		{
+			name:       "Test fragment without nodes",
+			fragments:  []proto3.FragmentInfo{{FragmentId: "file_0"}},
+			wantFailed: []string{"file_0"},
+		},
+		{
+			name: "Test journaled fragments are skipped",
+			fragments: []proto3.FragmentInfo{
+				{FragmentId: "file_0", StorageNodes: []proto3.StorageNodeInfo{node}},
+				{FragmentId: "file_1", StorageNodes: []proto3.StorageNodeInfo{node}},
+			},
+			journaled:  []string{"file_0"},
+			wantFailed: []string{"file_1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &transfer{Client: NewClient("", zap.NewNop()), ctx: context.Background()}
+			c.SetConcurrency(2, 1)
+			c.journal = openJournal(t.TempDir(), "file", "put")
+			for _, frag := range tt.journaled {
+				c.journal.markDone(frag, "")
+			}
+
+			failed := c.dispatchFragments(tt.fragments)
+			got := make([]string, 0, len(failed))
+			for frag, err := range failed {
+				if err == nil {
+					t.Errorf("fragment %s failed without an error", frag)
+				}
+				got = append(got, frag)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.wantFailed) {
+				t.Errorf("dispatchFragments() failed = %v, want %v", got, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package dfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"src/erasure"
+	proto3 "src/proto/controller_client"
+	"sync"
+)
+
+// DispatchShards uploads an erasure coded file one group at a time, so only the parity shards of a single
+// group are on disk at once. The shards of a group are uploaded in parallel. It returns the shards that could
+// not be stored.
+func (t *transfer) DispatchShards(plan *proto3.PlanResponse) (failed map[string]error) {
+
+	layout := *plan.Erasure
+	t.file.SetShardLayout(layout, plan.FragmentLayout)
+
+	shards := make(map[string]proto3.FragmentInfo)
+	for _, frag := range plan.FragmentLayout {
+		shards[frag.FragmentId] = frag
+	}
+
+	failed = make(map[string]error)
+	for group := 0; group < layout.Groups(); group++ {
+
+		groupShards := make([]proto3.FragmentInfo, 0, layout.Shards())
+		pending := false
+		for shard := 0; shard < layout.Shards(); shard++ {
+			name := layout.Block(group, shard)
+			frag, ok := shards[name]
+			if !ok {
+				frag = proto3.FragmentInfo{FragmentId: name, Index: layout.Index(group, shard)}
+			}
+			groupShards = append(groupShards, frag)
+			if !t.journal.done(name) {
+				pending = true
+			}
+		}
+		if !pending {
+			t.logger.Sugar().Infof("Group %d was stored by an earlier attempt", group)
+			continue
+		}
+
+		err := t.file.EncodeGroup(layout, group)
+		if err != nil {
+			t.logger.Sugar().Errorf("Error encoding group %d: %s", group, err)
+			t.file.RemoveParity(layout, group)
+			for _, frag := range groupShards {
+				failed[frag.FragmentId] = fmt.Errorf("encoding group %d: %w", group, err)
+			}
+			return
+		}
+
+		for frag, errD := range t.dispatchFragments(groupShards) {
+			failed[frag] = errD
+		}
+
+		t.file.RemoveParity(layout, group)
+	}
+
+	t.logger.Info("All shards dispatched")
+	return
+}
+
+// FetchShards downloads the data shards of an erasure coded file. A group that is missing some of them has its
+// parity shards fetched as well, and the missing data shards are rebuilt before the file is put back together.
+func (t *transfer) FetchShards(res *proto3.FragLayoutResponse) (err error) {
+
+	layout := *res.Erasure
+	fileName := t.file.FileName()
+
+	err = t.fetchGroups(res, 0, layout.Groups())
+	if err != nil {
+		return fmt.Errorf("rebuilding %s: %w", fileName, err)
+	}
+
+	err = t.CombineShards(t.file.Dir(), fileName, layout)
+	if err != nil {
+		t.logger.Error("Error combining shards")
+		return
+	}
+	t.journal.remove()
+
+	t.logger.Info("File fetched and combined")
+	return
+}
+
+// fetchGroups downloads the data shards of groups [first, last), and rebuilds the ones that are missing from
+// the parity shards of their group.
+func (t *transfer) fetchGroups(res *proto3.FragLayoutResponse, first int, last int) (err error) {
+
+	layout := *res.Erasure
+
+	located := make(map[string]proto3.FragmentInfo)
+	for _, frag := range res.FragmentLayout {
+		located[frag.FragmentId] = frag
+	}
+
+	data := make([]string, 0)
+	for group := first; group < last; group++ {
+		for shard := 0; shard < layout.DataShards; shard++ {
+			data = append(data, layout.Block(group, shard))
+		}
+	}
+	fetched := t.fetchShards(data, located)
+
+	for group := first; group < last; group++ {
+
+		missing := make([]int, 0)
+		for shard := 0; shard < layout.DataShards; shard++ {
+			if !fetched[layout.Block(group, shard)] {
+				missing = append(missing, shard)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		t.logger.Sugar().Warnf("Group %d is missing %d data shards, rebuilding them", group, len(missing))
+		parity := make([]string, 0)
+		for shard := layout.DataShards; shard < layout.Shards(); shard++ {
+			parity = append(parity, layout.Block(group, shard))
+		}
+		t.fetchShards(parity, located)
+
+		err = t.file.ReconstructShards(layout, group, missing)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// fetchShards downloads shards concurrently and reports which ones arrived. A shard that could not be fetched
+// is removed, so a copy left behind by an earlier download is never mistaken for it.
+func (t *transfer) fetchShards(shards []string, located map[string]proto3.FragmentInfo) (fetched map[string]bool) {
+
+	maxGoroutines := 10
+	sem := make(chan struct{}, maxGoroutines)
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	fetched = make(map[string]bool)
+
+	for _, shard := range shards {
+		frag, ok := located[shard]
+		if !ok {
+			t.logger.Sugar().Warnf("No node holds %s", shard)
+			os.Remove(filepath.Join(t.file.Dir(), shard))
+			continue
+		}
+
+		wg.Add(1)
+		go func(f proto3.FragmentInfo) {
+			sem <- struct{}{}
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if t.journal.verified(t.file.Dir(), f.FragmentId) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				fetched[f.FragmentId] = true
+				return
+			}
+
+			err := t.fetchAndRecord(f)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				os.Remove(filepath.Join(t.file.Dir(), f.FragmentId))
+				return
+			}
+			fetched[f.FragmentId] = true
+		}(frag)
+	}
+
+	wg.Wait()
+	return
+}
+
+// CombineShards writes the data shards of every group to the output file in order and removes all the shards.
+func (t *transfer) CombineShards(dir string, file string, layout erasure.Layout) (err error) {
+
+	outFile, err := os.Create(filepath.Join(dir, file))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %v", err)
+	}
+	defer outFile.Close()
+
+	for group := 0; group < layout.Groups(); group++ {
+		for shard := 0; shard < layout.DataShards; shard++ {
+
+			shardFile, err := os.Open(filepath.Join(dir, layout.Block(group, shard)))
+			if err != nil {
+				return fmt.Errorf("error opening shard: %v", err)
+			}
+
+			_, err = io.Copy(outFile, shardFile)
+			shardFile.Close()
+			if err != nil {
+				return fmt.Errorf("error copying shard to output file: %v", err)
+			}
+		}
+
+		for shard := 0; shard < layout.Shards(); shard++ {
+			os.Remove(filepath.Join(dir, layout.Block(group, shard)))
+		}
+	}
+
+	return outFile.Close()
+}
@@ -0,0 +1,81 @@
+package dfs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNotFound           = errors.New("file not found")
+	ErrExists             = errors.New("file already exists")
+	ErrInvalidRange       = errors.New("invalid range")
+	ErrUnexpectedResponse = errors.New("unexpected response from the Controller")
+)
+
+// StatusError is a request the Controller turned down, with the status code it answered. errors.Is matches it
+// against ErrNotFound, ErrExists and ErrInvalidRange.
+type StatusError struct {
+	Op     string
+	Status string
+}
+
+func (e *StatusError) Error() string {
+	return e.Op + ": " + e.Status
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch e.Status {
+	case "FILE_NOT_FOUND":
+		return target == ErrNotFound
+	case "FILE_ALREADY_EXISTS":
+		return target == ErrExists
+	case "INVALID_RANGE":
+		return target == ErrInvalidRange
+	}
+	return false
+}
+
+// DispatchError lists the fragments a PUT could not store on any of their nodes. The file is not committed.
+type DispatchError struct {
+	Failed map[string]error
+}
+
+func (e *DispatchError) Error() string {
+	return fmt.Sprintf("%d fragments could not be stored, the file is not committed", len(e.Failed))
+}
+
+// Fragments returns the fragments that failed in order.
+func (e *DispatchError) Fragments() []string {
+
+	fragments := make([]string, 0, len(e.Failed))
+	for frag := range e.Failed {
+		fragments = append(fragments, frag)
+	}
+	sort.Strings(fragments)
+	return fragments
+}
+
+// Replica is a copy of a fragment on a Storage Node.
+type Replica struct {
+	FragmentId string
+	NodeId     string
+	Addr       string
+}
+
+// DeleteError lists the replicas a DELETE could not remove. The Controller keeps the file as deleting and
+// removes them later.
+type DeleteError struct {
+	Status string
+	Failed []Replica
+}
+
+func (e *DeleteError) Error() string {
+
+	replicas := make([]string, 0, len(e.Failed))
+	for _, replica := range e.Failed {
+		replicas = append(replicas, replica.FragmentId+" on "+replica.NodeId)
+	}
+	return fmt.Sprintf("delete: %s, %d replicas were not removed (%s)", e.Status, len(e.Failed), strings.Join(replicas, ", "))
+}
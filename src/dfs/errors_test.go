@@ -0,0 +1,29 @@
+package dfs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStatusError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		target error
+		want   bool
+	}{
+		{name: "Test not found", status: "FILE_NOT_FOUND", target: ErrNotFound, want: true},
+		{name: "Test exists", status: "FILE_ALREADY_EXISTS", target: ErrExists, want: true},
+		{name: "Test invalid range", status: "INVALID_RANGE", target: ErrInvalidRange, want: true},
+		{name: "Test other status", status: "NOT_ENOUGH_NODES", target: ErrNotFound, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &StatusError{Op: "get", Status: tt.status})
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package dfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	proto3 "src/proto/controller_client"
+	"sync"
+)
+
+// FetchFile downloads the fragments of a file into file_dir and combines them. Fragments an interrupted
+// download already fetched are kept.
+func (t *transfer) FetchFile(res *proto3.FragLayoutResponse) (err error) {
+	t.logger.Info("Fetching file")
+	if res.Erasure != nil {
+		return t.FetchShards(res)
+	}
+	fragments := res.FragmentLayout
+	if len(fragments) == 0 {
+		return ErrNotFound
+	}
+
+	maxGoroutines := 10 // limit to 10 goroutines
+	sem := make(chan struct{}, maxGoroutines)
+	errs := make([]error, len(fragments))
+
+	var wg sync.WaitGroup
+	wg.Add(len(fragments))
+
+	for i, frag := range fragments {
+		go func(i int, f proto3.FragmentInfo) {
+			sem <- struct{}{} // acquire semaphore
+			defer func() {
+				<-sem // release semaphore
+				wg.Done()
+			}()
+			if t.journal.verified(t.file.Dir(), f.FragmentId) {
+				t.logger.Sugar().Infof("Fragment %s was fetched by an earlier attempt", f.FragmentId)
+				return
+			}
+			errs[i] = t.fetchAndRecord(f)
+
+		}(i, frag)
+	}
+
+	wg.Wait()
+	for i, frag := range fragments {
+		if errs[i] != nil {
+			return fmt.Errorf("fetching %s: %w", frag.FragmentId, errs[i])
+		}
+	}
+	t.logger.Info("All fragments fetched")
+	t.logger.Info("Combining fragments")
+
+	//the fragments are stored under opaque block ids, only their index says where they go
+	placed, _, err := sections(fragments)
+	if err != nil {
+		return
+	}
+	if placed[0].frag.Index != 0 {
+		return fmt.Errorf("fragment 0 was not located")
+	}
+	blocks := make([]string, len(placed))
+	for i, s := range placed {
+		blocks[i] = s.frag.FragmentId
+	}
+
+	err = t.CombineFragments(t.file.Dir(), t.file.FileName(), blocks)
+	if err != nil {
+		t.logger.Error("Error combining fragments")
+		return
+	}
+	t.journal.remove()
+
+	t.logger.Info("File fetched and combined")
+	return
+}
+
+// CombineFragments writes the fragments stored under blocks in dir, in that order, into file.
+func (t *transfer) CombineFragments(dir string, file string, blocks []string) (err error) {
+	// Create the output file
+	outFile, err := os.Create(filepath.Join(dir, file))
+	//outFile, err := os.Create(file)
+	if err != nil {
+		t.logger.Error("error creating output file")
+		return fmt.Errorf("error creating output file: %v", err)
+	}
+	defer func(outFile *os.File) {
+		err = outFile.Close()
+		if err != nil {
+			t.logger.Error("error closing output file")
+			return
+		}
+	}(outFile)
+
+	// Read in the fragments and write them to the output file
+	for i, fragFileName := range blocks {
+		t.logger.Sugar().Infof("Opening fragment file %s", fragFileName)
+
+		// Open the fragment file
+		fragFile, err := os.Open(filepath.Join(dir, fragFileName))
+		if err != nil {
+			err = fragFile.Close()
+			if err != nil {
+				t.logger.Error("error closing fragment file")
+				return err
+			}
+			t.logger.Error("error opening fragment file")
+			return fmt.Errorf("error opening fragment file %s: %v", fragFileName, err)
+		}
+
+		// Copy the fragment file to the output file
+		t.logger.Sugar().Infof("Appending fragment %d to output file", i)
+		_, err = io.Copy(outFile, fragFile)
+		if err != nil {
+			err := fragFile.Close()
+			if err != nil {
+				t.logger.Error("error closing fragment file")
+				return err
+			}
+			return fmt.Errorf("error copying fragment %d to output file: %v", i, err)
+		}
+		err = fragFile.Close()
+		if err != nil {
+			t.logger.Error("error closing fragment file")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchAndRecord fetches a fragment and records the checksum of the local copy in the journal.
+func (t *transfer) fetchAndRecord(frag proto3.FragmentInfo) (err error) {
+
+	err = t.FetchFragment(frag)
+	if err != nil {
+		return
+	}
+
+	sum, err := checksum(filepath.Join(t.file.Dir(), frag.FragmentId))
+	if err != nil {
+		return
+	}
+	if errJ := t.journal.markDone(frag.FragmentId, sum); errJ != nil {
+		//the fragment is fine, it is only fetched again if the download is repeated
+		t.logger.Sugar().Warnf("Could not record %s in the journal: %s", frag.FragmentId, errJ)
+	}
+	return
+}
+
+func (t *transfer) FetchFragment(frag proto3.FragmentInfo) (err error) {
+	if err = t.ctx.Err(); err != nil {
+		return
+	}
+	nodes := frag.StorageNodes
+
+	err = fmt.Errorf("no node holds %s", frag.FragmentId)
+	for _, node := range nodes {
+		err = t.FetchFromNode(frag, node)
+		if err != nil {
+			t.logger.Sugar().Error("There was an error fetching from node: ", node.NodeId)
+			continue
+		} else {
+			break
+		}
+	}
+
+	return
+}
+
+func (t *transfer) FetchFromNode(frag proto3.FragmentInfo, node proto3.StorageNodeInfo) (err error) {
+	return t.fetchFromNode(frag, node, nil)
+}
@@ -0,0 +1,166 @@
+package dfs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// journal records the progress of a PUT or GET in file_dir, so running the same transfer again only moves the
+// fragments that are missing or failed verification.
+type journal struct {
+	path  string
+	mutex sync.Mutex
+
+	//the upload id the Controller gave the file
+	SessionId string `json:"session_id"`
+	//the source of a PUT, the journal is dropped if it changed
+	FileSize int64     `json:"file_size,omitempty"`
+	ModTime  time.Time `json:"mod_time,omitempty"`
+	//fragment -> sha256 of the local copy, empty for an upload
+	Done map[string]string `json:"done"`
+}
+
+func journalPath(dir string, file string, kind string) string {
+	//the journal of a file in a directory of the DFS sits next to it
+	return filepath.Join(dir, filepath.Dir(file), "."+filepath.Base(file)+"."+kind+".journal")
+}
+
+// openJournal loads the journal of a transfer, or starts an empty one if there is none.
+func openJournal(dir string, file string, kind string) (j *journal) {
+
+	j = &journal{path: journalPath(dir, file, kind), Done: make(map[string]string)}
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		return
+	}
+	if json.Unmarshal(data, j) != nil || j.Done == nil {
+		j.SessionId = ""
+		j.Done = make(map[string]string)
+	}
+	return
+}
+
+// matches reports whether the journal was written for the source file as it is now.
+func (j *journal) matches(info os.FileInfo) bool {
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.SessionId != "" && j.FileSize == info.Size() && j.ModTime.Equal(info.ModTime())
+}
+
+// reset forgets the progress made on an earlier version of the source file.
+func (j *journal) reset(info os.FileInfo) {
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.SessionId = ""
+	j.FileSize, j.ModTime = info.Size(), info.ModTime()
+	j.Done = make(map[string]string)
+}
+
+// begin starts recording sessionId and reports whether the journal already was recording it. The progress of
+// another session is forgotten. Like the other methods that track progress, it does nothing on a nil journal.
+func (j *journal) begin(sessionId string) (resumed bool) {
+
+	if j == nil {
+		return false
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.SessionId != sessionId {
+		j.SessionId = sessionId
+		j.Done = make(map[string]string)
+		return false
+	}
+	return sessionId != ""
+}
+
+func (j *journal) session() string {
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.SessionId
+}
+
+func (j *journal) done(fragment string) bool {
+
+	if j == nil {
+		return false
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	_, ok := j.Done[fragment]
+	return ok
+}
+
+// verified reports whether the local copy of a downloaded fragment is still the one that was fetched.
+func (j *journal) verified(dir string, fragment string) bool {
+
+	if j == nil {
+		return false
+	}
+	j.mutex.Lock()
+	sum, ok := j.Done[fragment]
+	j.mutex.Unlock()
+	if !ok {
+		return false
+	}
+
+	local, err := checksum(filepath.Join(dir, fragment))
+	return err == nil && local == sum
+}
+
+// markDone records a transferred fragment and saves the journal.
+func (j *journal) markDone(fragment string, sum string) (err error) {
+
+	if j == nil {
+		return
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.Done[fragment] = sum
+	data, err := json.Marshal(j)
+	if err != nil {
+		return
+	}
+
+	//written aside and renamed, so a crash never leaves a torn journal
+	tmp := j.path + ".tmp"
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// remove deletes the journal once the transfer completed.
+func (j *journal) remove() {
+	if j != nil {
+		os.Remove(j.path)
+	}
+}
+
+func checksum(path string) (sum string, err error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,53 @@
+package dfs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournal_Resume(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  string
+		corrupt  bool
+		wantDone bool
+	}{
+		{name: "Test same session", session: "upload", wantDone: true},
+		{name: "Test another session", session: "other", wantDone: false},
+		{name: "Test fragment changed on disk", session: "upload", corrupt: true, wantDone: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			os.WriteFile(filepath.Join(dir, "file"), []byte("source"), 0644)
+			os.WriteFile(filepath.Join(dir, "file_0"), []byte("fragment"), 0644)
+			info, _ := os.Stat(filepath.Join(dir, "file"))
+
+			j := openJournal(dir, "file", "get")
+			j.reset(info)
+			j.begin("upload")
+			sum, _ := checksum(filepath.Join(dir, "file_0"))
+			if err := j.markDone("file_0", sum); err != nil {
+				t.Fatalf("markDone() error = %v", err)
+			}
+			if tt.corrupt {
+				os.WriteFile(filepath.Join(dir, "file_0"), []byte("fragmenT"), 0644)
+			}
+
+			reopened := openJournal(dir, "file", "get")
+			if !reopened.matches(info) {
+				t.Errorf("matches() = false for an unchanged source file")
+			}
+			reopened.begin(tt.session)
+			if got := reopened.verified(dir, "file_0"); got != tt.wantDone {
+				t.Errorf("verified() = %v, want %v", got, tt.wantDone)
+			}
+
+			reopened.remove()
+			if _, err := os.Stat(journalPath(dir, "file", "get")); !os.IsNotExist(err) {
+				t.Errorf("journal not removed")
+			}
+		})
+	}
+}
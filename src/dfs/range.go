@@ -0,0 +1,95 @@
+package dfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"src/erasure"
+	proto3 "src/proto/controller_client"
+)
+
+// Range is the part of a file a GET reads: bytes [Offset, Offset+Length). A Length of 0 reads to the end, and
+// the zero Range reads the whole file.
+type Range struct {
+	Offset int64
+	Length int64
+}
+
+// rangeFileName is where a range read is written: <file>.<start>-<end> in file_dir.
+func rangeFileName(file string, offset int64, length int64) string {
+	return fmt.Sprintf("%s.%d-%d", file, offset, offset+length)
+}
+
+// localName is the file a fetched fragment is written to. Part of a fragment is kept apart from the whole of it.
+func localName(frag proto3.FragmentInfo) string {
+	if frag.Length > 0 {
+		return rangeFileName(frag.FragmentId, frag.Offset, frag.Length)
+	}
+	return frag.FragmentId
+}
+
+// copyGroups writes bytes [start, end) of an erasure coded file to out. The groups holding them are fetched
+// one at a time, and their shards removed once copied, so only a single group is ever kept in file_dir.
+func (t *transfer) copyGroups(res *proto3.FragLayoutResponse, out io.Writer, start int64, end int64) (err error) {
+
+	if start >= end {
+		return
+	}
+	layout := *res.Erasure
+	first, last := int(start/layout.ChunkSize), int((end-1)/layout.ChunkSize)+1
+
+	for group := first; group < last; group++ {
+		err = t.fetchGroups(res, group, group+1)
+		if err == nil {
+			err = t.copyGroup(out, layout, group, start, end)
+		}
+		for shard := 0; shard < layout.Shards(); shard++ {
+			os.Remove(filepath.Join(t.file.Dir(), layout.Block(group, shard)))
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// copyGroup writes the bytes of a group that fall in [start, end) to out, from its data shards in file_dir.
+func (t *transfer) copyGroup(out io.Writer, layout erasure.Layout, group int, start int64, end int64) (err error) {
+
+	for shard := 0; shard < layout.DataShards; shard++ {
+		shardStart := layout.ShardOffset(group, shard)
+		from, to := shardStart, shardStart+layout.ShardLength(group, shard)
+		if from < start {
+			from = start
+		}
+		if to > end {
+			to = end
+		}
+		if from >= to {
+			continue
+		}
+
+		err = appendFile(out, filepath.Join(t.file.Dir(), layout.Block(group, shard)), from-shardStart, to-from)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// appendFile copies length bytes of the file at path, starting at offset, to out.
+func appendFile(out io.Writer, path string, offset int64, length int64) (err error) {
+
+	in, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	n, err := io.Copy(out, io.NewSectionReader(in, offset, length))
+	if err == nil && n != length {
+		err = fmt.Errorf("%s holds %d of the %d bytes expected", path, n, length)
+	}
+	return
+}
@@ -0,0 +1,254 @@
+package dfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"sort"
+	"src/file"
+	messagesStorage "src/messages/client_storage"
+	proto3Storage "src/proto/client_storage"
+	proto3 "src/proto/controller_client"
+	"sync"
+)
+
+// STREAM_WINDOW is how many fragments a GET to a writer holds in memory: the one being written and those
+// fetched ahead of it.
+const STREAM_WINDOW = 4
+
+// section is the place of a fragment in the output.
+type section struct {
+	frag   proto3.FragmentInfo
+	at     int64
+	length int64
+}
+
+// sections puts the fragments of a replicated file in order, each one after the last. A fragment takes up the
+// part a range read needs of it, or its size; total is 0 if a size is unknown.
+func sections(fragments []proto3.FragmentInfo) (placed []section, total int64, err error) {
+
+	sorted := append([]proto3.FragmentInfo{}, fragments...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Index < sorted[j].Index
+	})
+
+	sized := true
+	for i, frag := range sorted {
+		if frag.Index != sorted[0].Index+i {
+			return nil, 0, fmt.Errorf("fragment %d was not located", sorted[0].Index+i)
+		}
+
+		length := frag.Size
+		if frag.Length > 0 {
+			length = frag.Length
+		}
+		sized = sized && length > 0
+		placed = append(placed, section{frag: frag, at: total, length: length})
+		total += length
+	}
+	if !sized {
+		total = 0
+	}
+	return
+}
+
+// writeOutputFile writes the output into <path>.part, and only moves it into place once all of it was verified.
+func (t *transfer) writeOutputFile(res *proto3.FragLayoutResponse, path string) (err error) {
+
+	out, err := os.Create(path + ".part")
+	if err != nil {
+		return
+	}
+	defer func() {
+		out.Close()
+		if err != nil {
+			os.Remove(out.Name())
+		}
+	}()
+
+	placed, total, err := t.outputSections(res)
+	switch {
+	case err != nil:
+		return
+	case res.Erasure == nil && total > 0:
+		err = t.writeSections(out, placed)
+	default:
+		//without the size of every fragment they can only be written one after the other
+		err = t.writeOutput(res, out)
+	}
+	if err != nil {
+		return
+	}
+
+	err = out.Close()
+	if err != nil {
+		return
+	}
+	return os.Rename(out.Name(), path)
+}
+
+// writeOutput writes the output to out in order.
+func (t *transfer) writeOutput(res *proto3.FragLayoutResponse, out io.Writer) (err error) {
+
+	if res.Erasure != nil {
+		start, end := int64(0), res.Erasure.FileSize
+		if res.RangeLength > 0 {
+			start, end = res.RangeOffset, res.RangeOffset+res.RangeLength
+		}
+		return t.copyGroups(res, out, start, end)
+	}
+
+	placed, _, err := t.outputSections(res)
+	if err != nil {
+		return
+	}
+	return t.streamSections(out, placed)
+}
+
+// outputSections places the fragments of a replicated file in the output. Erasure coded files have none.
+func (t *transfer) outputSections(res *proto3.FragLayoutResponse) (placed []section, total int64, err error) {
+
+	if res.Erasure != nil {
+		return
+	}
+	placed, total, err = sections(res.FragmentLayout)
+	if err != nil {
+		return
+	}
+	if res.RangeLength > 0 && total != res.RangeLength {
+		return nil, 0, fmt.Errorf("the fragments located cover %d of %d bytes", total, res.RangeLength)
+	}
+	if res.RangeLength == 0 && len(placed) > 0 && placed[0].frag.Index != 0 {
+		return nil, 0, fmt.Errorf("fragment 0 was not located")
+	}
+	return
+}
+
+// writeSections fetches fragments concurrently, each one straight into its section of out.
+func (t *transfer) writeSections(out io.WriterAt, placed []section) (err error) {
+
+	sem := make(chan struct{}, t.maxConcurrency())
+	errs := make([]error, len(placed))
+	var wg sync.WaitGroup
+	wg.Add(len(placed))
+
+	for i, s := range placed {
+		go func(i int, s section) {
+			sem <- struct{}{}
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = t.fetchInto(s.frag, func() file.Receiver {
+				return file.NewSectionReceiver(out, s.at, s.length)
+			})
+		}(i, s)
+	}
+	wg.Wait()
+
+	for i, s := range placed {
+		if errs[i] != nil {
+			return fmt.Errorf("fetching %s: %w", s.frag.FragmentId, errs[i])
+		}
+	}
+	return
+}
+
+type fetched struct {
+	buffer *file.BufferReceiver
+	err    error
+}
+
+// streamSections writes fragments to out in order. Up to STREAM_WINDOW of them are held in memory, so the
+// next ones are being fetched while one is written.
+func (t *transfer) streamSections(out io.Writer, placed []section) (err error) {
+
+	results := make([]chan fetched, len(placed))
+	for i := range results {
+		results[i] = make(chan fetched, 1)
+	}
+	window := make(chan struct{}, STREAM_WINDOW)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		for i, s := range placed {
+			select {
+			case window <- struct{}{}:
+			case <-stop:
+				return
+			}
+			go func(i int, s section) {
+				var buffer *file.BufferReceiver
+				err := t.fetchInto(s.frag, func() file.Receiver {
+					buffer = file.NewBufferReceiver()
+					return buffer
+				})
+				results[i] <- fetched{buffer: buffer, err: err}
+			}(i, s)
+		}
+	}()
+
+	for i, s := range placed {
+		r := <-results[i]
+		if r.err != nil {
+			return fmt.Errorf("fetching %s: %w", s.frag.FragmentId, r.err)
+		}
+		_, err = out.Write(r.buffer.Bytes())
+		if err != nil {
+			return
+		}
+		<-window
+	}
+	return
+}
+
+// fetchInto fetches a fragment into the receiver newReceiver returns, trying the nodes holding it in turn.
+func (t *transfer) fetchInto(frag proto3.FragmentInfo, newReceiver func() file.Receiver) (err error) {
+
+	if err = t.ctx.Err(); err != nil {
+		return
+	}
+	err = fmt.Errorf("no node holds %s", frag.FragmentId)
+	for _, node := range frag.StorageNodes {
+		err = t.fetchFromNode(frag, node, newReceiver())
+		if err == nil {
+			return
+		}
+		t.logger.Sugar().Errorf("Error fetching %s from node %s: %s", frag.FragmentId, node.NodeId, err)
+	}
+	return
+}
+
+// fetchFromNode fetches a fragment from one node into receiver, or into a file in file_dir if it is nil.
+func (t *transfer) fetchFromNode(frag proto3.FragmentInfo, node proto3.StorageNodeInfo, receiver file.Receiver) (err error) {
+
+	conn, release, err := t.dial(t.ctx, node.Host+":"+node.Port)
+	if err != nil {
+		t.logger.Error("There was an error connecting to the host.")
+		return
+	}
+	defer release()
+
+	msgHandler := messagesStorage.NewMessageHandler(conn)
+	t.setTimeouts(msgHandler)
+	proto := proto3Storage.NewProtoHandler(msgHandler, t.logger, t.file.Dir())
+
+	fileHandler := file.FileHandler{}
+	fileHandler.SetFileName(localName(frag))
+	fileHandler.SetDir(t.file.Dir())
+
+	proto.SetFileHandler(&fileHandler)
+	if receiver != nil {
+		proto.SetReceiver(receiver)
+	}
+	err = proto.HandleFileRangeRequest(frag.FragmentId, frag.Offset, frag.Length)
+	if err != nil {
+		t.logger.Error("There was an error handling the file get request.")
+		return
+	}
+
+	err = t.handleStorageResponse(proto)
+	return
+}
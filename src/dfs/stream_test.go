@@ -0,0 +1,72 @@
+package dfs
+
+import (
+	proto3 "src/proto/controller_client"
+	"testing"
+)
+
+func Test_sections(t *testing.T) {
+	tests := []struct {
+		name      string
+		fragments []proto3.FragmentInfo
+		wantAt    []int64
+		wantTotal int64
+		wantErr   bool
+	}{
+		{
+			name: "Test whole file",
+			fragments: []proto3.FragmentInfo{
+				{FragmentId: "9f", Index: 2, Size: 5},
+				{FragmentId: "a1", Index: 0, Size: 10},
+				{FragmentId: "07", Index: 1, Size: 10},
+			},
+			wantAt:    []int64{0, 10, 20},
+			wantTotal: 25,
+		},
+		{
+			name: "Test range",
+			fragments: []proto3.FragmentInfo{
+				{FragmentId: "9f", Index: 2, Length: 3},
+				{FragmentId: "07", Index: 1, Offset: 8, Length: 2},
+			},
+			wantAt:    []int64{0, 2},
+			wantTotal: 5,
+		},
+		{
+			name: "Test unknown size",
+			fragments: []proto3.FragmentInfo{
+				{FragmentId: "a1", Index: 0, Size: 10},
+				{FragmentId: "07", Index: 1},
+			},
+			wantAt:    []int64{0, 10},
+			wantTotal: 0,
+		},
+		{
+			name: "Test missing fragment",
+			fragments: []proto3.FragmentInfo{
+				{FragmentId: "a1", Index: 0, Size: 10},
+				{FragmentId: "9f", Index: 2, Size: 5},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placed, total, err := sections(tt.fragments)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sections() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if total != tt.wantTotal {
+				t.Errorf("sections() total = %d, want %d", total, tt.wantTotal)
+			}
+			for i, s := range placed {
+				if s.at != tt.wantAt[i] {
+					t.Errorf("sections() %s at %d, want %d", s.frag.FragmentId, s.at, tt.wantAt[i])
+				}
+			}
+		})
+	}
+}
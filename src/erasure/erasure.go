@@ -0,0 +1,201 @@
+package erasure
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/reedsolomon"
+)
+
+// MAX_SHARDS is the largest number of data plus parity shards a group may be coded into.
+const MAX_SHARDS = 32
+
+var ErrTooFewShards = errors.New("too few shards to rebuild the group")
+
+// Layout describes how an erasure coded file is cut into shards. The file is split into groups of
+// ChunkSize bytes, and every group into DataShards data shards plus ParityShards parity shards.
+//
+// Shards are numbered across the whole file, so shard s of group g is stored as block Blocks[g*Shards()+s].
+// A data shard holds its exact range of the file and may be shorter than the others in the last group,
+// parity shards are always ShardSize(g) bytes long.
+type Layout struct {
+	DataShards   int
+	ParityShards int
+	ChunkSize    int64
+	FileSize     int64
+	//the block ids the Controller gave the shards, in the order of their numbers
+	Blocks []string
+}
+
+func (l Layout) Validate() error {
+	if l.DataShards < 1 || l.ParityShards < 1 {
+		return errors.New("erasure coding needs at least 1 data and 1 parity shard")
+	}
+	if l.DataShards+l.ParityShards > MAX_SHARDS {
+		return fmt.Errorf("erasure coding supports at most %d shards per group", MAX_SHARDS)
+	}
+	return nil
+}
+
+// Shards is the number of shards in every group.
+func (l Layout) Shards() int {
+	return l.DataShards + l.ParityShards
+}
+
+func (l Layout) Groups() int {
+	if l.FileSize == 0 || l.ChunkSize == 0 {
+		return 0
+	}
+	return int((l.FileSize + l.ChunkSize - 1) / l.ChunkSize)
+}
+
+// GroupSize is the number of file bytes in a group.
+func (l Layout) GroupSize(group int) int64 {
+	size := l.FileSize - int64(group)*l.ChunkSize
+	if size > l.ChunkSize {
+		size = l.ChunkSize
+	}
+	return size
+}
+
+// ShardSize is the length every shard of the group is coded at.
+func (l Layout) ShardSize(group int) int64 {
+	return (l.GroupSize(group) + int64(l.DataShards) - 1) / int64(l.DataShards)
+}
+
+// ShardOffset is where a data shard starts in the file.
+func (l Layout) ShardOffset(group int, shard int) int64 {
+	return int64(group)*l.ChunkSize + int64(shard)*l.ShardSize(group)
+}
+
+// ShardLength is the number of bytes stored for a shard.
+func (l Layout) ShardLength(group int, shard int) int64 {
+
+	size := l.ShardSize(group)
+	if shard >= l.DataShards {
+		return size
+	}
+
+	left := l.GroupSize(group) - int64(shard)*size
+	if left < 0 {
+		return 0
+	}
+	if left > size {
+		return size
+	}
+	return left
+}
+
+// Index is the number of shard s of group g across the whole file.
+func (l Layout) Index(group int, shard int) int {
+	return group*l.Shards() + shard
+}
+
+// Locate is the inverse of Index.
+func (l Layout) Locate(index int) (group int, shard int) {
+	return index / l.Shards(), index % l.Shards()
+}
+
+// Block is the block id shard s of group g is stored under.
+func (l Layout) Block(group int, shard int) string {
+	return l.Blocks[l.Index(group, shard)]
+}
+
+// Position is the number of the shard stored as block, found is false if it is not one of the file's.
+func (l Layout) Position(block string) (index int, found bool) {
+	for i, id := range l.Blocks {
+		if id == block {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// Encode reads the data shards of a group and writes its parity shards. data holds one reader per data shard,
+// each returning ShardLength bytes, and parity one writer per parity shard.
+func (l Layout) Encode(group int, data []io.Reader, parity []io.Writer) (err error) {
+
+	enc, err := reedsolomon.NewStream(l.DataShards, l.ParityShards)
+	if err != nil {
+		return
+	}
+
+	padded := make([]io.Reader, len(data))
+	for i, r := range data {
+		padded[i] = l.pad(group, i, r)
+	}
+	return enc.Encode(padded, parity)
+}
+
+// Reconstruct rebuilds shards of a group. shards holds a reader for every shard that is present
+// and nil for every one that is not, fill a writer for every shard to rebuild and nil otherwise.
+// At least DataShards shards must be present.
+func (l Layout) Reconstruct(group int, shards []io.Reader, fill []io.Writer) (err error) {
+
+	present := 0
+	valid := make([]io.Reader, len(shards))
+	for i, r := range shards {
+		if r != nil {
+			valid[i] = l.pad(group, i, r)
+			present++
+		}
+	}
+	if present < l.DataShards {
+		return ErrTooFewShards
+	}
+
+	// rebuilt data shards are cut back to the bytes that belong to the file
+	trimmed := make([]io.Writer, len(fill))
+	for i, w := range fill {
+		if w != nil {
+			trimmed[i] = &limitWriter{w: w, left: l.ShardLength(group, i)}
+		}
+	}
+
+	enc, err := reedsolomon.NewStream(l.DataShards, l.ParityShards)
+	if err != nil {
+		return
+	}
+	return enc.Reconstruct(valid, trimmed)
+}
+
+// pad extends a short data shard with the zeros it was coded with.
+func (l Layout) pad(group int, shard int, r io.Reader) io.Reader {
+
+	missing := l.ShardSize(group) - l.ShardLength(group, shard)
+	if missing <= 0 {
+		return r
+	}
+	return io.MultiReader(r, io.LimitReader(zeros{}, missing))
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// limitWriter passes on the first left bytes written to it and drops the rest.
+type limitWriter struct {
+	w    io.Writer
+	left int64
+}
+
+func (lw *limitWriter) Write(p []byte) (n int, err error) {
+
+	n = len(p)
+	if int64(len(p)) > lw.left {
+		p = p[:lw.left]
+	}
+	if len(p) == 0 {
+		return
+	}
+
+	_, err = lw.w.Write(p)
+	lw.left -= int64(len(p))
+	return
+}
@@ -0,0 +1,132 @@
+package erasure
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func TestLayout_ShardLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		group  int
+		want   []int64
+	}{
+		{
+			name:   "Test full group",
+			layout: Layout{DataShards: 4, ParityShards: 2, ChunkSize: 100, FileSize: 250},
+			group:  0,
+			want:   []int64{25, 25, 25, 25, 25, 25},
+		},
+		{
+			name:   "Test short last group",
+			layout: Layout{DataShards: 4, ParityShards: 2, ChunkSize: 100, FileSize: 250},
+			group:  2,
+			want:   []int64{13, 13, 13, 11, 13, 13},
+		},
+		{
+			name:   "Test empty data shard",
+			layout: Layout{DataShards: 4, ParityShards: 1, ChunkSize: 100, FileSize: 9},
+			group:  0,
+			want:   []int64{3, 3, 3, 0, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var total int64
+			for s, want := range tt.want {
+				if got := tt.layout.ShardLength(tt.group, s); got != want {
+					t.Errorf("ShardLength(%d, %d) = %d, want %d", tt.group, s, got, want)
+				}
+				if s < tt.layout.DataShards {
+					total += want
+				}
+			}
+			if total != tt.layout.GroupSize(tt.group) {
+				t.Errorf("data shards hold %d bytes, group has %d", total, tt.layout.GroupSize(tt.group))
+			}
+		})
+	}
+}
+
+func TestLayout_Reconstruct(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  Layout
+		lost    []int
+		wantErr bool
+	}{
+		{
+			name:   "Test lost data shard",
+			layout: Layout{DataShards: 4, ParityShards: 2, ChunkSize: 1000, FileSize: 1000},
+			lost:   []int{1},
+		},
+		{
+			name:   "Test lost data and parity shard in a short group",
+			layout: Layout{DataShards: 4, ParityShards: 2, ChunkSize: 1000, FileSize: 997},
+			lost:   []int{3, 5},
+		},
+		{
+			name:   "Test lost empty data shard",
+			layout: Layout{DataShards: 4, ParityShards: 1, ChunkSize: 100, FileSize: 9},
+			lost:   []int{3},
+		},
+		{
+			name:    "Test too many lost shards",
+			layout:  Layout{DataShards: 4, ParityShards: 2, ChunkSize: 1000, FileSize: 1000},
+			lost:    []int{0, 1, 4},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := tt.layout
+			data := make([]byte, l.FileSize)
+			rand.New(rand.NewSource(1)).Read(data)
+
+			shards := make([][]byte, l.Shards())
+			readers := make([]io.Reader, l.DataShards)
+			for s := 0; s < l.DataShards; s++ {
+				start := l.ShardOffset(0, s)
+				shards[s] = data[start : start+l.ShardLength(0, s)]
+				readers[s] = bytes.NewReader(shards[s])
+			}
+			parity := make([]io.Writer, l.ParityShards)
+			for p := range parity {
+				parity[p] = &bytes.Buffer{}
+			}
+			if err := l.Encode(0, readers, parity); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			for p, w := range parity {
+				shards[l.DataShards+p] = w.(*bytes.Buffer).Bytes()
+			}
+
+			valid := make([]io.Reader, l.Shards())
+			fill := make([]io.Writer, l.Shards())
+			for s := range shards {
+				valid[s] = bytes.NewReader(shards[s])
+			}
+			for _, s := range tt.lost {
+				valid[s] = nil
+				fill[s] = &bytes.Buffer{}
+			}
+
+			err := l.Reconstruct(0, valid, fill)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconstruct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for _, s := range tt.lost {
+				if got := fill[s].(*bytes.Buffer).Bytes(); !bytes.Equal(got, shards[s]) {
+					t.Errorf("shard %d rebuilt as %d bytes, want %d", s, len(got), len(shards[s]))
+				}
+			}
+		})
+	}
+}
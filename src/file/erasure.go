@@ -0,0 +1,126 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"src/erasure"
+	proto3 "src/proto/controller_client"
+)
+
+// parityPath is where the client keeps a parity shard while it is being uploaded.
+func (f *FileHandler) parityPath(shardName string) string {
+	return f.dir + "." + shardName + ".parity"
+}
+
+// SetShardLayout registers the shards of an erasure coded file planned by the Controller. Data shards are
+// streamed straight from their range of the file, parity shards from the files written by EncodeGroup.
+func (f *FileHandler) SetShardLayout(layout erasure.Layout, fragments []proto3.FragmentInfo) {
+
+	f.fragmentMap = make(map[string]*Fragment)
+	for _, fragment := range fragments {
+		shard := &Fragment{
+			fragName: fragment.FragmentId,
+			fragSize: fragment.Size,
+			location: make([]string, 0),
+			ranged:   true,
+		}
+
+		group, s := layout.Locate(fragment.Index)
+		if s < layout.DataShards {
+			shard.offset = layout.ShardOffset(group, s)
+		} else {
+			shard.source = f.parityPath(fragment.FragmentId)
+		}
+
+		for _, location := range fragment.StorageNodes {
+			shard.location = append(shard.location, location.Host)
+		}
+		f.fragments = append(f.fragments, shard)
+		f.fragmentMap[shard.fragName] = shard
+	}
+}
+
+// EncodeGroup computes the parity shards of a group and writes them next to the file.
+func (f *FileHandler) EncodeGroup(layout erasure.Layout, group int) (err error) {
+
+	file, err := os.Open(f.dir + f.fileName)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	data := make([]io.Reader, layout.DataShards)
+	for s := range data {
+		data[s] = io.NewSectionReader(file, layout.ShardOffset(group, s), layout.ShardLength(group, s))
+	}
+
+	parity := make([]io.Writer, layout.ParityShards)
+	for p := range parity {
+		out, errC := os.Create(f.parityPath(layout.Block(group, layout.DataShards+p)))
+		if errC != nil {
+			return errC
+		}
+		defer out.Close()
+		parity[p] = out
+	}
+
+	return layout.Encode(group, data, parity)
+}
+
+// RemoveParity deletes the parity shards EncodeGroup wrote for a group.
+func (f *FileHandler) RemoveParity(layout erasure.Layout, group int) {
+	for p := 0; p < layout.ParityShards; p++ {
+		os.Remove(f.parityPath(layout.Block(group, layout.DataShards+p)))
+	}
+}
+
+// ReconstructShards rebuilds the listed shards of a group from the other shards of the group found in the
+// handler's dir, named after the layout's block id. At least DataShards of them must be present.
+func (f *FileHandler) ReconstructShards(layout erasure.Layout, group int, rebuild []int) (err error) {
+
+	shards := make([]io.Reader, layout.Shards())
+	fill := make([]io.Writer, layout.Shards())
+
+	for _, s := range rebuild {
+		out, errC := os.Create(f.dir + layout.Block(group, s) + ".part")
+		if errC != nil {
+			return errC
+		}
+		defer func() {
+			out.Close()
+			os.Remove(out.Name())
+		}()
+		fill[s] = out
+	}
+
+	for s := range shards {
+		if fill[s] != nil {
+			continue
+		}
+		in, errO := os.Open(f.dir + layout.Block(group, s))
+		if errO != nil {
+			continue
+		}
+		defer in.Close()
+		shards[s] = in
+	}
+
+	err = layout.Reconstruct(group, shards, fill)
+	if err != nil {
+		return fmt.Errorf("rebuilding group %d of %s: %w", group, f.fileName, err)
+	}
+
+	for _, s := range rebuild {
+		out := fill[s].(*os.File)
+		err = out.Close()
+		if err != nil {
+			return
+		}
+		err = os.Rename(out.Name(), f.dir+layout.Block(group, s))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
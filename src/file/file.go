@@ -10,7 +10,6 @@ import (
 	"os"
 	"sort"
 	proto3 "src/proto/controller_client"
-	"strconv"
 	"strings"
 )
 
@@ -70,6 +69,12 @@ type Fragment struct {
 	fragmentData []byte
 	fragChecksum [16]byte
 	location     []string
+
+	//shards of an erasure coded file are not where their number puts them: data shards are an
+	//explicit range of the file, parity shards are read from source
+	ranged bool
+	offset int64
+	source string
 }
 
 func (f *Fragment) Location() []string {
@@ -158,17 +163,6 @@ func (f *FileHandler) FillFragmentData(fragId string) []byte {
 
 }
 
-func (f *FileHandler) GetFileName(fragID string) (int, error) {
-
-	lastUnderscore := strings.LastIndex(fragID, "_")
-	if lastUnderscore == -1 {
-		// If there is no underscore, return the whole string
-		return 0, fmt.Errorf("no underscore in fragment name")
-	}
-	// Otherwise, return the substring after the last underscore, excluding the underscore
-	return strconv.Atoi(fragID[lastUnderscore+1:])
-}
-
 func (f *FileHandler) SetFragmentLayout(fragments []proto3.FragmentInfo) {
 
 	f.fragmentMap = make(map[string]*Fragment)
@@ -178,7 +172,7 @@ func (f *FileHandler) SetFragmentLayout(fragments []proto3.FragmentInfo) {
 			fragSize: fragment.Size,
 			location: make([]string, 0),
 		}
-		frag.fragPosition, _ = f.GetFileName(fragment.FragmentId)
+		frag.fragPosition = fragment.Index
 		frag.totalFrags = len(fragments)
 		for _, location := range fragment.StorageNodes {
 			frag.location = append(frag.location, location.Host)
@@ -186,16 +180,9 @@ func (f *FileHandler) SetFragmentLayout(fragments []proto3.FragmentInfo) {
 		f.fragments = append(f.fragments, frag)
 	}
 
-	// Sort the fragments based on their fragment number
+	// Sort the fragments based on their position in the file
 	sort.Slice(f.fragments, func(i, j int) bool {
-		f1 := f.fragments[i]
-		f2 := f.fragments[j]
-
-		// Extract the fragment numbers from the fragment names
-		f1Num, _ := strconv.Atoi(strings.Split(f1.fragName, "_")[1])
-		f2Num, _ := strconv.Atoi(strings.Split(f2.fragName, "_")[1])
-
-		return f1Num < f2Num
+		return f.fragments[i].fragPosition < f.fragments[j].fragPosition
 	})
 
 	for _, fragment := range f.fragments {
@@ -382,6 +369,25 @@ func (f *FileHandler) WriteFile() (err error) {
 	return
 }
 
+// DeleteFile removes the file and its .checksum sidecar from disk.
+// A file that is already gone is not treated as an error.
+func (f *FileHandler) DeleteFile() (err error) {
+
+	path := f.dir + f.fileName
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	err = os.Remove(path + ".checksum")
+	if err != nil && os.IsNotExist(err) {
+		err = nil
+	}
+
+	return
+}
+
 func (f *FileHandler) ChecksumOnDisk() (err error) {
 
 	//write the checksum to a file on disk. File name will be the checksum
@@ -0,0 +1,150 @@
+package file
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// Receiver takes the frames of a streamed file as they arrive. Commit is called with the checksum carried by
+// the last frame, and Abort when the transfer fails part way.
+type Receiver interface {
+	WriteChunk(chunk *Chunk) error
+	Commit(checksum []byte) error
+	Abort()
+}
+
+// frameCheck verifies that every frame continues where the last one ended and keeps the running crc32 and md5
+// of the bytes received so far.
+type frameCheck struct {
+	written int64
+	crc     uint32
+	md5     hash.Hash
+}
+
+func newFrameCheck() frameCheck {
+	return frameCheck{md5: md5.New()}
+}
+
+// check returns the running crc32 the frame leads to, or an error if it is out of order or corrupted.
+func (c *frameCheck) check(chunk *Chunk) (crc uint32, err error) {
+
+	if chunk.Offset != c.written {
+		return 0, fmt.Errorf("%w: expected offset %d, got %d", ErrChunkOutOfOrder, c.written, chunk.Offset)
+	}
+
+	crc = crc32.Update(c.crc, crc32.IEEETable, chunk.Data)
+	if crc != chunk.RunningCRC32 {
+		return 0, fmt.Errorf("%w at offset %d", ErrChunkCorrupted, chunk.Offset)
+	}
+	return
+}
+
+// add records a frame once it was written.
+func (c *frameCheck) add(data []byte, crc uint32) {
+	c.md5.Write(data)
+	c.crc = crc
+	c.written += int64(len(data))
+}
+
+func (c *frameCheck) matches(checksum []byte) bool {
+	return bytes.Equal(c.md5.Sum(nil), checksum)
+}
+
+// SectionReceiver writes a streamed fragment straight into its place in a larger file, length bytes from at.
+// Nothing marks the bytes as good: whoever owns the file only keeps it once every section committed.
+type SectionReceiver struct {
+	frameCheck
+	out    io.WriterAt
+	at     int64
+	length int64
+}
+
+func NewSectionReceiver(out io.WriterAt, at int64, length int64) *SectionReceiver {
+	return &SectionReceiver{frameCheck: newFrameCheck(), out: out, at: at, length: length}
+}
+
+func (s *SectionReceiver) WriteChunk(chunk *Chunk) (err error) {
+
+	crc, err := s.check(chunk)
+	if err != nil {
+		return
+	}
+	if s.written+int64(len(chunk.Data)) > s.length {
+		return fmt.Errorf("fragment runs past the %d bytes of its section", s.length)
+	}
+
+	_, err = s.out.WriteAt(chunk.Data, s.at+chunk.Offset)
+	if err != nil {
+		return
+	}
+
+	s.add(chunk.Data, crc)
+	return
+}
+
+// Commit verifies the md5 checksum of the section and that all of it was written.
+func (s *SectionReceiver) Commit(checksum []byte) error {
+
+	if !s.matches(checksum) {
+		return ErrChecksumMismatch
+	}
+	if s.written != s.length {
+		return fmt.Errorf("received %d of the %d bytes of the section", s.written, s.length)
+	}
+	return nil
+}
+
+// Abort leaves the section as it is, it is written over when the fragment is fetched again.
+func (s *SectionReceiver) Abort() {
+	s.frameCheck = newFrameCheck()
+}
+
+// BufferReceiver holds a streamed fragment in memory until its checksum matched, for output that cannot be
+// taken back once written, like stdout.
+type BufferReceiver struct {
+	frameCheck
+	buffer    bytes.Buffer
+	committed bool
+}
+
+func NewBufferReceiver() *BufferReceiver {
+	return &BufferReceiver{frameCheck: newFrameCheck()}
+}
+
+func (b *BufferReceiver) WriteChunk(chunk *Chunk) (err error) {
+
+	crc, err := b.check(chunk)
+	if err != nil {
+		return
+	}
+
+	b.buffer.Write(chunk.Data)
+	b.add(chunk.Data, crc)
+	return
+}
+
+func (b *BufferReceiver) Commit(checksum []byte) error {
+
+	if !b.matches(checksum) {
+		return ErrChecksumMismatch
+	}
+	b.committed = true
+	return nil
+}
+
+func (b *BufferReceiver) Abort() {
+	b.buffer.Reset()
+	b.frameCheck = newFrameCheck()
+}
+
+// Bytes returns the fragment once it committed, and nil before.
+func (b *BufferReceiver) Bytes() []byte {
+	if !b.committed {
+		return nil
+	}
+	return b.buffer.Bytes()
+}
@@ -0,0 +1,101 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestSectionReceiver(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		length  int64
+		corrupt func(chunk *Chunk)
+		wantErr error
+	}{
+		{
+			name:   "Test section",
+			size:   FRAME_SIZE + 10,
+			length: FRAME_SIZE + 10,
+		},
+		{
+			name:    "Test wrong checksum",
+			size:    10,
+			length:  10,
+			corrupt: func(chunk *Chunk) { chunk.Checksum = make([]byte, 16) },
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "Test fragment shorter than its section",
+			size:    10,
+			length:  20,
+			wantErr: errors.New("short"),
+		},
+		{
+			name:    "Test fragment longer than its section",
+			size:    20,
+			length:  10,
+			wantErr: errors.New("long"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir() + "/"
+
+			data := make([]byte, tt.size)
+			rand.Read(data)
+			os.WriteFile(dir+"file_1", data, 0644)
+
+			out, err := os.Create(dir + "file")
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			defer out.Close()
+
+			//the fragment goes after a first one of 5 bytes
+			sender := NewFileHandler("file_1")
+			sender.SetDir(dir)
+			section := NewSectionReceiver(out, 5, tt.length)
+			buffer := NewBufferReceiver()
+
+			err = sender.StreamFile(func(chunk *Chunk) error {
+				if tt.corrupt != nil && chunk.Last {
+					tt.corrupt(chunk)
+				}
+				for _, r := range []Receiver{section, buffer} {
+					if errW := r.WriteChunk(chunk); errW != nil {
+						return errW
+					}
+				}
+				if !chunk.Last {
+					return nil
+				}
+				if errC := buffer.Commit(chunk.Checksum); errC != nil {
+					return errC
+				}
+				return section.Commit(chunk.Checksum)
+			})
+
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("StreamFile() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == ErrChecksumMismatch && !errors.Is(err, ErrChecksumMismatch) {
+				t.Fatalf("StreamFile() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, _ := os.ReadFile(dir + "file")
+			if !bytes.Equal(got[5:], data) {
+				t.Errorf("the section holds %d bytes, which do not match the %d bytes sent", len(got)-5, len(data))
+			}
+			if !bytes.Equal(buffer.Bytes(), data) {
+				t.Errorf("the buffer holds %d bytes, which do not match the %d bytes sent", len(buffer.Bytes()), len(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package file
+
+// ReplicaStatus is the outcome of writing one replica of a fragment.
+type ReplicaStatus struct {
+	Host    string
+	Success bool
+	Error   string
+}
+
+func NewReplicaStatus(host string, err error) ReplicaStatus {
+	if err != nil {
+		return ReplicaStatus{Host: host, Error: err.Error()}
+	}
+	return ReplicaStatus{Host: host, Success: true}
+}
+
+// Pipeline forwards a streamed fragment to the next node of the replication chain while it is written locally.
+type Pipeline interface {
+	// Open connects to the first reachable node in nodes and hands it the rest of the chain.
+	Open(fileName string, fileSize int64, nodes []string)
+	// Forward passes a chunk that was written locally down the chain.
+	Forward(chunk *Chunk)
+	// Wait returns the status of every replica in the chain, starting with the local one.
+	// A local error aborts the rest of the chain.
+	Wait(local error) []ReplicaStatus
+}
+
+// CountReplicas returns how many replicas were written successfully.
+func CountReplicas(replicas []ReplicaStatus) (count int) {
+	for _, replica := range replicas {
+		if replica.Success {
+			count++
+		}
+	}
+	return
+}
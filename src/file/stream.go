@@ -0,0 +1,246 @@
+package file
+
+import (
+	"bytes"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// FRAME_SIZE is the largest amount of file data carried by a single streamed frame.
+const FRAME_SIZE = 1024 * 1024
+
+var (
+	ErrChunkOutOfOrder  = errors.New("chunk out of order")
+	ErrChunkCorrupted   = errors.New("chunk checksum mismatch")
+	ErrChecksumMismatch = errors.New("file checksum mismatch")
+)
+
+// Chunk is one frame of a streamed file. RunningCRC32 covers every byte sent up to and including this frame,
+// and the last frame carries the md5 checksum of the whole file.
+type Chunk struct {
+	Offset       int64
+	Data         []byte
+	RunningCRC32 uint32
+	Last         bool
+	Checksum     []byte
+}
+
+// PartialFile receives a streamed file into <name>.part and only renames it into place
+// once the whole file has arrived and its checksum matches.
+type PartialFile struct {
+	frameCheck
+	handler *FileHandler
+	file    *os.File
+}
+
+func (f *FileHandler) partPath() string {
+	return f.dir + f.fileName + ".part"
+}
+
+func (f *FileHandler) CreatePartial() (p *PartialFile, err error) {
+
+	file, err := os.Create(f.partPath())
+	if err != nil {
+		return
+	}
+
+	p = &PartialFile{
+		frameCheck: newFrameCheck(),
+		handler:    f,
+		file:       file,
+	}
+	return
+}
+
+func (p *PartialFile) Written() int64 {
+	return p.written
+}
+
+// WriteChunk appends the chunk to disk after checking it continues where the last one ended
+// and that the running crc32 still matches.
+func (p *PartialFile) WriteChunk(chunk *Chunk) (err error) {
+
+	crc, err := p.check(chunk)
+	if err != nil {
+		return
+	}
+
+	_, err = p.file.Write(chunk.Data)
+	if err != nil {
+		return
+	}
+
+	p.add(chunk.Data, crc)
+	return
+}
+
+// Commit verifies the md5 checksum of everything written and moves the file into place.
+// On a mismatch the partial file is removed.
+func (p *PartialFile) Commit(checksum []byte) (err error) {
+
+	err = p.file.Close()
+	if err != nil {
+		os.Remove(p.file.Name())
+		return
+	}
+
+	copy(p.handler.checksum[:], p.md5.Sum(nil))
+	if !bytes.Equal(p.handler.checksum[:], checksum) {
+		os.Remove(p.file.Name())
+		return ErrChecksumMismatch
+	}
+
+	p.handler.fileSize = p.written
+	return os.Rename(p.file.Name(), p.handler.dir+p.handler.fileName)
+}
+
+func (p *PartialFile) Abort() {
+	p.file.Close()
+	os.Remove(p.file.Name())
+}
+
+// StreamRange reads bytes [start, end) of the file on disk one frame at a time and passes every frame to send.
+// Only a single frame is held in memory. The md5 checksum of the range is set on the handler once done.
+func (f *FileHandler) StreamRange(start int64, end int64, send func(chunk *Chunk) error) (err error) {
+
+	checksum, err := f.streamRange(f.dir+f.fileName, start, end, send)
+	if err == nil {
+		f.checksum = checksum
+	}
+	return
+}
+
+// streamRange streams bytes [start, end) of the file at path and returns their md5 checksum. It leaves the
+// handler alone, so several fragments of a file can be streamed at once.
+func (f *FileHandler) streamRange(path string, start int64, end int64, send func(chunk *Chunk) error) (checksum [16]byte, err error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	sum := md5.New()
+	var crc uint32
+	buffer := make([]byte, FRAME_SIZE)
+
+	for offset := start; ; {
+		n := end - offset
+		if n > FRAME_SIZE {
+			n = FRAME_SIZE
+		}
+
+		read, errR := file.ReadAt(buffer[:n], offset)
+		if errR != nil && !(errR == io.EOF && int64(read) == n) {
+			return checksum, errR
+		}
+
+		data := buffer[:read]
+		sum.Write(data)
+		crc = crc32.Update(crc, crc32.IEEETable, data)
+
+		chunk := &Chunk{
+			Offset:       offset - start,
+			Data:         data,
+			RunningCRC32: crc,
+			Last:         offset+n >= end,
+		}
+		if chunk.Last {
+			copy(checksum[:], sum.Sum(nil))
+			chunk.Checksum = checksum[:]
+		}
+
+		err = send(chunk)
+		if err != nil || chunk.Last {
+			return
+		}
+		offset += n
+	}
+}
+
+// StreamFile streams the whole file on disk.
+func (f *FileHandler) StreamFile(send func(chunk *Chunk) error) (err error) {
+
+	info, err := os.Stat(f.dir + f.fileName)
+	if err != nil {
+		return
+	}
+	f.fileSize = info.Size()
+
+	return f.StreamRange(0, f.fileSize, send)
+}
+
+// FragmentRange returns where a fragment starts and ends in the source file.
+func (f *FileHandler) FragmentRange(fragId string) (start int64, end int64, err error) {
+
+	fragment, ok := f.fragmentMap[fragId]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown fragment %s", fragId)
+	}
+	if fragment.ranged {
+		return fragment.offset, fragment.offset + fragment.fragSize, nil
+	}
+
+	info, err := os.Stat(f.dir + f.fileName)
+	if err != nil {
+		return
+	}
+
+	if fragment.fragPosition == fragment.totalFrags-1 {
+		start = info.Size() - fragment.fragSize
+		end = info.Size()
+	} else {
+		start = int64(fragment.fragPosition) * fragment.fragSize
+		end = start + fragment.fragSize
+		if end > info.Size() {
+			end = info.Size()
+		}
+	}
+	return
+}
+
+// StreamFragment streams one fragment of the source file and records its checksum on the fragment.
+func (f *FileHandler) StreamFragment(fragId string, send func(chunk *Chunk) error) (err error) {
+
+	start, end, err := f.FragmentRange(fragId)
+	if err != nil {
+		return
+	}
+
+	path := f.dir + f.fileName
+	if source := f.fragmentMap[fragId].source; source != "" {
+		path = source
+	}
+
+	checksum, err := f.streamRange(path, start, end, send)
+	if err != nil {
+		return
+	}
+
+	f.fragmentMap[fragId].fragChecksum = checksum
+	return
+}
+
+// FindChecksumOnDisk computes the md5 checksum of the file on disk without loading it into memory.
+func (f *FileHandler) FindChecksumOnDisk() (err error) {
+
+	file, err := os.Open(f.dir + f.fileName)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	sum := md5.New()
+	size, err := io.Copy(sum, file)
+	if err != nil {
+		return
+	}
+
+	f.fileSize = size
+	copy(f.checksum[:], sum.Sum(nil))
+	return
+}
@@ -0,0 +1,106 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestFileHandler_StreamFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		corrupt func(chunk *Chunk)
+		wantErr error
+	}{
+		{
+			name: "Test empty file",
+			size: 0,
+		},
+		{
+			name: "Test single frame",
+			size: 1000,
+		},
+		{
+			name: "Test several frames",
+			size: 2*FRAME_SIZE + 3,
+		},
+		{
+			name:    "Test corrupted frame",
+			size:    2 * FRAME_SIZE,
+			corrupt: func(chunk *Chunk) { chunk.Data[0] ^= 0xff },
+			wantErr: ErrChunkCorrupted,
+		},
+		{
+			name:    "Test out of order frame",
+			size:    2 * FRAME_SIZE,
+			corrupt: func(chunk *Chunk) { chunk.Offset++ },
+			wantErr: ErrChunkOutOfOrder,
+		},
+		{
+			name:    "Test wrong file checksum",
+			size:    10,
+			corrupt: func(chunk *Chunk) { chunk.Checksum = make([]byte, 16) },
+			wantErr: ErrChecksumMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := t.TempDir() + "/"
+			dst := t.TempDir() + "/"
+
+			data := make([]byte, tt.size)
+			rand.Read(data)
+			os.WriteFile(src+"file_0", data, 0644)
+
+			sender := NewFileHandler("file_0")
+			sender.SetDir(src)
+			receiver := NewFileHandler("file_0")
+			receiver.SetDir(dst)
+
+			partial, err := receiver.CreatePartial()
+			if err != nil {
+				t.Fatalf("CreatePartial() error = %v", err)
+			}
+
+			frames := 0
+			err = sender.StreamFile(func(chunk *Chunk) error {
+				frames++
+				if len(chunk.Data) > FRAME_SIZE {
+					t.Errorf("frame of %d bytes exceeds FRAME_SIZE", len(chunk.Data))
+				}
+				if tt.corrupt != nil && (chunk.Last || frames == 2) {
+					tt.corrupt(chunk)
+				}
+				if errW := partial.WriteChunk(chunk); errW != nil {
+					return errW
+				}
+				if chunk.Last {
+					return partial.Commit(chunk.Checksum)
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StreamFile() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				partial.Abort()
+				if _, errS := os.Stat(dst + "file_0"); !os.IsNotExist(errS) {
+					t.Errorf("file committed after a failed transfer")
+				}
+				return
+			}
+
+			got, _ := os.ReadFile(dst + "file_0")
+			if !bytes.Equal(got, data) {
+				t.Errorf("received %d bytes, which do not match the %d bytes sent", len(got), len(data))
+			}
+			if _, errS := os.Stat(dst + "file_0.part"); !os.IsNotExist(errS) {
+				t.Errorf("partial file left behind")
+			}
+		})
+	}
+}
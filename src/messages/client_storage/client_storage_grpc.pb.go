@@ -0,0 +1,245 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: client_storage.proto
+
+package client_storage
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// StorageServiceClient is the client API for StorageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StorageServiceClient interface {
+	// The first request carries a streamed FileDataRequest, every following one a FileChunk of the fragment.
+	// Answered once the fragment and the replicas after it in the pipeline were written.
+	PutFragment(ctx context.Context, opts ...grpc.CallOption) (StorageService_PutFragmentClient, error)
+	// Answered with the FileGetResponse header, followed by the requested bytes in FileChunk frames.
+	GetFragment(ctx context.Context, in *FileGetRequest, opts ...grpc.CallOption) (StorageService_GetFragmentClient, error)
+	DeleteFragment(ctx context.Context, in *FileDeleteRequest, opts ...grpc.CallOption) (*FileDeleteResponse, error)
+}
+
+type storageServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStorageServiceClient(cc grpc.ClientConnInterface) StorageServiceClient {
+	return &storageServiceClient{cc}
+}
+
+func (c *storageServiceClient) PutFragment(ctx context.Context, opts ...grpc.CallOption) (StorageService_PutFragmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[0], "/StorageService/PutFragment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServicePutFragmentClient{stream}
+	return x, nil
+}
+
+type StorageService_PutFragmentClient interface {
+	Send(*ClientRequest) error
+	CloseAndRecv() (*FileDataResponse, error)
+	grpc.ClientStream
+}
+
+type storageServicePutFragmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServicePutFragmentClient) Send(m *ClientRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storageServicePutFragmentClient) CloseAndRecv() (*FileDataResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(FileDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) GetFragment(ctx context.Context, in *FileGetRequest, opts ...grpc.CallOption) (StorageService_GetFragmentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[1], "/StorageService/GetFragment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceGetFragmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_GetFragmentClient interface {
+	Recv() (*ServerResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceGetFragmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceGetFragmentClient) Recv() (*ServerResponse, error) {
+	m := new(ServerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) DeleteFragment(ctx context.Context, in *FileDeleteRequest, opts ...grpc.CallOption) (*FileDeleteResponse, error) {
+	out := new(FileDeleteResponse)
+	err := c.cc.Invoke(ctx, "/StorageService/DeleteFragment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StorageServiceServer is the server API for StorageService service.
+// All implementations must embed UnimplementedStorageServiceServer
+// for forward compatibility
+type StorageServiceServer interface {
+	// The first request carries a streamed FileDataRequest, every following one a FileChunk of the fragment.
+	// Answered once the fragment and the replicas after it in the pipeline were written.
+	PutFragment(StorageService_PutFragmentServer) error
+	// Answered with the FileGetResponse header, followed by the requested bytes in FileChunk frames.
+	GetFragment(*FileGetRequest, StorageService_GetFragmentServer) error
+	DeleteFragment(context.Context, *FileDeleteRequest) (*FileDeleteResponse, error)
+	mustEmbedUnimplementedStorageServiceServer()
+}
+
+// UnimplementedStorageServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStorageServiceServer struct {
+}
+
+func (UnimplementedStorageServiceServer) PutFragment(StorageService_PutFragmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutFragment not implemented")
+}
+func (UnimplementedStorageServiceServer) GetFragment(*FileGetRequest, StorageService_GetFragmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetFragment not implemented")
+}
+func (UnimplementedStorageServiceServer) DeleteFragment(context.Context, *FileDeleteRequest) (*FileDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFragment not implemented")
+}
+func (UnimplementedStorageServiceServer) mustEmbedUnimplementedStorageServiceServer() {}
+
+// UnsafeStorageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StorageServiceServer will
+// result in compilation errors.
+type UnsafeStorageServiceServer interface {
+	mustEmbedUnimplementedStorageServiceServer()
+}
+
+func RegisterStorageServiceServer(s grpc.ServiceRegistrar, srv StorageServiceServer) {
+	s.RegisterService(&StorageService_ServiceDesc, srv)
+}
+
+func _StorageService_PutFragment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StorageServiceServer).PutFragment(&storageServicePutFragmentServer{stream})
+}
+
+type StorageService_PutFragmentServer interface {
+	SendAndClose(*FileDataResponse) error
+	Recv() (*ClientRequest, error)
+	grpc.ServerStream
+}
+
+type storageServicePutFragmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServicePutFragmentServer) SendAndClose(m *FileDataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storageServicePutFragmentServer) Recv() (*ClientRequest, error) {
+	m := new(ClientRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StorageService_GetFragment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileGetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).GetFragment(m, &storageServiceGetFragmentServer{stream})
+}
+
+type StorageService_GetFragmentServer interface {
+	Send(*ServerResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceGetFragmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceGetFragmentServer) Send(m *ServerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_DeleteFragment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).DeleteFragment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/StorageService/DeleteFragment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).DeleteFragment(ctx, req.(*FileDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StorageService_ServiceDesc is the grpc.ServiceDesc for StorageService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StorageService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "StorageService",
+	HandlerType: (*StorageServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeleteFragment",
+			Handler:    _StorageService_DeleteFragment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PutFragment",
+			Handler:       _StorageService_PutFragment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetFragment",
+			Handler:       _StorageService_GetFragment_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "client_storage.proto",
+}
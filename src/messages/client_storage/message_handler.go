@@ -1,46 +1,26 @@
 package client_storage
 
 import (
-	"encoding/binary"
-	"fmt"
 	"google.golang.org/protobuf/proto"
 	"net"
+	"src/messages/wire"
+	"time"
 )
 
 type MessageHandler struct {
-	conn net.Conn
+	conn *wire.Conn
 }
 
 func NewMessageHandler(conn net.Conn) *MessageHandler {
 	m := &MessageHandler{
-		conn: conn,
+		conn: wire.NewConn(conn),
 	}
-
 	return m
 }
 
-func (m *MessageHandler) readN(buf []byte) error {
-	bytesRead := uint64(0)
-	for bytesRead < uint64(len(buf)) {
-		n, err := m.conn.Read(buf[bytesRead:])
-		if err != nil {
-			return err
-		}
-		bytesRead += uint64(n)
-	}
-	return nil
-}
-
-func (m *MessageHandler) writeN(buf []byte) error {
-	bytesWritten := uint64(0)
-	for bytesWritten < uint64(len(buf)) {
-		n, err := m.conn.Write(buf[bytesWritten:])
-		if err != nil {
-			return err
-		}
-		bytesWritten += uint64(n)
-	}
-	return nil
+// SetTimeouts bounds the read and the write of every message on the connection. Zero leaves it without a deadline.
+func (m *MessageHandler) SetTimeouts(read time.Duration, write time.Duration) {
+	m.conn.SetTimeouts(read, write)
 }
 
 func (m *MessageHandler) ClientRequestSend(wrapper *ClientRequest) error {
@@ -49,12 +29,7 @@ func (m *MessageHandler) ClientRequestSend(wrapper *ClientRequest) error {
 		return err
 	}
 
-	prefix := make([]byte, 8)
-	binary.LittleEndian.PutUint64(prefix, uint64(len(serialized)))
-	m.writeN(prefix)
-	m.writeN(serialized)
-
-	return nil
+	return m.conn.Send(serialized)
 }
 
 func (m *MessageHandler) ServerResponseSend(wrapper *ServerResponse) error {
@@ -63,41 +38,37 @@ func (m *MessageHandler) ServerResponseSend(wrapper *ServerResponse) error {
 		return err
 	}
 
-	prefix := make([]byte, 8)
-	binary.LittleEndian.PutUint64(prefix, uint64(len(serialized)))
-	m.writeN(prefix)
-	m.writeN(serialized)
+	return m.conn.Send(serialized)
 
-	return nil
+}
 
+// ErrorSend answers the peer with an error frame instead of a message. The peer's receive fails with a
+// *wire.RemoteError carrying code and message.
+func (m *MessageHandler) ErrorSend(code wire.ErrorCode, message string) error {
+	return m.conn.SendError(code, message)
 }
 
 func (m *MessageHandler) ClientRequestReceive() (*ClientRequest, error) {
-	prefix := make([]byte, 8)
-	m.readN(prefix)
+	wrapper := &ClientRequest{}
 
-	payloadSize := binary.LittleEndian.Uint64(prefix)
-	payload := make([]byte, payloadSize)
-	m.readN(payload)
+	payload, err := m.conn.Receive()
+	if err != nil {
+		return wrapper, err
+	}
 
-	wrapper := &ClientRequest{}
-	err := proto.Unmarshal(payload, wrapper)
+	err = proto.Unmarshal(payload, wrapper)
 	return wrapper, err
 }
 
 func (m *MessageHandler) ServerResponseReceive() (*ServerResponse, error) {
-	prefix := make([]byte, 8)
-	m.readN(prefix)
-
-	payloadSize := binary.LittleEndian.Uint64(prefix)
-	payload := make([]byte, payloadSize)
-	m.readN(payload)
-
 	wrapper := &ServerResponse{}
-	err := proto.Unmarshal(payload, wrapper)
+
+	payload, err := m.conn.Receive()
 	if err != nil {
-		fmt.Printf("err: %s\n", err)
+		return wrapper, err
 	}
+
+	err = proto.Unmarshal(payload, wrapper)
 	return wrapper, err
 }
 
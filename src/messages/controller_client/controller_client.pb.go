@@ -23,28 +23,64 @@ const (
 type ControllerMessage_StatusCode int32
 
 const (
-	ControllerMessage_OK                  ControllerMessage_StatusCode = 0
-	ControllerMessage_ERROR               ControllerMessage_StatusCode = 1
-	ControllerMessage_FILE_NOT_FOUND      ControllerMessage_StatusCode = 2
-	ControllerMessage_FILE_ALREADY_EXISTS ControllerMessage_StatusCode = 3
-	ControllerMessage_FILE_TOO_LARGE      ControllerMessage_StatusCode = 4
+	ControllerMessage_OK                         ControllerMessage_StatusCode = 0
+	ControllerMessage_ERROR                      ControllerMessage_StatusCode = 1
+	ControllerMessage_FILE_NOT_FOUND             ControllerMessage_StatusCode = 2
+	ControllerMessage_FILE_ALREADY_EXISTS        ControllerMessage_StatusCode = 3
+	ControllerMessage_FILE_TOO_LARGE             ControllerMessage_StatusCode = 4
+	ControllerMessage_INVALID_REPLICATION_FACTOR ControllerMessage_StatusCode = 5
+	ControllerMessage_NOT_ENOUGH_NODES           ControllerMessage_StatusCode = 6
+	ControllerMessage_INVALID_ERASURE_CODING     ControllerMessage_StatusCode = 7
+	ControllerMessage_NOT_ENOUGH_SPACE           ControllerMessage_StatusCode = 8
+	ControllerMessage_INVALID_RANGE              ControllerMessage_StatusCode = 9
+	// The path is not a directory, or one of the directories above it is a file
+	ControllerMessage_NOT_A_DIRECTORY     ControllerMessage_StatusCode = 10
+	ControllerMessage_IS_A_DIRECTORY      ControllerMessage_StatusCode = 11
+	ControllerMessage_DIRECTORY_NOT_EMPTY ControllerMessage_StatusCode = 12
+	ControllerMessage_INVALID_PATH        ControllerMessage_StatusCode = 13
+	// The file is being uploaded or deleted
+	ControllerMessage_FILE_BUSY ControllerMessage_StatusCode = 14
+	// The pattern of a listing is not a valid glob
+	ControllerMessage_INVALID_PATTERN ControllerMessage_StatusCode = 15
 )
 
 // Enum value maps for ControllerMessage_StatusCode.
 var (
 	ControllerMessage_StatusCode_name = map[int32]string{
-		0: "OK",
-		1: "ERROR",
-		2: "FILE_NOT_FOUND",
-		3: "FILE_ALREADY_EXISTS",
-		4: "FILE_TOO_LARGE",
+		0:  "OK",
+		1:  "ERROR",
+		2:  "FILE_NOT_FOUND",
+		3:  "FILE_ALREADY_EXISTS",
+		4:  "FILE_TOO_LARGE",
+		5:  "INVALID_REPLICATION_FACTOR",
+		6:  "NOT_ENOUGH_NODES",
+		7:  "INVALID_ERASURE_CODING",
+		8:  "NOT_ENOUGH_SPACE",
+		9:  "INVALID_RANGE",
+		10: "NOT_A_DIRECTORY",
+		11: "IS_A_DIRECTORY",
+		12: "DIRECTORY_NOT_EMPTY",
+		13: "INVALID_PATH",
+		14: "FILE_BUSY",
+		15: "INVALID_PATTERN",
 	}
 	ControllerMessage_StatusCode_value = map[string]int32{
-		"OK":                  0,
-		"ERROR":               1,
-		"FILE_NOT_FOUND":      2,
-		"FILE_ALREADY_EXISTS": 3,
-		"FILE_TOO_LARGE":      4,
+		"OK":                         0,
+		"ERROR":                      1,
+		"FILE_NOT_FOUND":             2,
+		"FILE_ALREADY_EXISTS":        3,
+		"FILE_TOO_LARGE":             4,
+		"INVALID_REPLICATION_FACTOR": 5,
+		"NOT_ENOUGH_NODES":           6,
+		"INVALID_ERASURE_CODING":     7,
+		"NOT_ENOUGH_SPACE":           8,
+		"INVALID_RANGE":              9,
+		"NOT_A_DIRECTORY":            10,
+		"IS_A_DIRECTORY":             11,
+		"DIRECTORY_NOT_EMPTY":        12,
+		"INVALID_PATH":               13,
+		"FILE_BUSY":                  14,
+		"INVALID_PATTERN":            15,
 	}
 )
 
@@ -75,6 +111,57 @@ func (ControllerMessage_StatusCode) EnumDescriptor() ([]byte, []int) {
 	return file_controller_client_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type ControllerMessage_StatResponse_FragmentHealth int32
+
+const (
+	ControllerMessage_StatResponse_HEALTHY ControllerMessage_StatResponse_FragmentHealth = 0
+	// Fewer replicas are on live nodes than the replication factor asks for
+	ControllerMessage_StatResponse_UNDER_REPLICATED ControllerMessage_StatResponse_FragmentHealth = 1
+	// No live node holds the fragment
+	ControllerMessage_StatResponse_MISSING ControllerMessage_StatResponse_FragmentHealth = 2
+)
+
+// Enum value maps for ControllerMessage_StatResponse_FragmentHealth.
+var (
+	ControllerMessage_StatResponse_FragmentHealth_name = map[int32]string{
+		0: "HEALTHY",
+		1: "UNDER_REPLICATED",
+		2: "MISSING",
+	}
+	ControllerMessage_StatResponse_FragmentHealth_value = map[string]int32{
+		"HEALTHY":          0,
+		"UNDER_REPLICATED": 1,
+		"MISSING":          2,
+	}
+)
+
+func (x ControllerMessage_StatResponse_FragmentHealth) Enum() *ControllerMessage_StatResponse_FragmentHealth {
+	p := new(ControllerMessage_StatResponse_FragmentHealth)
+	*p = x
+	return p
+}
+
+func (x ControllerMessage_StatResponse_FragmentHealth) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ControllerMessage_StatResponse_FragmentHealth) Descriptor() protoreflect.EnumDescriptor {
+	return file_controller_client_proto_enumTypes[1].Descriptor()
+}
+
+func (ControllerMessage_StatResponse_FragmentHealth) Type() protoreflect.EnumType {
+	return &file_controller_client_proto_enumTypes[1]
+}
+
+func (x ControllerMessage_StatResponse_FragmentHealth) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ControllerMessage_StatResponse_FragmentHealth.Descriptor instead.
+func (ControllerMessage_StatResponse_FragmentHealth) EnumDescriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 8, 0}
+}
+
 type ClientMessage_RestOption int32
 
 const (
@@ -83,6 +170,11 @@ const (
 	ClientMessage_DELETE     ClientMessage_RestOption = 2
 	ClientMessage_LS         ClientMessage_RestOption = 3
 	ClientMessage_NODE_STATS ClientMessage_RestOption = 4
+	ClientMessage_COMMIT     ClientMessage_RestOption = 5
+	ClientMessage_MKDIR      ClientMessage_RestOption = 6
+	ClientMessage_RMDIR      ClientMessage_RestOption = 7
+	ClientMessage_RENAME     ClientMessage_RestOption = 8
+	ClientMessage_STAT       ClientMessage_RestOption = 9
 )
 
 // Enum value maps for ClientMessage_RestOption.
@@ -93,6 +185,11 @@ var (
 		2: "DELETE",
 		3: "LS",
 		4: "NODE_STATS",
+		5: "COMMIT",
+		6: "MKDIR",
+		7: "RMDIR",
+		8: "RENAME",
+		9: "STAT",
 	}
 	ClientMessage_RestOption_value = map[string]int32{
 		"GET":        0,
@@ -100,6 +197,11 @@ var (
 		"DELETE":     2,
 		"LS":         3,
 		"NODE_STATS": 4,
+		"COMMIT":     5,
+		"MKDIR":      6,
+		"RMDIR":      7,
+		"RENAME":     8,
+		"STAT":       9,
 	}
 )
 
@@ -114,11 +216,11 @@ func (x ClientMessage_RestOption) String() string {
 }
 
 func (ClientMessage_RestOption) Descriptor() protoreflect.EnumDescriptor {
-	return file_controller_client_proto_enumTypes[1].Descriptor()
+	return file_controller_client_proto_enumTypes[2].Descriptor()
 }
 
 func (ClientMessage_RestOption) Type() protoreflect.EnumType {
-	return &file_controller_client_proto_enumTypes[1]
+	return &file_controller_client_proto_enumTypes[2]
 }
 
 func (x ClientMessage_RestOption) Number() protoreflect.EnumNumber {
@@ -142,6 +244,9 @@ type ControllerMessage struct {
 	//	*ControllerMessage_DeleteResponse_
 	//	*ControllerMessage_LsResponse_
 	//	*ControllerMessage_NodeStats_
+	//	*ControllerMessage_CommitResponse_
+	//	*ControllerMessage_NamespaceResponse_
+	//	*ControllerMessage_StatResponse_
 	ControllerMessage isControllerMessage_ControllerMessage `protobuf_oneof:"controller_message"`
 }
 
@@ -219,6 +324,27 @@ func (x *ControllerMessage) GetNodeStats() *ControllerMessage_NodeStats {
 	return nil
 }
 
+func (x *ControllerMessage) GetCommitResponse() *ControllerMessage_CommitResponse {
+	if x, ok := x.GetControllerMessage().(*ControllerMessage_CommitResponse_); ok {
+		return x.CommitResponse
+	}
+	return nil
+}
+
+func (x *ControllerMessage) GetNamespaceResponse() *ControllerMessage_NamespaceResponse {
+	if x, ok := x.GetControllerMessage().(*ControllerMessage_NamespaceResponse_); ok {
+		return x.NamespaceResponse
+	}
+	return nil
+}
+
+func (x *ControllerMessage) GetStatResponse() *ControllerMessage_StatResponse {
+	if x, ok := x.GetControllerMessage().(*ControllerMessage_StatResponse_); ok {
+		return x.StatResponse
+	}
+	return nil
+}
+
 type isControllerMessage_ControllerMessage interface {
 	isControllerMessage_ControllerMessage()
 }
@@ -243,6 +369,18 @@ type ControllerMessage_NodeStats_ struct {
 	NodeStats *ControllerMessage_NodeStats `protobuf:"bytes,5,opt,name=node_stats,json=nodeStats,proto3,oneof"`
 }
 
+type ControllerMessage_CommitResponse_ struct {
+	CommitResponse *ControllerMessage_CommitResponse `protobuf:"bytes,6,opt,name=commit_response,json=commitResponse,proto3,oneof"`
+}
+
+type ControllerMessage_NamespaceResponse_ struct {
+	NamespaceResponse *ControllerMessage_NamespaceResponse `protobuf:"bytes,7,opt,name=namespace_response,json=namespaceResponse,proto3,oneof"`
+}
+
+type ControllerMessage_StatResponse_ struct {
+	StatResponse *ControllerMessage_StatResponse `protobuf:"bytes,8,opt,name=stat_response,json=statResponse,proto3,oneof"`
+}
+
 func (*ControllerMessage_PlanResponse_) isControllerMessage_ControllerMessage() {}
 
 func (*ControllerMessage_FragLayoutResponse_) isControllerMessage_ControllerMessage() {}
@@ -253,6 +391,12 @@ func (*ControllerMessage_LsResponse_) isControllerMessage_ControllerMessage() {}
 
 func (*ControllerMessage_NodeStats_) isControllerMessage_ControllerMessage() {}
 
+func (*ControllerMessage_CommitResponse_) isControllerMessage_ControllerMessage() {}
+
+func (*ControllerMessage_NamespaceResponse_) isControllerMessage_ControllerMessage() {}
+
+func (*ControllerMessage_StatResponse_) isControllerMessage_ControllerMessage() {}
+
 type ClientMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -265,6 +409,11 @@ type ClientMessage struct {
 	//	*ClientMessage_DeleteRequest_
 	//	*ClientMessage_LsRequest_
 	//	*ClientMessage_NodeStatsRequest_
+	//	*ClientMessage_CommitRequest_
+	//	*ClientMessage_MkdirRequest_
+	//	*ClientMessage_RmdirRequest_
+	//	*ClientMessage_RenameRequest_
+	//	*ClientMessage_StatRequest_
 	ClientMessage isClientMessage_ClientMessage `protobuf_oneof:"client_message"`
 }
 
@@ -342,6 +491,41 @@ func (x *ClientMessage) GetNodeStatsRequest() *ClientMessage_NodeStatsRequest {
 	return nil
 }
 
+func (x *ClientMessage) GetCommitRequest() *ClientMessage_CommitRequest {
+	if x, ok := x.GetClientMessage().(*ClientMessage_CommitRequest_); ok {
+		return x.CommitRequest
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetMkdirRequest() *ClientMessage_MkdirRequest {
+	if x, ok := x.GetClientMessage().(*ClientMessage_MkdirRequest_); ok {
+		return x.MkdirRequest
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetRmdirRequest() *ClientMessage_RmdirRequest {
+	if x, ok := x.GetClientMessage().(*ClientMessage_RmdirRequest_); ok {
+		return x.RmdirRequest
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetRenameRequest() *ClientMessage_RenameRequest {
+	if x, ok := x.GetClientMessage().(*ClientMessage_RenameRequest_); ok {
+		return x.RenameRequest
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetStatRequest() *ClientMessage_StatRequest {
+	if x, ok := x.GetClientMessage().(*ClientMessage_StatRequest_); ok {
+		return x.StatRequest
+	}
+	return nil
+}
+
 type isClientMessage_ClientMessage interface {
 	isClientMessage_ClientMessage()
 }
@@ -366,6 +550,26 @@ type ClientMessage_NodeStatsRequest_ struct {
 	NodeStatsRequest *ClientMessage_NodeStatsRequest `protobuf:"bytes,5,opt,name=node_stats_request,json=nodeStatsRequest,proto3,oneof"`
 }
 
+type ClientMessage_CommitRequest_ struct {
+	CommitRequest *ClientMessage_CommitRequest `protobuf:"bytes,6,opt,name=commit_request,json=commitRequest,proto3,oneof"`
+}
+
+type ClientMessage_MkdirRequest_ struct {
+	MkdirRequest *ClientMessage_MkdirRequest `protobuf:"bytes,7,opt,name=mkdir_request,json=mkdirRequest,proto3,oneof"`
+}
+
+type ClientMessage_RmdirRequest_ struct {
+	RmdirRequest *ClientMessage_RmdirRequest `protobuf:"bytes,8,opt,name=rmdir_request,json=rmdirRequest,proto3,oneof"`
+}
+
+type ClientMessage_RenameRequest_ struct {
+	RenameRequest *ClientMessage_RenameRequest `protobuf:"bytes,9,opt,name=rename_request,json=renameRequest,proto3,oneof"`
+}
+
+type ClientMessage_StatRequest_ struct {
+	StatRequest *ClientMessage_StatRequest `protobuf:"bytes,10,opt,name=stat_request,json=statRequest,proto3,oneof"`
+}
+
 func (*ClientMessage_PutRequest_) isClientMessage_ClientMessage() {}
 
 func (*ClientMessage_GetRequest_) isClientMessage_ClientMessage() {}
@@ -376,6 +580,97 @@ func (*ClientMessage_LsRequest_) isClientMessage_ClientMessage() {}
 
 func (*ClientMessage_NodeStatsRequest_) isClientMessage_ClientMessage() {}
 
+func (*ClientMessage_CommitRequest_) isClientMessage_ClientMessage() {}
+
+func (*ClientMessage_MkdirRequest_) isClientMessage_ClientMessage() {}
+
+func (*ClientMessage_RmdirRequest_) isClientMessage_ClientMessage() {}
+
+func (*ClientMessage_RenameRequest_) isClientMessage_ClientMessage() {}
+
+func (*ClientMessage_StatRequest_) isClientMessage_ClientMessage() {}
+
+// Set for erasure coded files. Fragments are then shards, numbered group by group.
+type ControllerMessage_ErasureCoding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DataShards   uint32 `protobuf:"varint,1,opt,name=data_shards,json=dataShards,proto3" json:"data_shards,omitempty"`
+	ParityShards uint32 `protobuf:"varint,2,opt,name=parity_shards,json=parityShards,proto3" json:"parity_shards,omitempty"`
+	ChunkSize    int64  `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	FileSize     int64  `protobuf:"varint,4,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	// The block id of every shard of the file, shard s of group g is block_ids[g*(data_shards+parity_shards)+s]
+	BlockIds []string `protobuf:"bytes,6,rep,name=block_ids,json=blockIds,proto3" json:"block_ids,omitempty"`
+}
+
+func (x *ControllerMessage_ErasureCoding) Reset() {
+	*x = ControllerMessage_ErasureCoding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_ErasureCoding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_ErasureCoding) ProtoMessage() {}
+
+func (x *ControllerMessage_ErasureCoding) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_ErasureCoding.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_ErasureCoding) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ControllerMessage_ErasureCoding) GetDataShards() uint32 {
+	if x != nil {
+		return x.DataShards
+	}
+	return 0
+}
+
+func (x *ControllerMessage_ErasureCoding) GetParityShards() uint32 {
+	if x != nil {
+		return x.ParityShards
+	}
+	return 0
+}
+
+func (x *ControllerMessage_ErasureCoding) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *ControllerMessage_ErasureCoding) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *ControllerMessage_ErasureCoding) GetBlockIds() []string {
+	if x != nil {
+		return x.BlockIds
+	}
+	return nil
+}
+
 type ControllerMessage_PlanResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -384,12 +679,15 @@ type ControllerMessage_PlanResponse struct {
 	StatusCode        ControllerMessage_StatusCode                   `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
 	TotalNumFragments uint32                                         `protobuf:"varint,2,opt,name=total_num_fragments,json=totalNumFragments,proto3" json:"total_num_fragments,omitempty"`
 	FragmentLayout    []*ControllerMessage_PlanResponse_FragmentInfo `protobuf:"bytes,5,rep,name=fragment_layout,json=fragmentLayout,proto3" json:"fragment_layout,omitempty"`
+	ErasureCoding     *ControllerMessage_ErasureCoding               `protobuf:"bytes,6,opt,name=erasure_coding,json=erasureCoding,proto3" json:"erasure_coding,omitempty"`
+	// Identifies the PUT that stores the file, sent back to resume it
+	UploadId string `protobuf:"bytes,7,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
 }
 
 func (x *ControllerMessage_PlanResponse) Reset() {
 	*x = ControllerMessage_PlanResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[2]
+		mi := &file_controller_client_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +700,7 @@ func (x *ControllerMessage_PlanResponse) String() string {
 func (*ControllerMessage_PlanResponse) ProtoMessage() {}
 
 func (x *ControllerMessage_PlanResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[2]
+	mi := &file_controller_client_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +713,7 @@ func (x *ControllerMessage_PlanResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControllerMessage_PlanResponse.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_PlanResponse) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 0}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 1}
 }
 
 func (x *ControllerMessage_PlanResponse) GetStatusCode() ControllerMessage_StatusCode {
@@ -439,6 +737,20 @@ func (x *ControllerMessage_PlanResponse) GetFragmentLayout() []*ControllerMessag
 	return nil
 }
 
+func (x *ControllerMessage_PlanResponse) GetErasureCoding() *ControllerMessage_ErasureCoding {
+	if x != nil {
+		return x.ErasureCoding
+	}
+	return nil
+}
+
+func (x *ControllerMessage_PlanResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
 type ControllerMessage_FragLayoutResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -447,12 +759,18 @@ type ControllerMessage_FragLayoutResponse struct {
 	StatusCode        ControllerMessage_StatusCode                         `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
 	TotalNumFragments uint32                                               `protobuf:"varint,2,opt,name=total_num_fragments,json=totalNumFragments,proto3" json:"total_num_fragments,omitempty"`
 	FragmentLayout    []*ControllerMessage_FragLayoutResponse_FragmentInfo `protobuf:"bytes,5,rep,name=fragment_layout,json=fragmentLayout,proto3" json:"fragment_layout,omitempty"`
+	ErasureCoding     *ControllerMessage_ErasureCoding                     `protobuf:"bytes,6,opt,name=erasure_coding,json=erasureCoding,proto3" json:"erasure_coding,omitempty"`
+	// Identifies the PUT that stores the file, sent back to resume it
+	UploadId string `protobuf:"bytes,7,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// Set for a range read: the bytes of the file it covers, clipped to its end
+	RangeOffset int64 `protobuf:"varint,8,opt,name=range_offset,json=rangeOffset,proto3" json:"range_offset,omitempty"`
+	RangeLength int64 `protobuf:"varint,9,opt,name=range_length,json=rangeLength,proto3" json:"range_length,omitempty"`
 }
 
 func (x *ControllerMessage_FragLayoutResponse) Reset() {
 	*x = ControllerMessage_FragLayoutResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[3]
+		mi := &file_controller_client_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -465,7 +783,7 @@ func (x *ControllerMessage_FragLayoutResponse) String() string {
 func (*ControllerMessage_FragLayoutResponse) ProtoMessage() {}
 
 func (x *ControllerMessage_FragLayoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[3]
+	mi := &file_controller_client_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,7 +796,7 @@ func (x *ControllerMessage_FragLayoutResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use ControllerMessage_FragLayoutResponse.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_FragLayoutResponse) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 1}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 2}
 }
 
 func (x *ControllerMessage_FragLayoutResponse) GetStatusCode() ControllerMessage_StatusCode {
@@ -502,18 +820,47 @@ func (x *ControllerMessage_FragLayoutResponse) GetFragmentLayout() []*Controller
 	return nil
 }
 
+func (x *ControllerMessage_FragLayoutResponse) GetErasureCoding() *ControllerMessage_ErasureCoding {
+	if x != nil {
+		return x.ErasureCoding
+	}
+	return nil
+}
+
+func (x *ControllerMessage_FragLayoutResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *ControllerMessage_FragLayoutResponse) GetRangeOffset() int64 {
+	if x != nil {
+		return x.RangeOffset
+	}
+	return 0
+}
+
+func (x *ControllerMessage_FragLayoutResponse) GetRangeLength() int64 {
+	if x != nil {
+		return x.RangeLength
+	}
+	return 0
+}
+
 type ControllerMessage_DeleteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StatusCode ControllerMessage_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
+	StatusCode     ControllerMessage_StatusCode                      `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
+	FailedReplicas []*ControllerMessage_DeleteResponse_FailedReplica `protobuf:"bytes,2,rep,name=failed_replicas,json=failedReplicas,proto3" json:"failed_replicas,omitempty"`
 }
 
 func (x *ControllerMessage_DeleteResponse) Reset() {
 	*x = ControllerMessage_DeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[4]
+		mi := &file_controller_client_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -526,7 +873,7 @@ func (x *ControllerMessage_DeleteResponse) String() string {
 func (*ControllerMessage_DeleteResponse) ProtoMessage() {}
 
 func (x *ControllerMessage_DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[4]
+	mi := &file_controller_client_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +886,7 @@ func (x *ControllerMessage_DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControllerMessage_DeleteResponse.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 2}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 3}
 }
 
 func (x *ControllerMessage_DeleteResponse) GetStatusCode() ControllerMessage_StatusCode {
@@ -549,6 +896,13 @@ func (x *ControllerMessage_DeleteResponse) GetStatusCode() ControllerMessage_Sta
 	return ControllerMessage_OK
 }
 
+func (x *ControllerMessage_DeleteResponse) GetFailedReplicas() []*ControllerMessage_DeleteResponse_FailedReplica {
+	if x != nil {
+		return x.FailedReplicas
+	}
+	return nil
+}
+
 type ControllerMessage_NodeStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -561,7 +915,7 @@ type ControllerMessage_NodeStats struct {
 func (x *ControllerMessage_NodeStats) Reset() {
 	*x = ControllerMessage_NodeStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[5]
+		mi := &file_controller_client_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -574,7 +928,7 @@ func (x *ControllerMessage_NodeStats) String() string {
 func (*ControllerMessage_NodeStats) ProtoMessage() {}
 
 func (x *ControllerMessage_NodeStats) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[5]
+	mi := &file_controller_client_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,7 +941,7 @@ func (x *ControllerMessage_NodeStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControllerMessage_NodeStats.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_NodeStats) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 3}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 4}
 }
 
 func (x *ControllerMessage_NodeStats) GetStatusCode() ControllerMessage_StatusCode {
@@ -610,13 +964,19 @@ type ControllerMessage_LsResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	StatusCode ControllerMessage_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
-	FileNames  []string                     `protobuf:"bytes,2,rep,name=file_names,json=fileNames,proto3" json:"file_names,omitempty"`
+	// Names of the files and directories directly in the listed directory
+	FileNames []string `protobuf:"bytes,2,rep,name=file_names,json=fileNames,proto3" json:"file_names,omitempty"`
+	DirNames  []string `protobuf:"bytes,3,rep,name=dir_names,json=dirNames,proto3" json:"dir_names,omitempty"`
+	// The listed page, sorted by name
+	Entries []*ControllerMessage_LsResponse_Entry `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Sent back in the next LsRequest to list the following page, empty on the last one
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
 func (x *ControllerMessage_LsResponse) Reset() {
 	*x = ControllerMessage_LsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[6]
+		mi := &file_controller_client_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -629,7 +989,7 @@ func (x *ControllerMessage_LsResponse) String() string {
 func (*ControllerMessage_LsResponse) ProtoMessage() {}
 
 func (x *ControllerMessage_LsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[6]
+	mi := &file_controller_client_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,7 +1002,7 @@ func (x *ControllerMessage_LsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControllerMessage_LsResponse.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_LsResponse) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 4}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 5}
 }
 
 func (x *ControllerMessage_LsResponse) GetStatusCode() ControllerMessage_StatusCode {
@@ -659,33 +1019,52 @@ func (x *ControllerMessage_LsResponse) GetFileNames() []string {
 	return nil
 }
 
-type ControllerMessage_PlanResponse_StorageNodeInfo struct {
+func (x *ControllerMessage_LsResponse) GetDirNames() []string {
+	if x != nil {
+		return x.DirNames
+	}
+	return nil
+}
+
+func (x *ControllerMessage_LsResponse) GetEntries() []*ControllerMessage_LsResponse_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ControllerMessage_LsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ControllerMessage_CommitResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StorageNodeId string `protobuf:"bytes,1,opt,name=storage_node_id,json=storageNodeId,proto3" json:"storage_node_id,omitempty"`
-	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
-	Port          string `protobuf:"bytes,3,opt,name=port,proto3" json:"port,omitempty"`
+	StatusCode ControllerMessage_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
 }
 
-func (x *ControllerMessage_PlanResponse_StorageNodeInfo) Reset() {
-	*x = ControllerMessage_PlanResponse_StorageNodeInfo{}
+func (x *ControllerMessage_CommitResponse) Reset() {
+	*x = ControllerMessage_CommitResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[7]
+		mi := &file_controller_client_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ControllerMessage_PlanResponse_StorageNodeInfo) String() string {
+func (x *ControllerMessage_CommitResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ControllerMessage_PlanResponse_StorageNodeInfo) ProtoMessage() {}
+func (*ControllerMessage_CommitResponse) ProtoMessage() {}
 
-func (x *ControllerMessage_PlanResponse_StorageNodeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[7]
+func (x *ControllerMessage_CommitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,29 +1075,257 @@ func (x *ControllerMessage_PlanResponse_StorageNodeInfo) ProtoReflect() protoref
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ControllerMessage_PlanResponse_StorageNodeInfo.ProtoReflect.Descriptor instead.
-func (*ControllerMessage_PlanResponse_StorageNodeInfo) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 0, 0}
+// Deprecated: Use ControllerMessage_CommitResponse.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_CommitResponse) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 6}
 }
 
-func (x *ControllerMessage_PlanResponse_StorageNodeInfo) GetStorageNodeId() string {
+func (x *ControllerMessage_CommitResponse) GetStatusCode() ControllerMessage_StatusCode {
 	if x != nil {
-		return x.StorageNodeId
+		return x.StatusCode
 	}
-	return ""
+	return ControllerMessage_OK
 }
 
-func (x *ControllerMessage_PlanResponse_StorageNodeInfo) GetHost() string {
-	if x != nil {
-		return x.Host
-	}
-	return ""
+// Answers MKDIR, RMDIR and RENAME
+type ControllerMessage_NamespaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode ControllerMessage_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
 }
 
-func (x *ControllerMessage_PlanResponse_StorageNodeInfo) GetPort() string {
-	if x != nil {
-		return x.Port
-	}
+func (x *ControllerMessage_NamespaceResponse) Reset() {
+	*x = ControllerMessage_NamespaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_NamespaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_NamespaceResponse) ProtoMessage() {}
+
+func (x *ControllerMessage_NamespaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_NamespaceResponse.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_NamespaceResponse) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 7}
+}
+
+func (x *ControllerMessage_NamespaceResponse) GetStatusCode() ControllerMessage_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ControllerMessage_OK
+}
+
+type ControllerMessage_StatResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusCode ControllerMessage_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=ControllerMessage_StatusCode" json:"status_code,omitempty"`
+	FileName   string                       `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// 0 for a file stored by a Controller without a metadata store
+	Size              int64                            `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ChunkSize         int64                            `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	NumFragments      uint32                           `protobuf:"varint,5,opt,name=num_fragments,json=numFragments,proto3" json:"num_fragments,omitempty"`
+	ReplicationFactor uint32                           `protobuf:"varint,6,opt,name=replication_factor,json=replicationFactor,proto3" json:"replication_factor,omitempty"`
+	ErasureCoding     *ControllerMessage_ErasureCoding `protobuf:"bytes,7,opt,name=erasure_coding,json=erasureCoding,proto3" json:"erasure_coding,omitempty"`
+	// Unix time in milliseconds the file was planned at
+	Created int64  `protobuf:"varint,8,opt,name=created,proto3" json:"created,omitempty"`
+	Owner   string `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+	// SHA-256 of the whole file in hex, as the client that stored it computed it
+	Checksum string `protobuf:"bytes,10,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// In file order
+	Fragments []*ControllerMessage_StatResponse_FragmentInfo `protobuf:"bytes,11,rep,name=fragments,proto3" json:"fragments,omitempty"`
+}
+
+func (x *ControllerMessage_StatResponse) Reset() {
+	*x = ControllerMessage_StatResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_StatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_StatResponse) ProtoMessage() {}
+
+func (x *ControllerMessage_StatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_StatResponse.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_StatResponse) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 8}
+}
+
+func (x *ControllerMessage_StatResponse) GetStatusCode() ControllerMessage_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ControllerMessage_OK
+}
+
+func (x *ControllerMessage_StatResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ControllerMessage_StatResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ControllerMessage_StatResponse) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *ControllerMessage_StatResponse) GetNumFragments() uint32 {
+	if x != nil {
+		return x.NumFragments
+	}
+	return 0
+}
+
+func (x *ControllerMessage_StatResponse) GetReplicationFactor() uint32 {
+	if x != nil {
+		return x.ReplicationFactor
+	}
+	return 0
+}
+
+func (x *ControllerMessage_StatResponse) GetErasureCoding() *ControllerMessage_ErasureCoding {
+	if x != nil {
+		return x.ErasureCoding
+	}
+	return nil
+}
+
+func (x *ControllerMessage_StatResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ControllerMessage_StatResponse) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ControllerMessage_StatResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *ControllerMessage_StatResponse) GetFragments() []*ControllerMessage_StatResponse_FragmentInfo {
+	if x != nil {
+		return x.Fragments
+	}
+	return nil
+}
+
+type ControllerMessage_PlanResponse_StorageNodeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StorageNodeId string `protobuf:"bytes,1,opt,name=storage_node_id,json=storageNodeId,proto3" json:"storage_node_id,omitempty"`
+	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          string `protobuf:"bytes,3,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (x *ControllerMessage_PlanResponse_StorageNodeInfo) Reset() {
+	*x = ControllerMessage_PlanResponse_StorageNodeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_PlanResponse_StorageNodeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_PlanResponse_StorageNodeInfo) ProtoMessage() {}
+
+func (x *ControllerMessage_PlanResponse_StorageNodeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_PlanResponse_StorageNodeInfo.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_PlanResponse_StorageNodeInfo) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 1, 0}
+}
+
+func (x *ControllerMessage_PlanResponse_StorageNodeInfo) GetStorageNodeId() string {
+	if x != nil {
+		return x.StorageNodeId
+	}
+	return ""
+}
+
+func (x *ControllerMessage_PlanResponse_StorageNodeInfo) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ControllerMessage_PlanResponse_StorageNodeInfo) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
 	return ""
 }
 
@@ -727,15 +1334,18 @@ type ControllerMessage_PlanResponse_FragmentInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The block id the fragment is stored under
 	FragmentId     string                                            `protobuf:"bytes,1,opt,name=fragment_id,json=fragmentId,proto3" json:"fragment_id,omitempty"`
 	Size           int64                                             `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 	StorageNodeIds []*ControllerMessage_PlanResponse_StorageNodeInfo `protobuf:"bytes,3,rep,name=storage_node_ids,json=storageNodeIds,proto3" json:"storage_node_ids,omitempty"`
+	// The position of the fragment in the file
+	Index uint32 `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
 }
 
 func (x *ControllerMessage_PlanResponse_FragmentInfo) Reset() {
 	*x = ControllerMessage_PlanResponse_FragmentInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[8]
+		mi := &file_controller_client_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -748,7 +1358,7 @@ func (x *ControllerMessage_PlanResponse_FragmentInfo) String() string {
 func (*ControllerMessage_PlanResponse_FragmentInfo) ProtoMessage() {}
 
 func (x *ControllerMessage_PlanResponse_FragmentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[8]
+	mi := &file_controller_client_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -761,7 +1371,7 @@ func (x *ControllerMessage_PlanResponse_FragmentInfo) ProtoReflect() protoreflec
 
 // Deprecated: Use ControllerMessage_PlanResponse_FragmentInfo.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_PlanResponse_FragmentInfo) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 0, 1}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 1, 1}
 }
 
 func (x *ControllerMessage_PlanResponse_FragmentInfo) GetFragmentId() string {
@@ -785,6 +1395,13 @@ func (x *ControllerMessage_PlanResponse_FragmentInfo) GetStorageNodeIds() []*Con
 	return nil
 }
 
+func (x *ControllerMessage_PlanResponse_FragmentInfo) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
 type ControllerMessage_FragLayoutResponse_StorageNodeInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -798,7 +1415,7 @@ type ControllerMessage_FragLayoutResponse_StorageNodeInfo struct {
 func (x *ControllerMessage_FragLayoutResponse_StorageNodeInfo) Reset() {
 	*x = ControllerMessage_FragLayoutResponse_StorageNodeInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[9]
+		mi := &file_controller_client_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -811,7 +1428,7 @@ func (x *ControllerMessage_FragLayoutResponse_StorageNodeInfo) String() string {
 func (*ControllerMessage_FragLayoutResponse_StorageNodeInfo) ProtoMessage() {}
 
 func (x *ControllerMessage_FragLayoutResponse_StorageNodeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[9]
+	mi := &file_controller_client_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,7 +1441,7 @@ func (x *ControllerMessage_FragLayoutResponse_StorageNodeInfo) ProtoReflect() pr
 
 // Deprecated: Use ControllerMessage_FragLayoutResponse_StorageNodeInfo.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_FragLayoutResponse_StorageNodeInfo) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 1, 0}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 2, 0}
 }
 
 func (x *ControllerMessage_FragLayoutResponse_StorageNodeInfo) GetStorageNodeId() string {
@@ -856,12 +1473,17 @@ type ControllerMessage_FragLayoutResponse_FragmentInfo struct {
 	FragmentId     string                                                  `protobuf:"bytes,1,opt,name=fragment_id,json=fragmentId,proto3" json:"fragment_id,omitempty"`
 	Size           int64                                                   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 	StorageNodeIds []*ControllerMessage_FragLayoutResponse_StorageNodeInfo `protobuf:"bytes,3,rep,name=storage_node_ids,json=storageNodeIds,proto3" json:"storage_node_ids,omitempty"`
+	// Set for a range read: the bytes of the fragment it covers
+	Offset int64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length int64 `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`
+	// The position of the fragment in the file
+	Index uint32 `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
 }
 
 func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) Reset() {
 	*x = ControllerMessage_FragLayoutResponse_FragmentInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[10]
+		mi := &file_controller_client_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -874,7 +1496,7 @@ func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) String() string {
 func (*ControllerMessage_FragLayoutResponse_FragmentInfo) ProtoMessage() {}
 
 func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[10]
+	mi := &file_controller_client_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -887,7 +1509,7 @@ func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) ProtoReflect() proto
 
 // Deprecated: Use ControllerMessage_FragLayoutResponse_FragmentInfo.ProtoReflect.Descriptor instead.
 func (*ControllerMessage_FragLayoutResponse_FragmentInfo) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{0, 1, 1}
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 2, 1}
 }
 
 func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) GetFragmentId() string {
@@ -911,33 +1533,55 @@ func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) GetStorageNodeIds()
 	return nil
 }
 
-type ControllerMessage_NodeStats_NodeInfo struct {
+func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *ControllerMessage_FragLayoutResponse_FragmentInfo) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type ControllerMessage_DeleteResponse_FailedReplica struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NodeId             string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	DiskSpace          int64  `protobuf:"varint,2,opt,name=disk_space,json=diskSpace,proto3" json:"disk_space,omitempty"`
-	NumRequestsHandled int64  `protobuf:"varint,3,opt,name=num_requests_handled,json=numRequestsHandled,proto3" json:"num_requests_handled,omitempty"`
+	FragmentId    string `protobuf:"bytes,1,opt,name=fragment_id,json=fragmentId,proto3" json:"fragment_id,omitempty"`
+	StorageNodeId string `protobuf:"bytes,2,opt,name=storage_node_id,json=storageNodeId,proto3" json:"storage_node_id,omitempty"`
+	Host          string `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Port          string `protobuf:"bytes,4,opt,name=port,proto3" json:"port,omitempty"`
 }
 
-func (x *ControllerMessage_NodeStats_NodeInfo) Reset() {
-	*x = ControllerMessage_NodeStats_NodeInfo{}
+func (x *ControllerMessage_DeleteResponse_FailedReplica) Reset() {
+	*x = ControllerMessage_DeleteResponse_FailedReplica{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[11]
+		mi := &file_controller_client_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ControllerMessage_NodeStats_NodeInfo) String() string {
+func (x *ControllerMessage_DeleteResponse_FailedReplica) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ControllerMessage_NodeStats_NodeInfo) ProtoMessage() {}
+func (*ControllerMessage_DeleteResponse_FailedReplica) ProtoMessage() {}
 
-func (x *ControllerMessage_NodeStats_NodeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[11]
+func (x *ControllerMessage_DeleteResponse_FailedReplica) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,47 +1592,352 @@ func (x *ControllerMessage_NodeStats_NodeInfo) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ControllerMessage_NodeStats_NodeInfo.ProtoReflect.Descriptor instead.
-func (*ControllerMessage_NodeStats_NodeInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use ControllerMessage_DeleteResponse_FailedReplica.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_DeleteResponse_FailedReplica) Descriptor() ([]byte, []int) {
 	return file_controller_client_proto_rawDescGZIP(), []int{0, 3, 0}
 }
 
-func (x *ControllerMessage_NodeStats_NodeInfo) GetNodeId() string {
+func (x *ControllerMessage_DeleteResponse_FailedReplica) GetFragmentId() string {
 	if x != nil {
-		return x.NodeId
+		return x.FragmentId
 	}
 	return ""
 }
 
-func (x *ControllerMessage_NodeStats_NodeInfo) GetDiskSpace() int64 {
+func (x *ControllerMessage_DeleteResponse_FailedReplica) GetStorageNodeId() string {
 	if x != nil {
-		return x.DiskSpace
+		return x.StorageNodeId
 	}
-	return 0
+	return ""
 }
 
-func (x *ControllerMessage_NodeStats_NodeInfo) GetNumRequestsHandled() int64 {
+func (x *ControllerMessage_DeleteResponse_FailedReplica) GetHost() string {
 	if x != nil {
-		return x.NumRequestsHandled
+		return x.Host
 	}
-	return 0
+	return ""
 }
 
-type ClientMessage_PutRequest struct {
+func (x *ControllerMessage_DeleteResponse_FailedReplica) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+type ControllerMessage_NodeStats_NodeInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RestOption        ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
-	Filename          string                   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	Filesize          int64                    `protobuf:"varint,3,opt,name=filesize,proto3" json:"filesize,omitempty"`
-	OptionalChunkSize int64                    `protobuf:"varint,4,opt,name=optional_chunk_size,json=optionalChunkSize,proto3" json:"optional_chunk_size,omitempty"`
+	NodeId             string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DiskSpace          int64  `protobuf:"varint,2,opt,name=disk_space,json=diskSpace,proto3" json:"disk_space,omitempty"`
+	NumRequestsHandled int64  `protobuf:"varint,3,opt,name=num_requests_handled,json=numRequestsHandled,proto3" json:"num_requests_handled,omitempty"`
 }
 
-func (x *ClientMessage_PutRequest) Reset() {
+func (x *ControllerMessage_NodeStats_NodeInfo) Reset() {
+	*x = ControllerMessage_NodeStats_NodeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_NodeStats_NodeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_NodeStats_NodeInfo) ProtoMessage() {}
+
+func (x *ControllerMessage_NodeStats_NodeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_NodeStats_NodeInfo.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_NodeStats_NodeInfo) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 4, 0}
+}
+
+func (x *ControllerMessage_NodeStats_NodeInfo) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *ControllerMessage_NodeStats_NodeInfo) GetDiskSpace() int64 {
+	if x != nil {
+		return x.DiskSpace
+	}
+	return 0
+}
+
+func (x *ControllerMessage_NodeStats_NodeInfo) GetNumRequestsHandled() int64 {
+	if x != nil {
+		return x.NumRequestsHandled
+	}
+	return 0
+}
+
+type ControllerMessage_LsResponse_Entry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dir  bool   `protobuf:"varint,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	// Set for files
+	Size         int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	NumFragments uint32 `protobuf:"varint,4,opt,name=num_fragments,json=numFragments,proto3" json:"num_fragments,omitempty"`
+	// A fragment of the file has fewer replicas on live nodes than the replication factor asks for
+	UnderReplicated bool `protobuf:"varint,5,opt,name=under_replicated,json=underReplicated,proto3" json:"under_replicated,omitempty"`
+}
+
+func (x *ControllerMessage_LsResponse_Entry) Reset() {
+	*x = ControllerMessage_LsResponse_Entry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_LsResponse_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_LsResponse_Entry) ProtoMessage() {}
+
+func (x *ControllerMessage_LsResponse_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_LsResponse_Entry.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_LsResponse_Entry) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 5, 0}
+}
+
+func (x *ControllerMessage_LsResponse_Entry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ControllerMessage_LsResponse_Entry) GetDir() bool {
+	if x != nil {
+		return x.Dir
+	}
+	return false
+}
+
+func (x *ControllerMessage_LsResponse_Entry) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ControllerMessage_LsResponse_Entry) GetNumFragments() uint32 {
+	if x != nil {
+		return x.NumFragments
+	}
+	return 0
+}
+
+func (x *ControllerMessage_LsResponse_Entry) GetUnderReplicated() bool {
+	if x != nil {
+		return x.UnderReplicated
+	}
+	return false
+}
+
+type ControllerMessage_StatResponse_StorageNodeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StorageNodeId string `protobuf:"bytes,1,opt,name=storage_node_id,json=storageNodeId,proto3" json:"storage_node_id,omitempty"`
+	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          string `protobuf:"bytes,3,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (x *ControllerMessage_StatResponse_StorageNodeInfo) Reset() {
+	*x = ControllerMessage_StatResponse_StorageNodeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_StatResponse_StorageNodeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_StatResponse_StorageNodeInfo) ProtoMessage() {}
+
+func (x *ControllerMessage_StatResponse_StorageNodeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_StatResponse_StorageNodeInfo.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_StatResponse_StorageNodeInfo) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 8, 0}
+}
+
+func (x *ControllerMessage_StatResponse_StorageNodeInfo) GetStorageNodeId() string {
+	if x != nil {
+		return x.StorageNodeId
+	}
+	return ""
+}
+
+func (x *ControllerMessage_StatResponse_StorageNodeInfo) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ControllerMessage_StatResponse_StorageNodeInfo) GetPort() string {
+	if x != nil {
+		return x.Port
+	}
+	return ""
+}
+
+type ControllerMessage_StatResponse_FragmentInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The block id the fragment is stored under
+	FragmentId string `protobuf:"bytes,1,opt,name=fragment_id,json=fragmentId,proto3" json:"fragment_id,omitempty"`
+	// The position of the fragment in the file
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Size  int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// The live nodes holding a replica
+	Replicas []*ControllerMessage_StatResponse_StorageNodeInfo `protobuf:"bytes,4,rep,name=replicas,proto3" json:"replicas,omitempty"`
+	Health   ControllerMessage_StatResponse_FragmentHealth     `protobuf:"varint,5,opt,name=health,proto3,enum=ControllerMessage_StatResponse_FragmentHealth" json:"health,omitempty"`
+}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) Reset() {
+	*x = ControllerMessage_StatResponse_FragmentInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerMessage_StatResponse_FragmentInfo) ProtoMessage() {}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerMessage_StatResponse_FragmentInfo.ProtoReflect.Descriptor instead.
+func (*ControllerMessage_StatResponse_FragmentInfo) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{0, 8, 1}
+}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) GetFragmentId() string {
+	if x != nil {
+		return x.FragmentId
+	}
+	return ""
+}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) GetReplicas() []*ControllerMessage_StatResponse_StorageNodeInfo {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *ControllerMessage_StatResponse_FragmentInfo) GetHealth() ControllerMessage_StatResponse_FragmentHealth {
+	if x != nil {
+		return x.Health
+	}
+	return ControllerMessage_StatResponse_HEALTHY
+}
+
+type ClientMessage_PutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestOption        ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	Filename          string                   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Filesize          int64                    `protobuf:"varint,3,opt,name=filesize,proto3" json:"filesize,omitempty"`
+	OptionalChunkSize int64                    `protobuf:"varint,4,opt,name=optional_chunk_size,json=optionalChunkSize,proto3" json:"optional_chunk_size,omitempty"`
+	// 0 uses the controller's default
+	ReplicationFactor uint32 `protobuf:"varint,5,opt,name=replication_factor,json=replicationFactor,proto3" json:"replication_factor,omitempty"`
+	// Both set stores the file erasure coded instead of replicated
+	DataShards   uint32 `protobuf:"varint,6,opt,name=data_shards,json=dataShards,proto3" json:"data_shards,omitempty"`
+	ParityShards uint32 `protobuf:"varint,7,opt,name=parity_shards,json=parityShards,proto3" json:"parity_shards,omitempty"`
+	// Set to resume an interrupted PUT instead of planning a new one
+	UploadId string `protobuf:"bytes,8,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// The user storing the file
+	Owner string `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (x *ClientMessage_PutRequest) Reset() {
 	*x = ClientMessage_PutRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[12]
+		mi := &file_controller_client_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1001,7 +1950,7 @@ func (x *ClientMessage_PutRequest) String() string {
 func (*ClientMessage_PutRequest) ProtoMessage() {}
 
 func (x *ClientMessage_PutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[12]
+	mi := &file_controller_client_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1017,60 +1966,418 @@ func (*ClientMessage_PutRequest) Descriptor() ([]byte, []int) {
 	return file_controller_client_proto_rawDescGZIP(), []int{1, 0}
 }
 
-func (x *ClientMessage_PutRequest) GetRestOption() ClientMessage_RestOption {
+func (x *ClientMessage_PutRequest) GetRestOption() ClientMessage_RestOption {
+	if x != nil {
+		return x.RestOption
+	}
+	return ClientMessage_GET
+}
+
+func (x *ClientMessage_PutRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ClientMessage_PutRequest) GetFilesize() int64 {
+	if x != nil {
+		return x.Filesize
+	}
+	return 0
+}
+
+func (x *ClientMessage_PutRequest) GetOptionalChunkSize() int64 {
+	if x != nil {
+		return x.OptionalChunkSize
+	}
+	return 0
+}
+
+func (x *ClientMessage_PutRequest) GetReplicationFactor() uint32 {
+	if x != nil {
+		return x.ReplicationFactor
+	}
+	return 0
+}
+
+func (x *ClientMessage_PutRequest) GetDataShards() uint32 {
+	if x != nil {
+		return x.DataShards
+	}
+	return 0
+}
+
+func (x *ClientMessage_PutRequest) GetParityShards() uint32 {
+	if x != nil {
+		return x.ParityShards
+	}
+	return 0
+}
+
+func (x *ClientMessage_PutRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *ClientMessage_PutRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type ClientMessage_GetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	FileName   string                   `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Reads only bytes [offset, offset + length) of the file. A length of 0 reads to the end
+	Offset int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length int64 `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *ClientMessage_GetRequest) Reset() {
+	*x = ClientMessage_GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientMessage_GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage_GetRequest) ProtoMessage() {}
+
+func (x *ClientMessage_GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage_GetRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_GetRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *ClientMessage_GetRequest) GetRestOption() ClientMessage_RestOption {
+	if x != nil {
+		return x.RestOption
+	}
+	return ClientMessage_GET
+}
+
+func (x *ClientMessage_GetRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ClientMessage_GetRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ClientMessage_GetRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type ClientMessage_DeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	Filename   string                   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+}
+
+func (x *ClientMessage_DeleteRequest) Reset() {
+	*x = ClientMessage_DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientMessage_DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage_DeleteRequest) ProtoMessage() {}
+
+func (x *ClientMessage_DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage_DeleteRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *ClientMessage_DeleteRequest) GetRestOption() ClientMessage_RestOption {
+	if x != nil {
+		return x.RestOption
+	}
+	return ClientMessage_GET
+}
+
+func (x *ClientMessage_DeleteRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type ClientMessage_LsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	// The directory to list, the root when empty
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Lists only the entries whose name matches this glob, or starts with it when it has no *, ? or [
+	Pattern string `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// The next_page_token of the previous page, empty for the first one
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// The most entries the page holds, 0 for all of them
+	Limit uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ClientMessage_LsRequest) Reset() {
+	*x = ClientMessage_LsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientMessage_LsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage_LsRequest) ProtoMessage() {}
+
+func (x *ClientMessage_LsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage_LsRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_LsRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 3}
+}
+
+func (x *ClientMessage_LsRequest) GetRestOption() ClientMessage_RestOption {
+	if x != nil {
+		return x.RestOption
+	}
+	return ClientMessage_GET
+}
+
+func (x *ClientMessage_LsRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ClientMessage_LsRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *ClientMessage_LsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ClientMessage_LsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ClientMessage_NodeStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+}
+
+func (x *ClientMessage_NodeStatsRequest) Reset() {
+	*x = ClientMessage_NodeStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientMessage_NodeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage_NodeStatsRequest) ProtoMessage() {}
+
+func (x *ClientMessage_NodeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage_NodeStatsRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_NodeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *ClientMessage_NodeStatsRequest) GetRestOption() ClientMessage_RestOption {
+	if x != nil {
+		return x.RestOption
+	}
+	return ClientMessage_GET
+}
+
+// Sent once every fragment of a PUT was stored, makes the file visible
+type ClientMessage_CommitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	Filename   string                   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// SHA-256 of the whole file in hex
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *ClientMessage_CommitRequest) Reset() {
+	*x = ClientMessage_CommitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_client_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientMessage_CommitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage_CommitRequest) ProtoMessage() {}
+
+func (x *ClientMessage_CommitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage_CommitRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_CommitRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *ClientMessage_CommitRequest) GetRestOption() ClientMessage_RestOption {
 	if x != nil {
 		return x.RestOption
 	}
 	return ClientMessage_GET
 }
 
-func (x *ClientMessage_PutRequest) GetFilename() string {
+func (x *ClientMessage_CommitRequest) GetFilename() string {
 	if x != nil {
 		return x.Filename
 	}
 	return ""
 }
 
-func (x *ClientMessage_PutRequest) GetFilesize() int64 {
-	if x != nil {
-		return x.Filesize
-	}
-	return 0
-}
-
-func (x *ClientMessage_PutRequest) GetOptionalChunkSize() int64 {
+func (x *ClientMessage_CommitRequest) GetChecksum() string {
 	if x != nil {
-		return x.OptionalChunkSize
+		return x.Checksum
 	}
-	return 0
+	return ""
 }
 
-type ClientMessage_GetRequest struct {
+// Creates a directory, and the directories above it that do not exist yet
+type ClientMessage_MkdirRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
-	FileName   string                   `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Path       string                   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
 }
 
-func (x *ClientMessage_GetRequest) Reset() {
-	*x = ClientMessage_GetRequest{}
+func (x *ClientMessage_MkdirRequest) Reset() {
+	*x = ClientMessage_MkdirRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[13]
+		mi := &file_controller_client_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientMessage_GetRequest) String() string {
+func (x *ClientMessage_MkdirRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientMessage_GetRequest) ProtoMessage() {}
+func (*ClientMessage_MkdirRequest) ProtoMessage() {}
 
-func (x *ClientMessage_GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[13]
+func (x *ClientMessage_MkdirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1081,51 +2388,52 @@ func (x *ClientMessage_GetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientMessage_GetRequest.ProtoReflect.Descriptor instead.
-func (*ClientMessage_GetRequest) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{1, 1}
+// Deprecated: Use ClientMessage_MkdirRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_MkdirRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 6}
 }
 
-func (x *ClientMessage_GetRequest) GetRestOption() ClientMessage_RestOption {
+func (x *ClientMessage_MkdirRequest) GetRestOption() ClientMessage_RestOption {
 	if x != nil {
 		return x.RestOption
 	}
 	return ClientMessage_GET
 }
 
-func (x *ClientMessage_GetRequest) GetFileName() string {
+func (x *ClientMessage_MkdirRequest) GetPath() string {
 	if x != nil {
-		return x.FileName
+		return x.Path
 	}
 	return ""
 }
 
-type ClientMessage_DeleteRequest struct {
+// Removes an empty directory
+type ClientMessage_RmdirRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
-	Filename   string                   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Path       string                   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
 }
 
-func (x *ClientMessage_DeleteRequest) Reset() {
-	*x = ClientMessage_DeleteRequest{}
+func (x *ClientMessage_RmdirRequest) Reset() {
+	*x = ClientMessage_RmdirRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[14]
+		mi := &file_controller_client_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientMessage_DeleteRequest) String() string {
+func (x *ClientMessage_RmdirRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientMessage_DeleteRequest) ProtoMessage() {}
+func (*ClientMessage_RmdirRequest) ProtoMessage() {}
 
-func (x *ClientMessage_DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[14]
+func (x *ClientMessage_RmdirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1136,50 +2444,53 @@ func (x *ClientMessage_DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientMessage_DeleteRequest.ProtoReflect.Descriptor instead.
-func (*ClientMessage_DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{1, 2}
+// Deprecated: Use ClientMessage_RmdirRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_RmdirRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 7}
 }
 
-func (x *ClientMessage_DeleteRequest) GetRestOption() ClientMessage_RestOption {
+func (x *ClientMessage_RmdirRequest) GetRestOption() ClientMessage_RestOption {
 	if x != nil {
 		return x.RestOption
 	}
 	return ClientMessage_GET
 }
 
-func (x *ClientMessage_DeleteRequest) GetFilename() string {
+func (x *ClientMessage_RmdirRequest) GetPath() string {
 	if x != nil {
-		return x.Filename
+		return x.Path
 	}
 	return ""
 }
 
-type ClientMessage_LsRequest struct {
+// Moves a file, or a directory with everything below it. Only metadata changes, no data is copied
+type ClientMessage_RenameRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	RestOption  ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	Source      string                   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string                   `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
 }
 
-func (x *ClientMessage_LsRequest) Reset() {
-	*x = ClientMessage_LsRequest{}
+func (x *ClientMessage_RenameRequest) Reset() {
+	*x = ClientMessage_RenameRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[15]
+		mi := &file_controller_client_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientMessage_LsRequest) String() string {
+func (x *ClientMessage_RenameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientMessage_LsRequest) ProtoMessage() {}
+func (*ClientMessage_RenameRequest) ProtoMessage() {}
 
-func (x *ClientMessage_LsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[15]
+func (x *ClientMessage_RenameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1190,43 +2501,59 @@ func (x *ClientMessage_LsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientMessage_LsRequest.ProtoReflect.Descriptor instead.
-func (*ClientMessage_LsRequest) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{1, 3}
+// Deprecated: Use ClientMessage_RenameRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_RenameRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 8}
 }
 
-func (x *ClientMessage_LsRequest) GetRestOption() ClientMessage_RestOption {
+func (x *ClientMessage_RenameRequest) GetRestOption() ClientMessage_RestOption {
 	if x != nil {
 		return x.RestOption
 	}
 	return ClientMessage_GET
 }
 
-type ClientMessage_NodeStatsRequest struct {
+func (x *ClientMessage_RenameRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ClientMessage_RenameRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+// Describes a file: its layout, where its fragments are and how healthy they are
+type ClientMessage_StatRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	RestOption ClientMessage_RestOption `protobuf:"varint,1,opt,name=rest_option,json=restOption,proto3,enum=ClientMessage_RestOption" json:"rest_option,omitempty"`
+	Filename   string                   `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *ClientMessage_NodeStatsRequest) Reset() {
-	*x = ClientMessage_NodeStatsRequest{}
+func (x *ClientMessage_StatRequest) Reset() {
+	*x = ClientMessage_StatRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_controller_client_proto_msgTypes[16]
+		mi := &file_controller_client_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientMessage_NodeStatsRequest) String() string {
+func (x *ClientMessage_StatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientMessage_NodeStatsRequest) ProtoMessage() {}
+func (*ClientMessage_StatRequest) ProtoMessage() {}
 
-func (x *ClientMessage_NodeStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_controller_client_proto_msgTypes[16]
+func (x *ClientMessage_StatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_client_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1237,23 +2564,30 @@ func (x *ClientMessage_NodeStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientMessage_NodeStatsRequest.ProtoReflect.Descriptor instead.
-func (*ClientMessage_NodeStatsRequest) Descriptor() ([]byte, []int) {
-	return file_controller_client_proto_rawDescGZIP(), []int{1, 4}
+// Deprecated: Use ClientMessage_StatRequest.ProtoReflect.Descriptor instead.
+func (*ClientMessage_StatRequest) Descriptor() ([]byte, []int) {
+	return file_controller_client_proto_rawDescGZIP(), []int{1, 9}
 }
 
-func (x *ClientMessage_NodeStatsRequest) GetRestOption() ClientMessage_RestOption {
+func (x *ClientMessage_StatRequest) GetRestOption() ClientMessage_RestOption {
 	if x != nil {
 		return x.RestOption
 	}
 	return ClientMessage_GET
 }
 
+func (x *ClientMessage_StatRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
 var File_controller_client_proto protoreflect.FileDescriptor
 
 var file_controller_client_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x94, 0x0f, 0x0a, 0x11, 0x43, 0x6f,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa4, 0x23, 0x0a, 0x11, 0x43, 0x6f,
 	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
 	0x46, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
@@ -1278,36 +2612,70 @@ var file_controller_client_proto_rawDesc = []byte{
 	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
 	0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53,
 	0x74, 0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x1a, 0xd9, 0x03, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x5f,
-	0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x12, 0x55, 0x0a, 0x0f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6c,
-	0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x43, 0x6f,
+	0x73, 0x12, 0x4c, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
+	0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x55, 0x0a, 0x12, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x11, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
+	0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0xb4,
+	0x01, 0x0a, 0x0d, 0x45, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x53, 0x68, 0x61, 0x72, 0x64,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x69, 0x74, 0x79,
+	0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x73, 0x4a,
+	0x04, 0x08, 0x05, 0x10, 0x06, 0x1a, 0xd5, 0x04, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x43, 0x6f,
 	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
-	0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x72, 0x61,
-	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x66, 0x72, 0x61, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x1a, 0x61, 0x0a, 0x0f, 0x53, 0x74, 0x6f,
-	0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x0f,
-	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f,
-	0x64, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x9e, 0x01, 0x0a,
-	0x0c, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x0a,
-	0x0b, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69,
-	0x7a, 0x65, 0x12, 0x59, 0x0a, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f,
-	0x64, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x43,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x73,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x1a, 0xeb, 0x03,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x6e, 0x75, 0x6d, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x46, 0x72, 0x61,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x55, 0x0a, 0x0f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x66,
+	0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x47, 0x0a,
+	0x0e, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65,
+	0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x49, 0x64, 0x1a, 0x61, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0xb4, 0x01, 0x0a, 0x0c, 0x46, 0x72, 0x61, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x61, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72,
+	0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x59, 0x0a, 0x10,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x1a, 0xdd, 0x05,
 	0x0a, 0x12, 0x46, 0x72, 0x61, 0x67, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
 	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
@@ -1322,28 +2690,57 @@ var file_controller_client_proto_rawDesc = []byte{
 	0x65, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66,
 	0x6f, 0x52, 0x0e, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x61, 0x79, 0x6f, 0x75,
-	0x74, 0x1a, 0x61, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f,
-	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x70, 0x6f, 0x72, 0x74, 0x1a, 0xa4, 0x01, 0x0a, 0x0c, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x73, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
-	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x4c, 0x61, 0x79,
-	0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x73, 0x74, 0x6f,
-	0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x1a, 0x50, 0x0a, 0x0e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
-	0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64,
-	0x65, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x8b, 0x02,
+	0x74, 0x12, 0x47, 0x0a, 0x0e, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x72,
+	0x61, 0x73, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x65, 0x72, 0x61,
+	0x73, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x1a, 0x61, 0x0a,
+	0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x26, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x1a, 0xea, 0x01, 0x0a, 0x0c, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x35, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x1a, 0xad, 0x02,
+	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x58, 0x0a, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x1a, 0x80, 0x01, 0x0a, 0x0d, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x1f, 0x0a, 0x0b,
+	0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x26, 0x0a,
+	0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x8b, 0x02,
 	0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x73,
 	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73,
@@ -1360,22 +2757,120 @@ var file_controller_client_proto_rawDesc = []byte{
 	0x64, 0x69, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x75, 0x6d,
 	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65,
 	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x64, 0x1a, 0x6b, 0x0a, 0x0a, 0x4c,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x65, 0x73, 0x74, 0x73, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x64, 0x1a, 0x83, 0x03, 0x0a, 0x0a,
+	0x4c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x91, 0x01,
+	0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64,
+	0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x64, 0x69, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d, 0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x46, 0x72, 0x61,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x5f,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x1a, 0x50, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
+	0x6f, 0x64, 0x65, 0x1a, 0x53, 0x0a, 0x11, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0xe9, 0x06, 0x0a, 0x0c, 0x53, 0x74, 0x61,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x74, 0x61,
 	0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d,
 	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c,
-	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66,
-	0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x60, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09,
-	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x4c,
-	0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x17, 0x0a,
-	0x13, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45, 0x58,
-	0x49, 0x53, 0x54, 0x53, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x54,
-	0x4f, 0x4f, 0x5f, 0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x04, 0x42, 0x14, 0x0a, 0x12, 0x63, 0x6f,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x75, 0x6d,
+	0x5f, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2d,
+	0x0a, 0x12, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x72, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x47, 0x0a,
+	0x0e, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65,
+	0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x12, 0x4a, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x09, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x61,
+	0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72,
+	0x74, 0x1a, 0xee, 0x01, 0x0a, 0x0c, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x4b, 0x0a,
+	0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x46, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x72, 0x61, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x22, 0x40, 0x0a, 0x0e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x12, 0x0b, 0x0a, 0x07, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x10,
+	0x00, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e, 0x44, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x49,
+	0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x49, 0x53, 0x53, 0x49,
+	0x4e, 0x47, 0x10, 0x02, 0x22, 0xd3, 0x02, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x4e,
+	0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x46, 0x49,
+	0x4c, 0x45, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54,
+	0x53, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x54, 0x4f, 0x4f, 0x5f,
+	0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x04, 0x12, 0x1e, 0x0a, 0x1a, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46,
+	0x41, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x4e, 0x4f, 0x54, 0x5f, 0x45,
+	0x4e, 0x4f, 0x55, 0x47, 0x48, 0x5f, 0x4e, 0x4f, 0x44, 0x45, 0x53, 0x10, 0x06, 0x12, 0x1a, 0x0a,
+	0x16, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x45, 0x52, 0x41, 0x53, 0x55, 0x52, 0x45,
+	0x5f, 0x43, 0x4f, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x4e, 0x4f, 0x54,
+	0x5f, 0x45, 0x4e, 0x4f, 0x55, 0x47, 0x48, 0x5f, 0x53, 0x50, 0x41, 0x43, 0x45, 0x10, 0x08, 0x12,
+	0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45,
+	0x10, 0x09, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x4f, 0x54, 0x5f, 0x41, 0x5f, 0x44, 0x49, 0x52, 0x45,
+	0x43, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x53, 0x5f, 0x41, 0x5f,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x0b, 0x12, 0x17, 0x0a, 0x13, 0x44,
+	0x49, 0x52, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4d, 0x50,
+	0x54, 0x59, 0x10, 0x0c, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x50, 0x41, 0x54, 0x48, 0x10, 0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x42,
+	0x55, 0x53, 0x59, 0x10, 0x0e, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44,
+	0x5f, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x10, 0x0f, 0x42, 0x14, 0x0a, 0x12, 0x63, 0x6f,
 	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0xd0, 0x07, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x22, 0xd1, 0x11, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x70, 0x75, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
@@ -1397,48 +2892,175 @@ var file_controller_client_proto_rawDesc = []byte{
 	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x6f, 0x64,
 	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
 	0x10, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0xb0, 0x01, 0x0a, 0x0a, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
-	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x73, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
-	0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b,
-	0x53, 0x69, 0x7a, 0x65, 0x1a, 0x65, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x74, 0x12, 0x45, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x0d, 0x6d, 0x6b, 0x64, 0x69,
+	0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x4d, 0x6b, 0x64, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c,
+	0x6d, 0x6b, 0x64, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x0d,
+	0x72, 0x6d, 0x64, 0x69, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x52, 0x6d, 0x64, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0c, 0x72, 0x6d, 0x64, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x45, 0x0a, 0x0e, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x61,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0xd8, 0x02, 0x0a, 0x0a, 0x50, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x72,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x64, 0x61, 0x74, 0x61, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x70,
+	0x61, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x69, 0x74, 0x79, 0x53, 0x68, 0x61, 0x72, 0x64, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x1a, 0x95, 0x01, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
 	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69,
 	0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
 	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x67, 0x0a, 0x0d, 0x44,
+	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x1a, 0x67, 0x0a, 0x0d, 0x44,
 	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b,
 	0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
 	0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65,
 	0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x47, 0x0a, 0x09, 0x4c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x4e, 0x0a,
-	0x10, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x42, 0x0a,
-	0x0a, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x07, 0x0a, 0x03, 0x47,
-	0x45, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a,
-	0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x53, 0x10,
-	0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x53, 0x10,
-	0x04, 0x42, 0x10, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x42, 0x1e, 0x5a, 0x1c, 0x2e, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x61, 0x6d, 0x65, 0x1a, 0xaa, 0x01, 0x0a, 0x09, 0x4c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x1a, 0x4e, 0x0a, 0x10, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x1a, 0x83, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x1a, 0x5e, 0x0a, 0x0c, 0x4d, 0x6b, 0x64, 0x69, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x1a, 0x5e, 0x0a, 0x0c, 0x52, 0x6d, 0x64, 0x69, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x1a, 0x85, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73,
+	0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52,
+	0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
+	0x65, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a,
+	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x72, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x7a, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x74, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a,
+	0x03, 0x50, 0x55, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45,
+	0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x53, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x4f,
+	0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x53, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4f,
+	0x4d, 0x4d, 0x49, 0x54, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x4b, 0x44, 0x49, 0x52, 0x10,
+	0x06, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x4d, 0x44, 0x49, 0x52, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06,
+	0x52, 0x45, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x08, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x41, 0x54,
+	0x10, 0x09, 0x42, 0x10, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x32, 0xed, 0x05, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x04, 0x50, 0x6c,
+	0x61, 0x6e, 0x12, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a,
+	0x0a, 0x06, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x19, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x4c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x06, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x12, 0x1c, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x46, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x05, 0x4d, 0x6b, 0x64,
+	0x69, 0x72, 0x12, 0x1b, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x4d, 0x6b, 0x64, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x24, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x05, 0x52, 0x6d, 0x64, 0x69, 0x72, 0x12, 0x1b,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52,
+	0x6d, 0x64, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4c, 0x0a, 0x06, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x43, 0x0a, 0x04, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1a, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1e, 0x5a, 0x1c, 0x2e, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1453,60 +3075,118 @@ func file_controller_client_proto_rawDescGZIP() []byte {
 	return file_controller_client_proto_rawDescData
 }
 
-var file_controller_client_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_controller_client_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_controller_client_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_controller_client_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_controller_client_proto_goTypes = []interface{}{
 	(ControllerMessage_StatusCode)(0),                            // 0: ControllerMessage.StatusCode
-	(ClientMessage_RestOption)(0),                                // 1: ClientMessage.RestOption
-	(*ControllerMessage)(nil),                                    // 2: ControllerMessage
-	(*ClientMessage)(nil),                                        // 3: ClientMessage
-	(*ControllerMessage_PlanResponse)(nil),                       // 4: ControllerMessage.PlanResponse
-	(*ControllerMessage_FragLayoutResponse)(nil),                 // 5: ControllerMessage.FragLayoutResponse
-	(*ControllerMessage_DeleteResponse)(nil),                     // 6: ControllerMessage.DeleteResponse
-	(*ControllerMessage_NodeStats)(nil),                          // 7: ControllerMessage.NodeStats
-	(*ControllerMessage_LsResponse)(nil),                         // 8: ControllerMessage.LsResponse
-	(*ControllerMessage_PlanResponse_StorageNodeInfo)(nil),       // 9: ControllerMessage.PlanResponse.StorageNodeInfo
-	(*ControllerMessage_PlanResponse_FragmentInfo)(nil),          // 10: ControllerMessage.PlanResponse.FragmentInfo
-	(*ControllerMessage_FragLayoutResponse_StorageNodeInfo)(nil), // 11: ControllerMessage.FragLayoutResponse.StorageNodeInfo
-	(*ControllerMessage_FragLayoutResponse_FragmentInfo)(nil),    // 12: ControllerMessage.FragLayoutResponse.FragmentInfo
-	(*ControllerMessage_NodeStats_NodeInfo)(nil),                 // 13: ControllerMessage.NodeStats.NodeInfo
-	(*ClientMessage_PutRequest)(nil),                             // 14: ClientMessage.PutRequest
-	(*ClientMessage_GetRequest)(nil),                             // 15: ClientMessage.GetRequest
-	(*ClientMessage_DeleteRequest)(nil),                          // 16: ClientMessage.DeleteRequest
-	(*ClientMessage_LsRequest)(nil),                              // 17: ClientMessage.LsRequest
-	(*ClientMessage_NodeStatsRequest)(nil),                       // 18: ClientMessage.NodeStatsRequest
+	(ControllerMessage_StatResponse_FragmentHealth)(0),           // 1: ControllerMessage.StatResponse.FragmentHealth
+	(ClientMessage_RestOption)(0),                                // 2: ClientMessage.RestOption
+	(*ControllerMessage)(nil),                                    // 3: ControllerMessage
+	(*ClientMessage)(nil),                                        // 4: ClientMessage
+	(*ControllerMessage_ErasureCoding)(nil),                      // 5: ControllerMessage.ErasureCoding
+	(*ControllerMessage_PlanResponse)(nil),                       // 6: ControllerMessage.PlanResponse
+	(*ControllerMessage_FragLayoutResponse)(nil),                 // 7: ControllerMessage.FragLayoutResponse
+	(*ControllerMessage_DeleteResponse)(nil),                     // 8: ControllerMessage.DeleteResponse
+	(*ControllerMessage_NodeStats)(nil),                          // 9: ControllerMessage.NodeStats
+	(*ControllerMessage_LsResponse)(nil),                         // 10: ControllerMessage.LsResponse
+	(*ControllerMessage_CommitResponse)(nil),                     // 11: ControllerMessage.CommitResponse
+	(*ControllerMessage_NamespaceResponse)(nil),                  // 12: ControllerMessage.NamespaceResponse
+	(*ControllerMessage_StatResponse)(nil),                       // 13: ControllerMessage.StatResponse
+	(*ControllerMessage_PlanResponse_StorageNodeInfo)(nil),       // 14: ControllerMessage.PlanResponse.StorageNodeInfo
+	(*ControllerMessage_PlanResponse_FragmentInfo)(nil),          // 15: ControllerMessage.PlanResponse.FragmentInfo
+	(*ControllerMessage_FragLayoutResponse_StorageNodeInfo)(nil), // 16: ControllerMessage.FragLayoutResponse.StorageNodeInfo
+	(*ControllerMessage_FragLayoutResponse_FragmentInfo)(nil),    // 17: ControllerMessage.FragLayoutResponse.FragmentInfo
+	(*ControllerMessage_DeleteResponse_FailedReplica)(nil),       // 18: ControllerMessage.DeleteResponse.FailedReplica
+	(*ControllerMessage_NodeStats_NodeInfo)(nil),                 // 19: ControllerMessage.NodeStats.NodeInfo
+	(*ControllerMessage_LsResponse_Entry)(nil),                   // 20: ControllerMessage.LsResponse.Entry
+	(*ControllerMessage_StatResponse_StorageNodeInfo)(nil),       // 21: ControllerMessage.StatResponse.StorageNodeInfo
+	(*ControllerMessage_StatResponse_FragmentInfo)(nil),          // 22: ControllerMessage.StatResponse.FragmentInfo
+	(*ClientMessage_PutRequest)(nil),                             // 23: ClientMessage.PutRequest
+	(*ClientMessage_GetRequest)(nil),                             // 24: ClientMessage.GetRequest
+	(*ClientMessage_DeleteRequest)(nil),                          // 25: ClientMessage.DeleteRequest
+	(*ClientMessage_LsRequest)(nil),                              // 26: ClientMessage.LsRequest
+	(*ClientMessage_NodeStatsRequest)(nil),                       // 27: ClientMessage.NodeStatsRequest
+	(*ClientMessage_CommitRequest)(nil),                          // 28: ClientMessage.CommitRequest
+	(*ClientMessage_MkdirRequest)(nil),                           // 29: ClientMessage.MkdirRequest
+	(*ClientMessage_RmdirRequest)(nil),                           // 30: ClientMessage.RmdirRequest
+	(*ClientMessage_RenameRequest)(nil),                          // 31: ClientMessage.RenameRequest
+	(*ClientMessage_StatRequest)(nil),                            // 32: ClientMessage.StatRequest
 }
 var file_controller_client_proto_depIdxs = []int32{
-	4,  // 0: ControllerMessage.plan_response:type_name -> ControllerMessage.PlanResponse
-	5,  // 1: ControllerMessage.frag_layout_response:type_name -> ControllerMessage.FragLayoutResponse
-	6,  // 2: ControllerMessage.delete_response:type_name -> ControllerMessage.DeleteResponse
-	8,  // 3: ControllerMessage.ls_response:type_name -> ControllerMessage.LsResponse
-	7,  // 4: ControllerMessage.node_stats:type_name -> ControllerMessage.NodeStats
-	14, // 5: ClientMessage.put_request:type_name -> ClientMessage.PutRequest
-	15, // 6: ClientMessage.get_request:type_name -> ClientMessage.GetRequest
-	16, // 7: ClientMessage.delete_request:type_name -> ClientMessage.DeleteRequest
-	17, // 8: ClientMessage.ls_request:type_name -> ClientMessage.LsRequest
-	18, // 9: ClientMessage.node_stats_request:type_name -> ClientMessage.NodeStatsRequest
-	0,  // 10: ControllerMessage.PlanResponse.status_code:type_name -> ControllerMessage.StatusCode
-	10, // 11: ControllerMessage.PlanResponse.fragment_layout:type_name -> ControllerMessage.PlanResponse.FragmentInfo
-	0,  // 12: ControllerMessage.FragLayoutResponse.status_code:type_name -> ControllerMessage.StatusCode
-	12, // 13: ControllerMessage.FragLayoutResponse.fragment_layout:type_name -> ControllerMessage.FragLayoutResponse.FragmentInfo
-	0,  // 14: ControllerMessage.DeleteResponse.status_code:type_name -> ControllerMessage.StatusCode
-	0,  // 15: ControllerMessage.NodeStats.status_code:type_name -> ControllerMessage.StatusCode
-	13, // 16: ControllerMessage.NodeStats.active_nodes:type_name -> ControllerMessage.NodeStats.NodeInfo
-	0,  // 17: ControllerMessage.LsResponse.status_code:type_name -> ControllerMessage.StatusCode
-	9,  // 18: ControllerMessage.PlanResponse.FragmentInfo.storage_node_ids:type_name -> ControllerMessage.PlanResponse.StorageNodeInfo
-	11, // 19: ControllerMessage.FragLayoutResponse.FragmentInfo.storage_node_ids:type_name -> ControllerMessage.FragLayoutResponse.StorageNodeInfo
-	1,  // 20: ClientMessage.PutRequest.rest_option:type_name -> ClientMessage.RestOption
-	1,  // 21: ClientMessage.GetRequest.rest_option:type_name -> ClientMessage.RestOption
-	1,  // 22: ClientMessage.DeleteRequest.rest_option:type_name -> ClientMessage.RestOption
-	1,  // 23: ClientMessage.LsRequest.rest_option:type_name -> ClientMessage.RestOption
-	1,  // 24: ClientMessage.NodeStatsRequest.rest_option:type_name -> ClientMessage.RestOption
-	25, // [25:25] is the sub-list for method output_type
-	25, // [25:25] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	6,  // 0: ControllerMessage.plan_response:type_name -> ControllerMessage.PlanResponse
+	7,  // 1: ControllerMessage.frag_layout_response:type_name -> ControllerMessage.FragLayoutResponse
+	8,  // 2: ControllerMessage.delete_response:type_name -> ControllerMessage.DeleteResponse
+	10, // 3: ControllerMessage.ls_response:type_name -> ControllerMessage.LsResponse
+	9,  // 4: ControllerMessage.node_stats:type_name -> ControllerMessage.NodeStats
+	11, // 5: ControllerMessage.commit_response:type_name -> ControllerMessage.CommitResponse
+	12, // 6: ControllerMessage.namespace_response:type_name -> ControllerMessage.NamespaceResponse
+	13, // 7: ControllerMessage.stat_response:type_name -> ControllerMessage.StatResponse
+	23, // 8: ClientMessage.put_request:type_name -> ClientMessage.PutRequest
+	24, // 9: ClientMessage.get_request:type_name -> ClientMessage.GetRequest
+	25, // 10: ClientMessage.delete_request:type_name -> ClientMessage.DeleteRequest
+	26, // 11: ClientMessage.ls_request:type_name -> ClientMessage.LsRequest
+	27, // 12: ClientMessage.node_stats_request:type_name -> ClientMessage.NodeStatsRequest
+	28, // 13: ClientMessage.commit_request:type_name -> ClientMessage.CommitRequest
+	29, // 14: ClientMessage.mkdir_request:type_name -> ClientMessage.MkdirRequest
+	30, // 15: ClientMessage.rmdir_request:type_name -> ClientMessage.RmdirRequest
+	31, // 16: ClientMessage.rename_request:type_name -> ClientMessage.RenameRequest
+	32, // 17: ClientMessage.stat_request:type_name -> ClientMessage.StatRequest
+	0,  // 18: ControllerMessage.PlanResponse.status_code:type_name -> ControllerMessage.StatusCode
+	15, // 19: ControllerMessage.PlanResponse.fragment_layout:type_name -> ControllerMessage.PlanResponse.FragmentInfo
+	5,  // 20: ControllerMessage.PlanResponse.erasure_coding:type_name -> ControllerMessage.ErasureCoding
+	0,  // 21: ControllerMessage.FragLayoutResponse.status_code:type_name -> ControllerMessage.StatusCode
+	17, // 22: ControllerMessage.FragLayoutResponse.fragment_layout:type_name -> ControllerMessage.FragLayoutResponse.FragmentInfo
+	5,  // 23: ControllerMessage.FragLayoutResponse.erasure_coding:type_name -> ControllerMessage.ErasureCoding
+	0,  // 24: ControllerMessage.DeleteResponse.status_code:type_name -> ControllerMessage.StatusCode
+	18, // 25: ControllerMessage.DeleteResponse.failed_replicas:type_name -> ControllerMessage.DeleteResponse.FailedReplica
+	0,  // 26: ControllerMessage.NodeStats.status_code:type_name -> ControllerMessage.StatusCode
+	19, // 27: ControllerMessage.NodeStats.active_nodes:type_name -> ControllerMessage.NodeStats.NodeInfo
+	0,  // 28: ControllerMessage.LsResponse.status_code:type_name -> ControllerMessage.StatusCode
+	20, // 29: ControllerMessage.LsResponse.entries:type_name -> ControllerMessage.LsResponse.Entry
+	0,  // 30: ControllerMessage.CommitResponse.status_code:type_name -> ControllerMessage.StatusCode
+	0,  // 31: ControllerMessage.NamespaceResponse.status_code:type_name -> ControllerMessage.StatusCode
+	0,  // 32: ControllerMessage.StatResponse.status_code:type_name -> ControllerMessage.StatusCode
+	5,  // 33: ControllerMessage.StatResponse.erasure_coding:type_name -> ControllerMessage.ErasureCoding
+	22, // 34: ControllerMessage.StatResponse.fragments:type_name -> ControllerMessage.StatResponse.FragmentInfo
+	14, // 35: ControllerMessage.PlanResponse.FragmentInfo.storage_node_ids:type_name -> ControllerMessage.PlanResponse.StorageNodeInfo
+	16, // 36: ControllerMessage.FragLayoutResponse.FragmentInfo.storage_node_ids:type_name -> ControllerMessage.FragLayoutResponse.StorageNodeInfo
+	21, // 37: ControllerMessage.StatResponse.FragmentInfo.replicas:type_name -> ControllerMessage.StatResponse.StorageNodeInfo
+	1,  // 38: ControllerMessage.StatResponse.FragmentInfo.health:type_name -> ControllerMessage.StatResponse.FragmentHealth
+	2,  // 39: ClientMessage.PutRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 40: ClientMessage.GetRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 41: ClientMessage.DeleteRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 42: ClientMessage.LsRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 43: ClientMessage.NodeStatsRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 44: ClientMessage.CommitRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 45: ClientMessage.MkdirRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 46: ClientMessage.RmdirRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 47: ClientMessage.RenameRequest.rest_option:type_name -> ClientMessage.RestOption
+	2,  // 48: ClientMessage.StatRequest.rest_option:type_name -> ClientMessage.RestOption
+	23, // 49: ControllerService.Plan:input_type -> ClientMessage.PutRequest
+	24, // 50: ControllerService.Layout:input_type -> ClientMessage.GetRequest
+	28, // 51: ControllerService.Commit:input_type -> ClientMessage.CommitRequest
+	26, // 52: ControllerService.List:input_type -> ClientMessage.LsRequest
+	25, // 53: ControllerService.Delete:input_type -> ClientMessage.DeleteRequest
+	27, // 54: ControllerService.Stats:input_type -> ClientMessage.NodeStatsRequest
+	29, // 55: ControllerService.Mkdir:input_type -> ClientMessage.MkdirRequest
+	30, // 56: ControllerService.Rmdir:input_type -> ClientMessage.RmdirRequest
+	31, // 57: ControllerService.Rename:input_type -> ClientMessage.RenameRequest
+	32, // 58: ControllerService.Stat:input_type -> ClientMessage.StatRequest
+	6,  // 59: ControllerService.Plan:output_type -> ControllerMessage.PlanResponse
+	7,  // 60: ControllerService.Layout:output_type -> ControllerMessage.FragLayoutResponse
+	11, // 61: ControllerService.Commit:output_type -> ControllerMessage.CommitResponse
+	10, // 62: ControllerService.List:output_type -> ControllerMessage.LsResponse
+	8,  // 63: ControllerService.Delete:output_type -> ControllerMessage.DeleteResponse
+	9,  // 64: ControllerService.Stats:output_type -> ControllerMessage.NodeStats
+	12, // 65: ControllerService.Mkdir:output_type -> ControllerMessage.NamespaceResponse
+	12, // 66: ControllerService.Rmdir:output_type -> ControllerMessage.NamespaceResponse
+	12, // 67: ControllerService.Rename:output_type -> ControllerMessage.NamespaceResponse
+	13, // 68: ControllerService.Stat:output_type -> ControllerMessage.StatResponse
+	59, // [59:69] is the sub-list for method output_type
+	49, // [49:59] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_controller_client_proto_init() }
@@ -1540,7 +3220,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_PlanResponse); i {
+			switch v := v.(*ControllerMessage_ErasureCoding); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1552,7 +3232,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_FragLayoutResponse); i {
+			switch v := v.(*ControllerMessage_PlanResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1564,7 +3244,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_DeleteResponse); i {
+			switch v := v.(*ControllerMessage_FragLayoutResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1576,7 +3256,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_NodeStats); i {
+			switch v := v.(*ControllerMessage_DeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1588,7 +3268,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_LsResponse); i {
+			switch v := v.(*ControllerMessage_NodeStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1600,7 +3280,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_PlanResponse_StorageNodeInfo); i {
+			switch v := v.(*ControllerMessage_LsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1612,7 +3292,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_PlanResponse_FragmentInfo); i {
+			switch v := v.(*ControllerMessage_CommitResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1624,7 +3304,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_FragLayoutResponse_StorageNodeInfo); i {
+			switch v := v.(*ControllerMessage_NamespaceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1636,7 +3316,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_FragLayoutResponse_FragmentInfo); i {
+			switch v := v.(*ControllerMessage_StatResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1648,7 +3328,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControllerMessage_NodeStats_NodeInfo); i {
+			switch v := v.(*ControllerMessage_PlanResponse_StorageNodeInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1660,7 +3340,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientMessage_PutRequest); i {
+			switch v := v.(*ControllerMessage_PlanResponse_FragmentInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1672,7 +3352,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientMessage_GetRequest); i {
+			switch v := v.(*ControllerMessage_FragLayoutResponse_StorageNodeInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1684,7 +3364,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientMessage_DeleteRequest); i {
+			switch v := v.(*ControllerMessage_FragLayoutResponse_FragmentInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1696,7 +3376,7 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientMessage_LsRequest); i {
+			switch v := v.(*ControllerMessage_DeleteResponse_FailedReplica); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1708,6 +3388,102 @@ func file_controller_client_proto_init() {
 			}
 		}
 		file_controller_client_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControllerMessage_NodeStats_NodeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControllerMessage_LsResponse_Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControllerMessage_StatResponse_StorageNodeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControllerMessage_StatResponse_FragmentInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_PutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_LsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientMessage_NodeStatsRequest); i {
 			case 0:
 				return &v.state
@@ -1719,6 +3495,66 @@ func file_controller_client_proto_init() {
 				return nil
 			}
 		}
+		file_controller_client_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_CommitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_MkdirRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_RmdirRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_RenameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_client_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage_StatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_controller_client_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*ControllerMessage_PlanResponse_)(nil),
@@ -1726,6 +3562,9 @@ func file_controller_client_proto_init() {
 		(*ControllerMessage_DeleteResponse_)(nil),
 		(*ControllerMessage_LsResponse_)(nil),
 		(*ControllerMessage_NodeStats_)(nil),
+		(*ControllerMessage_CommitResponse_)(nil),
+		(*ControllerMessage_NamespaceResponse_)(nil),
+		(*ControllerMessage_StatResponse_)(nil),
 	}
 	file_controller_client_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*ClientMessage_PutRequest_)(nil),
@@ -1733,16 +3572,21 @@ func file_controller_client_proto_init() {
 		(*ClientMessage_DeleteRequest_)(nil),
 		(*ClientMessage_LsRequest_)(nil),
 		(*ClientMessage_NodeStatsRequest_)(nil),
+		(*ClientMessage_CommitRequest_)(nil),
+		(*ClientMessage_MkdirRequest_)(nil),
+		(*ClientMessage_RmdirRequest_)(nil),
+		(*ClientMessage_RenameRequest_)(nil),
+		(*ClientMessage_StatRequest_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_controller_client_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   17,
+			NumEnums:      3,
+			NumMessages:   30,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_controller_client_proto_goTypes,
 		DependencyIndexes: file_controller_client_proto_depIdxs,
@@ -0,0 +1,429 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: controller_client.proto
+
+package controller_client
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ControllerServiceClient is the client API for ControllerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ControllerServiceClient interface {
+	Plan(ctx context.Context, in *ClientMessage_PutRequest, opts ...grpc.CallOption) (*ControllerMessage_PlanResponse, error)
+	Layout(ctx context.Context, in *ClientMessage_GetRequest, opts ...grpc.CallOption) (*ControllerMessage_FragLayoutResponse, error)
+	Commit(ctx context.Context, in *ClientMessage_CommitRequest, opts ...grpc.CallOption) (*ControllerMessage_CommitResponse, error)
+	List(ctx context.Context, in *ClientMessage_LsRequest, opts ...grpc.CallOption) (*ControllerMessage_LsResponse, error)
+	Delete(ctx context.Context, in *ClientMessage_DeleteRequest, opts ...grpc.CallOption) (*ControllerMessage_DeleteResponse, error)
+	Stats(ctx context.Context, in *ClientMessage_NodeStatsRequest, opts ...grpc.CallOption) (*ControllerMessage_NodeStats, error)
+	Mkdir(ctx context.Context, in *ClientMessage_MkdirRequest, opts ...grpc.CallOption) (*ControllerMessage_NamespaceResponse, error)
+	Rmdir(ctx context.Context, in *ClientMessage_RmdirRequest, opts ...grpc.CallOption) (*ControllerMessage_NamespaceResponse, error)
+	Rename(ctx context.Context, in *ClientMessage_RenameRequest, opts ...grpc.CallOption) (*ControllerMessage_NamespaceResponse, error)
+	Stat(ctx context.Context, in *ClientMessage_StatRequest, opts ...grpc.CallOption) (*ControllerMessage_StatResponse, error)
+}
+
+type controllerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewControllerServiceClient(cc grpc.ClientConnInterface) ControllerServiceClient {
+	return &controllerServiceClient{cc}
+}
+
+func (c *controllerServiceClient) Plan(ctx context.Context, in *ClientMessage_PutRequest, opts ...grpc.CallOption) (*ControllerMessage_PlanResponse, error) {
+	out := new(ControllerMessage_PlanResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Plan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Layout(ctx context.Context, in *ClientMessage_GetRequest, opts ...grpc.CallOption) (*ControllerMessage_FragLayoutResponse, error) {
+	out := new(ControllerMessage_FragLayoutResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Layout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Commit(ctx context.Context, in *ClientMessage_CommitRequest, opts ...grpc.CallOption) (*ControllerMessage_CommitResponse, error) {
+	out := new(ControllerMessage_CommitResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Commit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) List(ctx context.Context, in *ClientMessage_LsRequest, opts ...grpc.CallOption) (*ControllerMessage_LsResponse, error) {
+	out := new(ControllerMessage_LsResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Delete(ctx context.Context, in *ClientMessage_DeleteRequest, opts ...grpc.CallOption) (*ControllerMessage_DeleteResponse, error) {
+	out := new(ControllerMessage_DeleteResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Stats(ctx context.Context, in *ClientMessage_NodeStatsRequest, opts ...grpc.CallOption) (*ControllerMessage_NodeStats, error) {
+	out := new(ControllerMessage_NodeStats)
+	err := c.cc.Invoke(ctx, "/ControllerService/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Mkdir(ctx context.Context, in *ClientMessage_MkdirRequest, opts ...grpc.CallOption) (*ControllerMessage_NamespaceResponse, error) {
+	out := new(ControllerMessage_NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Mkdir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Rmdir(ctx context.Context, in *ClientMessage_RmdirRequest, opts ...grpc.CallOption) (*ControllerMessage_NamespaceResponse, error) {
+	out := new(ControllerMessage_NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Rmdir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Rename(ctx context.Context, in *ClientMessage_RenameRequest, opts ...grpc.CallOption) (*ControllerMessage_NamespaceResponse, error) {
+	out := new(ControllerMessage_NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Rename", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerServiceClient) Stat(ctx context.Context, in *ClientMessage_StatRequest, opts ...grpc.CallOption) (*ControllerMessage_StatResponse, error) {
+	out := new(ControllerMessage_StatResponse)
+	err := c.cc.Invoke(ctx, "/ControllerService/Stat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControllerServiceServer is the server API for ControllerService service.
+// All implementations must embed UnimplementedControllerServiceServer
+// for forward compatibility
+type ControllerServiceServer interface {
+	Plan(context.Context, *ClientMessage_PutRequest) (*ControllerMessage_PlanResponse, error)
+	Layout(context.Context, *ClientMessage_GetRequest) (*ControllerMessage_FragLayoutResponse, error)
+	Commit(context.Context, *ClientMessage_CommitRequest) (*ControllerMessage_CommitResponse, error)
+	List(context.Context, *ClientMessage_LsRequest) (*ControllerMessage_LsResponse, error)
+	Delete(context.Context, *ClientMessage_DeleteRequest) (*ControllerMessage_DeleteResponse, error)
+	Stats(context.Context, *ClientMessage_NodeStatsRequest) (*ControllerMessage_NodeStats, error)
+	Mkdir(context.Context, *ClientMessage_MkdirRequest) (*ControllerMessage_NamespaceResponse, error)
+	Rmdir(context.Context, *ClientMessage_RmdirRequest) (*ControllerMessage_NamespaceResponse, error)
+	Rename(context.Context, *ClientMessage_RenameRequest) (*ControllerMessage_NamespaceResponse, error)
+	Stat(context.Context, *ClientMessage_StatRequest) (*ControllerMessage_StatResponse, error)
+	mustEmbedUnimplementedControllerServiceServer()
+}
+
+// UnimplementedControllerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedControllerServiceServer struct {
+}
+
+func (UnimplementedControllerServiceServer) Plan(context.Context, *ClientMessage_PutRequest) (*ControllerMessage_PlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Plan not implemented")
+}
+func (UnimplementedControllerServiceServer) Layout(context.Context, *ClientMessage_GetRequest) (*ControllerMessage_FragLayoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Layout not implemented")
+}
+func (UnimplementedControllerServiceServer) Commit(context.Context, *ClientMessage_CommitRequest) (*ControllerMessage_CommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Commit not implemented")
+}
+func (UnimplementedControllerServiceServer) List(context.Context, *ClientMessage_LsRequest) (*ControllerMessage_LsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedControllerServiceServer) Delete(context.Context, *ClientMessage_DeleteRequest) (*ControllerMessage_DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedControllerServiceServer) Stats(context.Context, *ClientMessage_NodeStatsRequest) (*ControllerMessage_NodeStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedControllerServiceServer) Mkdir(context.Context, *ClientMessage_MkdirRequest) (*ControllerMessage_NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mkdir not implemented")
+}
+func (UnimplementedControllerServiceServer) Rmdir(context.Context, *ClientMessage_RmdirRequest) (*ControllerMessage_NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rmdir not implemented")
+}
+func (UnimplementedControllerServiceServer) Rename(context.Context, *ClientMessage_RenameRequest) (*ControllerMessage_NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rename not implemented")
+}
+func (UnimplementedControllerServiceServer) Stat(context.Context, *ClientMessage_StatRequest) (*ControllerMessage_StatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedControllerServiceServer) mustEmbedUnimplementedControllerServiceServer() {}
+
+// UnsafeControllerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ControllerServiceServer will
+// result in compilation errors.
+type UnsafeControllerServiceServer interface {
+	mustEmbedUnimplementedControllerServiceServer()
+}
+
+func RegisterControllerServiceServer(s grpc.ServiceRegistrar, srv ControllerServiceServer) {
+	s.RegisterService(&ControllerService_ServiceDesc, srv)
+}
+
+func _ControllerService_Plan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_PutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Plan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Plan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Plan(ctx, req.(*ClientMessage_PutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Layout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Layout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Layout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Layout(ctx, req.(*ClientMessage_GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_CommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Commit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Commit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Commit(ctx, req.(*ClientMessage_CommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_LsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).List(ctx, req.(*ClientMessage_LsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Delete(ctx, req.(*ClientMessage_DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_NodeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Stats(ctx, req.(*ClientMessage_NodeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Mkdir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_MkdirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Mkdir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Mkdir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Mkdir(ctx, req.(*ClientMessage_MkdirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Rmdir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_RmdirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Rmdir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Rmdir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Rmdir(ctx, req.(*ClientMessage_RmdirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_RenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Rename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Rename",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Rename(ctx, req.(*ClientMessage_RenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ControllerService_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientMessage_StatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ControllerService/Stat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).Stat(ctx, req.(*ClientMessage_StatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ControllerService_ServiceDesc is the grpc.ServiceDesc for ControllerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ControllerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ControllerService",
+	HandlerType: (*ControllerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Plan",
+			Handler:    _ControllerService_Plan_Handler,
+		},
+		{
+			MethodName: "Layout",
+			Handler:    _ControllerService_Layout_Handler,
+		},
+		{
+			MethodName: "Commit",
+			Handler:    _ControllerService_Commit_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ControllerService_List_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ControllerService_Delete_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _ControllerService_Stats_Handler,
+		},
+		{
+			MethodName: "Mkdir",
+			Handler:    _ControllerService_Mkdir_Handler,
+		},
+		{
+			MethodName: "Rmdir",
+			Handler:    _ControllerService_Rmdir_Handler,
+		},
+		{
+			MethodName: "Rename",
+			Handler:    _ControllerService_Rename_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _ControllerService_Stat_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controller_client.proto",
+}
@@ -0,0 +1,773 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: controller_controller.proto
+
+package controller_controller
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The controllers of a highly available group talk to each other in PeerMessages. Every request is answered
+// on its connection: a Status with the Status of the peer, AppendRecords and InstallSnapshot with an Ack, and
+// a RequestVote with a Vote.
+type PeerMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to PeerMessage:
+	//
+	//	*PeerMessage_Status_
+	//	*PeerMessage_AppendRecords_
+	//	*PeerMessage_InstallSnapshot_
+	//	*PeerMessage_Ack_
+	//	*PeerMessage_RequestVote_
+	//	*PeerMessage_Vote_
+	PeerMessage isPeerMessage_PeerMessage `protobuf_oneof:"peer_message"`
+}
+
+func (x *PeerMessage) Reset() {
+	*x = PeerMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_controller_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMessage) ProtoMessage() {}
+
+func (x *PeerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_controller_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMessage.ProtoReflect.Descriptor instead.
+func (*PeerMessage) Descriptor() ([]byte, []int) {
+	return file_controller_controller_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *PeerMessage) GetPeerMessage() isPeerMessage_PeerMessage {
+	if m != nil {
+		return m.PeerMessage
+	}
+	return nil
+}
+
+func (x *PeerMessage) GetStatus() *PeerMessage_Status {
+	if x, ok := x.GetPeerMessage().(*PeerMessage_Status_); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *PeerMessage) GetAppendRecords() *PeerMessage_AppendRecords {
+	if x, ok := x.GetPeerMessage().(*PeerMessage_AppendRecords_); ok {
+		return x.AppendRecords
+	}
+	return nil
+}
+
+func (x *PeerMessage) GetInstallSnapshot() *PeerMessage_InstallSnapshot {
+	if x, ok := x.GetPeerMessage().(*PeerMessage_InstallSnapshot_); ok {
+		return x.InstallSnapshot
+	}
+	return nil
+}
+
+func (x *PeerMessage) GetAck() *PeerMessage_Ack {
+	if x, ok := x.GetPeerMessage().(*PeerMessage_Ack_); ok {
+		return x.Ack
+	}
+	return nil
+}
+
+func (x *PeerMessage) GetRequestVote() *PeerMessage_RequestVote {
+	if x, ok := x.GetPeerMessage().(*PeerMessage_RequestVote_); ok {
+		return x.RequestVote
+	}
+	return nil
+}
+
+func (x *PeerMessage) GetVote() *PeerMessage_Vote {
+	if x, ok := x.GetPeerMessage().(*PeerMessage_Vote_); ok {
+		return x.Vote
+	}
+	return nil
+}
+
+type isPeerMessage_PeerMessage interface {
+	isPeerMessage_PeerMessage()
+}
+
+type PeerMessage_Status_ struct {
+	Status *PeerMessage_Status `protobuf:"bytes,1,opt,name=status,proto3,oneof"`
+}
+
+type PeerMessage_AppendRecords_ struct {
+	AppendRecords *PeerMessage_AppendRecords `protobuf:"bytes,2,opt,name=append_records,json=appendRecords,proto3,oneof"`
+}
+
+type PeerMessage_InstallSnapshot_ struct {
+	InstallSnapshot *PeerMessage_InstallSnapshot `protobuf:"bytes,3,opt,name=install_snapshot,json=installSnapshot,proto3,oneof"`
+}
+
+type PeerMessage_Ack_ struct {
+	Ack *PeerMessage_Ack `protobuf:"bytes,4,opt,name=ack,proto3,oneof"`
+}
+
+type PeerMessage_RequestVote_ struct {
+	RequestVote *PeerMessage_RequestVote `protobuf:"bytes,5,opt,name=request_vote,json=requestVote,proto3,oneof"`
+}
+
+type PeerMessage_Vote_ struct {
+	Vote *PeerMessage_Vote `protobuf:"bytes,6,opt,name=vote,proto3,oneof"`
+}
+
+func (*PeerMessage_Status_) isPeerMessage_PeerMessage() {}
+
+func (*PeerMessage_AppendRecords_) isPeerMessage_PeerMessage() {}
+
+func (*PeerMessage_InstallSnapshot_) isPeerMessage_PeerMessage() {}
+
+func (*PeerMessage_Ack_) isPeerMessage_PeerMessage() {}
+
+func (*PeerMessage_RequestVote_) isPeerMessage_PeerMessage() {}
+
+func (*PeerMessage_Vote_) isPeerMessage_PeerMessage() {}
+
+// Sent to every other controller every heartbeat interval
+type PeerMessage_Status struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The address the other controllers reach it on
+	ControllerId string `protobuf:"bytes,1,opt,name=controller_id,json=controllerId,proto3" json:"controller_id,omitempty"`
+	// Every election starts a new term, a controller that sees a higher term than its own adopts it
+	Term   uint64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Active bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	// The sequence number of the last metadata record it applied
+	Seq uint64 `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	// The term the last metadata record it applied was committed in
+	LastTerm uint64 `protobuf:"varint,5,opt,name=last_term,json=lastTerm,proto3" json:"last_term,omitempty"`
+}
+
+func (x *PeerMessage_Status) Reset() {
+	*x = PeerMessage_Status{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_controller_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMessage_Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMessage_Status) ProtoMessage() {}
+
+func (x *PeerMessage_Status) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_controller_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMessage_Status.ProtoReflect.Descriptor instead.
+func (*PeerMessage_Status) Descriptor() ([]byte, []int) {
+	return file_controller_controller_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *PeerMessage_Status) GetControllerId() string {
+	if x != nil {
+		return x.ControllerId
+	}
+	return ""
+}
+
+func (x *PeerMessage_Status) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *PeerMessage_Status) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *PeerMessage_Status) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PeerMessage_Status) GetLastTerm() uint64 {
+	if x != nil {
+		return x.LastTerm
+	}
+	return 0
+}
+
+// Metadata records the active controller committed, in order
+type PeerMessage_AppendRecords struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	// Every record is a JSON encoded metadata.Record, as written to the log
+	Records [][]byte `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *PeerMessage_AppendRecords) Reset() {
+	*x = PeerMessage_AppendRecords{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_controller_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMessage_AppendRecords) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMessage_AppendRecords) ProtoMessage() {}
+
+func (x *PeerMessage_AppendRecords) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_controller_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMessage_AppendRecords.ProtoReflect.Descriptor instead.
+func (*PeerMessage_AppendRecords) Descriptor() ([]byte, []int) {
+	return file_controller_controller_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *PeerMessage_AppendRecords) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *PeerMessage_AppendRecords) GetRecords() [][]byte {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// The whole metadata of the active controller, sent to a standby that is not in sync with it
+type PeerMessage_InstallSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term     uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Snapshot []byte `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *PeerMessage_InstallSnapshot) Reset() {
+	*x = PeerMessage_InstallSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_controller_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMessage_InstallSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMessage_InstallSnapshot) ProtoMessage() {}
+
+func (x *PeerMessage_InstallSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_controller_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMessage_InstallSnapshot.ProtoReflect.Descriptor instead.
+func (*PeerMessage_InstallSnapshot) Descriptor() ([]byte, []int) {
+	return file_controller_controller_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *PeerMessage_InstallSnapshot) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *PeerMessage_InstallSnapshot) GetSnapshot() []byte {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type PeerMessage_Ack struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The term of the controller that answers, higher than the sender's when it turned down a stale active one
+	Term    uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// The sequence number of the last metadata record it applied
+	Seq uint64 `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *PeerMessage_Ack) Reset() {
+	*x = PeerMessage_Ack{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_controller_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMessage_Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMessage_Ack) ProtoMessage() {}
+
+func (x *PeerMessage_Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_controller_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMessage_Ack.ProtoReflect.Descriptor instead.
+func (*PeerMessage_Ack) Descriptor() ([]byte, []int) {
+	return file_controller_controller_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *PeerMessage_Ack) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *PeerMessage_Ack) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PeerMessage_Ack) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// Sent by a controller that holds an election in a new term, to every other controller
+type PeerMessage_RequestVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term         uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	ControllerId string `protobuf:"bytes,2,opt,name=controller_id,json=controllerId,proto3" json:"controller_id,omitempty"`
+	// The sequence number and term of the last metadata record it applied
+	Seq      uint64 `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	LastTerm uint64 `protobuf:"varint,4,opt,name=last_term,json=lastTerm,proto3" json:"last_term,omitempty"`
+}
+
+func (x *PeerMessage_RequestVote) Reset() {
+	*x = PeerMessage_RequestVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_controller_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMessage_RequestVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMessage_RequestVote) ProtoMessage() {}
+
+func (x *PeerMessage_RequestVote) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_controller_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMessage_RequestVote.ProtoReflect.Descriptor instead.
+func (*PeerMessage_RequestVote) Descriptor() ([]byte, []int) {
+	return file_controller_controller_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *PeerMessage_RequestVote) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *PeerMessage_RequestVote) GetControllerId() string {
+	if x != nil {
+		return x.ControllerId
+	}
+	return ""
+}
+
+func (x *PeerMessage_RequestVote) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PeerMessage_RequestVote) GetLastTerm() uint64 {
+	if x != nil {
+		return x.LastTerm
+	}
+	return 0
+}
+
+type PeerMessage_Vote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The term of the controller that answers
+	Term uint64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	// A controller votes once per term, for a controller whose metadata is at least as recent as its own
+	Granted bool `protobuf:"varint,2,opt,name=granted,proto3" json:"granted,omitempty"`
+}
+
+func (x *PeerMessage_Vote) Reset() {
+	*x = PeerMessage_Vote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_controller_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerMessage_Vote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMessage_Vote) ProtoMessage() {}
+
+func (x *PeerMessage_Vote) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_controller_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerMessage_Vote.ProtoReflect.Descriptor instead.
+func (*PeerMessage_Vote) Descriptor() ([]byte, []int) {
+	return file_controller_controller_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *PeerMessage_Vote) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *PeerMessage_Vote) GetGranted() bool {
+	if x != nil {
+		return x.Granted
+	}
+	return false
+}
+
+var File_controller_controller_proto protoreflect.FileDescriptor
+
+var file_controller_controller_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xeb, 0x06,
+	0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x43, 0x0a, 0x0e,
+	0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x48, 0x00, 0x52, 0x0d, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x49, 0x0a, 0x10, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x24, 0x0a, 0x03,
+	0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x61,
+	0x63, 0x6b, 0x12, 0x3d, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x6f,
+	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x6f,
+	0x74, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74,
+	0x65, 0x12, 0x27, 0x0a, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x56, 0x6f,
+	0x74, 0x65, 0x48, 0x00, 0x52, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x1a, 0x88, 0x01, 0x0a, 0x06, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65,
+	0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x61, 0x73,
+	0x74, 0x54, 0x65, 0x72, 0x6d, 0x1a, 0x3d, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x1a, 0x41, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x1a, 0x45, 0x0a, 0x03, 0x41, 0x63, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65,
+	0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x1a, 0x75,
+	0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72,
+	0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x61, 0x73,
+	0x74, 0x54, 0x65, 0x72, 0x6d, 0x1a, 0x34, 0x0a, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72,
+	0x6d, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x70,
+	0x65, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x22, 0x5a, 0x20, 0x2e,
+	0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_controller_controller_proto_rawDescOnce sync.Once
+	file_controller_controller_proto_rawDescData = file_controller_controller_proto_rawDesc
+)
+
+func file_controller_controller_proto_rawDescGZIP() []byte {
+	file_controller_controller_proto_rawDescOnce.Do(func() {
+		file_controller_controller_proto_rawDescData = protoimpl.X.CompressGZIP(file_controller_controller_proto_rawDescData)
+	})
+	return file_controller_controller_proto_rawDescData
+}
+
+var file_controller_controller_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_controller_controller_proto_goTypes = []interface{}{
+	(*PeerMessage)(nil),                 // 0: PeerMessage
+	(*PeerMessage_Status)(nil),          // 1: PeerMessage.Status
+	(*PeerMessage_AppendRecords)(nil),   // 2: PeerMessage.AppendRecords
+	(*PeerMessage_InstallSnapshot)(nil), // 3: PeerMessage.InstallSnapshot
+	(*PeerMessage_Ack)(nil),             // 4: PeerMessage.Ack
+	(*PeerMessage_RequestVote)(nil),     // 5: PeerMessage.RequestVote
+	(*PeerMessage_Vote)(nil),            // 6: PeerMessage.Vote
+}
+var file_controller_controller_proto_depIdxs = []int32{
+	1, // 0: PeerMessage.status:type_name -> PeerMessage.Status
+	2, // 1: PeerMessage.append_records:type_name -> PeerMessage.AppendRecords
+	3, // 2: PeerMessage.install_snapshot:type_name -> PeerMessage.InstallSnapshot
+	4, // 3: PeerMessage.ack:type_name -> PeerMessage.Ack
+	5, // 4: PeerMessage.request_vote:type_name -> PeerMessage.RequestVote
+	6, // 5: PeerMessage.vote:type_name -> PeerMessage.Vote
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_controller_controller_proto_init() }
+func file_controller_controller_proto_init() {
+	if File_controller_controller_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controller_controller_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_controller_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMessage_Status); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_controller_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMessage_AppendRecords); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_controller_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMessage_InstallSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_controller_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMessage_Ack); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_controller_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMessage_RequestVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_controller_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMessage_Vote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_controller_controller_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*PeerMessage_Status_)(nil),
+		(*PeerMessage_AppendRecords_)(nil),
+		(*PeerMessage_InstallSnapshot_)(nil),
+		(*PeerMessage_Ack_)(nil),
+		(*PeerMessage_RequestVote_)(nil),
+		(*PeerMessage_Vote_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controller_controller_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_controller_controller_proto_goTypes,
+		DependencyIndexes: file_controller_controller_proto_depIdxs,
+		MessageInfos:      file_controller_controller_proto_msgTypes,
+	}.Build()
+	File_controller_controller_proto = out.File
+	file_controller_controller_proto_rawDesc = nil
+	file_controller_controller_proto_goTypes = nil
+	file_controller_controller_proto_depIdxs = nil
+}
@@ -0,0 +1,57 @@
+package controller_controller
+
+import (
+	"google.golang.org/protobuf/proto"
+	"net"
+	"src/messages/wire"
+	"time"
+)
+
+// MessageHandler frames the messages controllers exchange on a connection. The controller that dialed sends
+// requests and reads the answers, the one that accepted reads requests and answers them.
+type MessageHandler struct {
+	conn *wire.Conn
+}
+
+func NewMessageHandler(conn net.Conn) *MessageHandler {
+	m := &MessageHandler{
+		conn: wire.NewConn(conn),
+	}
+	return m
+}
+
+// SetTimeouts bounds the read and the write of every message on the connection. Zero leaves it without a deadline.
+func (m *MessageHandler) SetTimeouts(read time.Duration, write time.Duration) {
+	m.conn.SetTimeouts(read, write)
+}
+
+// SetMaxFrameSize sets the largest message the connection accepts. A snapshot of the metadata may be larger
+// than the default.
+func (m *MessageHandler) SetMaxFrameSize(size uint32) {
+	m.conn.SetMaxFrameSize(size)
+}
+
+func (m *MessageHandler) Send(wrapper *PeerMessage) error {
+	serialized, err := proto.Marshal(wrapper)
+	if err != nil {
+		return err
+	}
+
+	return m.conn.Send(serialized)
+}
+
+func (m *MessageHandler) Receive() (*PeerMessage, error) {
+	wrapper := &PeerMessage{}
+
+	payload, err := m.conn.Receive()
+	if err != nil {
+		return wrapper, err
+	}
+
+	err = proto.Unmarshal(payload, wrapper)
+	return wrapper, err
+}
+
+func (m *MessageHandler) Close() {
+	m.conn.Close()
+}
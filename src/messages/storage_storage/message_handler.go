@@ -1,44 +1,26 @@
 package storage_storage
 
 import (
-	"encoding/binary"
 	"google.golang.org/protobuf/proto"
 	"net"
+	"src/messages/wire"
+	"time"
 )
 
 type MessageHandler struct {
-	conn net.Conn
+	conn *wire.Conn
 }
 
 func NewMessageHandler(conn net.Conn) *MessageHandler {
 	m := &MessageHandler{
-		conn: conn,
+		conn: wire.NewConn(conn),
 	}
 	return m
 }
 
-func (m *MessageHandler) readN(buf []byte) error {
-	bytesRead := uint64(0)
-	for bytesRead < uint64(len(buf)) {
-		n, err := m.conn.Read(buf[bytesRead:])
-		if err != nil {
-			return err
-		}
-		bytesRead += uint64(n)
-	}
-	return nil
-}
-
-func (m *MessageHandler) writeN(buf []byte) error {
-	bytesWritten := uint64(0)
-	for bytesWritten < uint64(len(buf)) {
-		n, err := m.conn.Write(buf[bytesWritten:])
-		if err != nil {
-			return err
-		}
-		bytesWritten += uint64(n)
-	}
-	return nil
+// SetTimeouts bounds the read and the write of every message on the connection. Zero leaves it without a deadline.
+func (m *MessageHandler) SetTimeouts(read time.Duration, write time.Duration) {
+	m.conn.SetTimeouts(read, write)
 }
 
 func (m *MessageHandler) ClientRequestSend(wrapper *StorageNodeMessage) error {
@@ -47,12 +29,7 @@ func (m *MessageHandler) ClientRequestSend(wrapper *StorageNodeMessage) error {
 		return err
 	}
 
-	prefix := make([]byte, 8)
-	binary.LittleEndian.PutUint64(prefix, uint64(len(serialized)))
-	m.writeN(prefix)
-	m.writeN(serialized)
-
-	return nil
+	return m.conn.Send(serialized)
 }
 
 func (m *MessageHandler) ServerResponseSend(wrapper *StorageNodeMessage) error {
@@ -61,38 +38,37 @@ func (m *MessageHandler) ServerResponseSend(wrapper *StorageNodeMessage) error {
 		return err
 	}
 
-	prefix := make([]byte, 8)
-	binary.LittleEndian.PutUint64(prefix, uint64(len(serialized)))
-	m.writeN(prefix)
-	m.writeN(serialized)
+	return m.conn.Send(serialized)
 
-	return nil
+}
 
+// ErrorSend answers the peer with an error frame instead of a message. The peer's receive fails with a
+// *wire.RemoteError carrying code and message.
+func (m *MessageHandler) ErrorSend(code wire.ErrorCode, message string) error {
+	return m.conn.SendError(code, message)
 }
 
 func (m *MessageHandler) ClientRequestReceive() (*StorageNodeMessage, error) {
-	prefix := make([]byte, 8)
-	m.readN(prefix)
+	wrapper := &StorageNodeMessage{}
 
-	payloadSize := binary.LittleEndian.Uint64(prefix)
-	payload := make([]byte, payloadSize)
-	m.readN(payload)
+	payload, err := m.conn.Receive()
+	if err != nil {
+		return wrapper, err
+	}
 
-	wrapper := &StorageNodeMessage{}
-	err := proto.Unmarshal(payload, wrapper)
+	err = proto.Unmarshal(payload, wrapper)
 	return wrapper, err
 }
 
 func (m *MessageHandler) ServerResponseReceive() (*StorageNodeMessage, error) {
-	prefix := make([]byte, 8)
-	m.readN(prefix)
+	wrapper := &StorageNodeMessage{}
 
-	payloadSize := binary.LittleEndian.Uint64(prefix)
-	payload := make([]byte, payloadSize)
-	m.readN(payload)
+	payload, err := m.conn.Receive()
+	if err != nil {
+		return wrapper, err
+	}
 
-	wrapper := &StorageNodeMessage{}
-	err := proto.Unmarshal(payload, wrapper)
+	err = proto.Unmarshal(payload, wrapper)
 	return wrapper, err
 }
 
@@ -31,6 +31,7 @@ type StorageNodeMessage struct {
 	//	*StorageNodeMessage_PutCopyResponse
 	//	*StorageNodeMessage_GetReplica
 	//	*StorageNodeMessage_GetReplicaResponse
+	//	*StorageNodeMessage_Chunk
 	StorageNodeMessage isStorageNodeMessage_StorageNodeMessage `protobuf_oneof:"storage_node_message"`
 }
 
@@ -101,6 +102,13 @@ func (x *StorageNodeMessage) GetGetReplicaResponse() *StorageNodeMessage_GETRepl
 	return nil
 }
 
+func (x *StorageNodeMessage) GetChunk() *StorageNodeMessage_DataChunk {
+	if x, ok := x.GetStorageNodeMessage().(*StorageNodeMessage_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
 type isStorageNodeMessage_StorageNodeMessage interface {
 	isStorageNodeMessage_StorageNodeMessage()
 }
@@ -121,6 +129,10 @@ type StorageNodeMessage_GetReplicaResponse struct {
 	GetReplicaResponse *StorageNodeMessage_GETReplicaResponse `protobuf:"bytes,4,opt,name=get_replica_response,json=getReplicaResponse,proto3,oneof"`
 }
 
+type StorageNodeMessage_Chunk struct {
+	Chunk *StorageNodeMessage_DataChunk `protobuf:"bytes,5,opt,name=chunk,proto3,oneof"`
+}
+
 func (*StorageNodeMessage_PutCopy) isStorageNodeMessage_StorageNodeMessage() {}
 
 func (*StorageNodeMessage_PutCopyResponse) isStorageNodeMessage_StorageNodeMessage() {}
@@ -129,6 +141,8 @@ func (*StorageNodeMessage_GetReplica) isStorageNodeMessage_StorageNodeMessage()
 
 func (*StorageNodeMessage_GetReplicaResponse) isStorageNodeMessage_StorageNodeMessage() {}
 
+func (*StorageNodeMessage_Chunk) isStorageNodeMessage_StorageNodeMessage() {}
+
 type StorageNodeMessage_PUTCopy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -137,6 +151,11 @@ type StorageNodeMessage_PUTCopy struct {
 	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
 	FileData []byte `protobuf:"bytes,2,opt,name=file_data,json=fileData,proto3" json:"file_data,omitempty"`
 	Checksum []byte `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// When streamed, file_data is empty and the data follows in DataChunk frames
+	Streamed bool  `protobuf:"varint,4,opt,name=streamed,proto3" json:"streamed,omitempty"`
+	FileSize int64 `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	// Nodes the copy is forwarded to after this one
+	Pipeline []string `protobuf:"bytes,6,rep,name=pipeline,proto3" json:"pipeline,omitempty"`
 }
 
 func (x *StorageNodeMessage_PUTCopy) Reset() {
@@ -192,12 +211,35 @@ func (x *StorageNodeMessage_PUTCopy) GetChecksum() []byte {
 	return nil
 }
 
+func (x *StorageNodeMessage_PUTCopy) GetStreamed() bool {
+	if x != nil {
+		return x.Streamed
+	}
+	return false
+}
+
+func (x *StorageNodeMessage_PUTCopy) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *StorageNodeMessage_PUTCopy) GetPipeline() []string {
+	if x != nil {
+		return x.Pipeline
+	}
+	return nil
+}
+
 type StorageNodeMessage_PUTCopyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// One entry per node in the rest of the pipeline, starting with the node that answers
+	Replicas []*StorageNodeMessage_ReplicaStatus `protobuf:"bytes,2,rep,name=replicas,proto3" json:"replicas,omitempty"`
 }
 
 func (x *StorageNodeMessage_PUTCopyResponse) Reset() {
@@ -239,18 +281,89 @@ func (x *StorageNodeMessage_PUTCopyResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *StorageNodeMessage_PUTCopyResponse) GetReplicas() []*StorageNodeMessage_ReplicaStatus {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+type StorageNodeMessage_ReplicaStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host    string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error   string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *StorageNodeMessage_ReplicaStatus) Reset() {
+	*x = StorageNodeMessage_ReplicaStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_storage_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageNodeMessage_ReplicaStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageNodeMessage_ReplicaStatus) ProtoMessage() {}
+
+func (x *StorageNodeMessage_ReplicaStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_storage_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageNodeMessage_ReplicaStatus.ProtoReflect.Descriptor instead.
+func (*StorageNodeMessage_ReplicaStatus) Descriptor() ([]byte, []int) {
+	return file_storage_storage_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *StorageNodeMessage_ReplicaStatus) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *StorageNodeMessage_ReplicaStatus) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StorageNodeMessage_ReplicaStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type StorageNodeMessage_GETReplica struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Streamed bool   `protobuf:"varint,2,opt,name=streamed,proto3" json:"streamed,omitempty"`
 }
 
 func (x *StorageNodeMessage_GETReplica) Reset() {
 	*x = StorageNodeMessage_GETReplica{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_storage_storage_proto_msgTypes[3]
+		mi := &file_storage_storage_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -263,7 +376,7 @@ func (x *StorageNodeMessage_GETReplica) String() string {
 func (*StorageNodeMessage_GETReplica) ProtoMessage() {}
 
 func (x *StorageNodeMessage_GETReplica) ProtoReflect() protoreflect.Message {
-	mi := &file_storage_storage_proto_msgTypes[3]
+	mi := &file_storage_storage_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,7 +389,7 @@ func (x *StorageNodeMessage_GETReplica) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageNodeMessage_GETReplica.ProtoReflect.Descriptor instead.
 func (*StorageNodeMessage_GETReplica) Descriptor() ([]byte, []int) {
-	return file_storage_storage_proto_rawDescGZIP(), []int{0, 2}
+	return file_storage_storage_proto_rawDescGZIP(), []int{0, 3}
 }
 
 func (x *StorageNodeMessage_GETReplica) GetFileName() string {
@@ -286,6 +399,13 @@ func (x *StorageNodeMessage_GETReplica) GetFileName() string {
 	return ""
 }
 
+func (x *StorageNodeMessage_GETReplica) GetStreamed() bool {
+	if x != nil {
+		return x.Streamed
+	}
+	return false
+}
+
 type StorageNodeMessage_GETReplicaResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -294,12 +414,14 @@ type StorageNodeMessage_GETReplicaResponse struct {
 	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
 	FileData []byte `protobuf:"bytes,2,opt,name=file_data,json=fileData,proto3" json:"file_data,omitempty"`
 	Checksum []byte `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Streamed bool   `protobuf:"varint,4,opt,name=streamed,proto3" json:"streamed,omitempty"`
+	FileSize int64  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
 }
 
 func (x *StorageNodeMessage_GETReplicaResponse) Reset() {
 	*x = StorageNodeMessage_GETReplicaResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_storage_storage_proto_msgTypes[4]
+		mi := &file_storage_storage_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -312,7 +434,7 @@ func (x *StorageNodeMessage_GETReplicaResponse) String() string {
 func (*StorageNodeMessage_GETReplicaResponse) ProtoMessage() {}
 
 func (x *StorageNodeMessage_GETReplicaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_storage_storage_proto_msgTypes[4]
+	mi := &file_storage_storage_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,7 +447,7 @@ func (x *StorageNodeMessage_GETReplicaResponse) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use StorageNodeMessage_GETReplicaResponse.ProtoReflect.Descriptor instead.
 func (*StorageNodeMessage_GETReplicaResponse) Descriptor() ([]byte, []int) {
-	return file_storage_storage_proto_rawDescGZIP(), []int{0, 3}
+	return file_storage_storage_proto_rawDescGZIP(), []int{0, 4}
 }
 
 func (x *StorageNodeMessage_GETReplicaResponse) GetFileName() string {
@@ -349,11 +471,114 @@ func (x *StorageNodeMessage_GETReplicaResponse) GetChecksum() []byte {
 	return nil
 }
 
+func (x *StorageNodeMessage_GETReplicaResponse) GetStreamed() bool {
+	if x != nil {
+		return x.Streamed
+	}
+	return false
+}
+
+func (x *StorageNodeMessage_GETReplicaResponse) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+// A bounded-size frame of a streamed file. running_crc32 covers every byte sent so far,
+// and the last frame carries the md5 checksum of the whole file.
+type StorageNodeMessage_DataChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName     string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Offset       int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data         []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	RunningCrc32 uint32 `protobuf:"varint,4,opt,name=running_crc32,json=runningCrc32,proto3" json:"running_crc32,omitempty"`
+	Last         bool   `protobuf:"varint,5,opt,name=last,proto3" json:"last,omitempty"`
+	Checksum     []byte `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *StorageNodeMessage_DataChunk) Reset() {
+	*x = StorageNodeMessage_DataChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_storage_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageNodeMessage_DataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageNodeMessage_DataChunk) ProtoMessage() {}
+
+func (x *StorageNodeMessage_DataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_storage_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageNodeMessage_DataChunk.ProtoReflect.Descriptor instead.
+func (*StorageNodeMessage_DataChunk) Descriptor() ([]byte, []int) {
+	return file_storage_storage_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *StorageNodeMessage_DataChunk) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *StorageNodeMessage_DataChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StorageNodeMessage_DataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *StorageNodeMessage_DataChunk) GetRunningCrc32() uint32 {
+	if x != nil {
+		return x.RunningCrc32
+	}
+	return 0
+}
+
+func (x *StorageNodeMessage_DataChunk) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
+func (x *StorageNodeMessage_DataChunk) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
 var File_storage_storage_proto protoreflect.FileDescriptor
 
 var file_storage_storage_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfd, 0x04, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa0, 0x09, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72,
 	0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x38,
 	0x0a, 0x08, 0x70, 0x75, 0x74, 0x5f, 0x63, 0x6f, 0x70, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1b, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65,
@@ -373,29 +598,74 @@ var file_storage_storage_proto_rawDesc = []byte{
 	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
 	0x65, 0x2e, 0x47, 0x45, 0x54, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12, 0x67, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x5f, 0x0a, 0x07, 0x50, 0x55,
-	0x54, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12,
-	0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x1a, 0x2b, 0x0a, 0x0f, 0x50,
-	0x55, 0x54, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x1a, 0x29, 0x0a, 0x0a, 0x47, 0x45, 0x54, 0x52,
-	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x1a, 0x6a, 0x0a, 0x12, 0x47, 0x45, 0x54, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
-	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c,
-	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
-	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x42,
-	0x16, 0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x1c, 0x5a, 0x1a, 0x2e, 0x2f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x1a, 0xb4, 0x01, 0x0a, 0x07, 0x50, 0x55, 0x54, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x1b, 0x0a,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x6a, 0x0a, 0x0f, 0x50, 0x55, 0x54, 0x43,
+	0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x3d, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x73, 0x1a, 0x53, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x45, 0x0a, 0x0a, 0x47, 0x45, 0x54,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64,
+	0x1a, 0xa3, 0x01, 0x0a, 0x12, 0x47, 0x45, 0x54, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x1a, 0xa9, 0x01, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x72, 0x63, 0x33, 0x32, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x43, 0x72, 0x63,
+	0x33, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x42, 0x16, 0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xa0, 0x01, 0x0a, 0x12, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x45, 0x0a, 0x07, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x13, 0x2e, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x1a, 0x23, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x55, 0x54, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x1e, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x47, 0x45, 0x54, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x1a, 0x13, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x30, 0x01, 0x42, 0x1c, 0x5a,
+	0x1a, 0x2e, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -410,24 +680,32 @@ func file_storage_storage_proto_rawDescGZIP() []byte {
 	return file_storage_storage_proto_rawDescData
 }
 
-var file_storage_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_storage_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_storage_storage_proto_goTypes = []interface{}{
 	(*StorageNodeMessage)(nil),                    // 0: StorageNodeMessage
 	(*StorageNodeMessage_PUTCopy)(nil),            // 1: StorageNodeMessage.PUTCopy
 	(*StorageNodeMessage_PUTCopyResponse)(nil),    // 2: StorageNodeMessage.PUTCopyResponse
-	(*StorageNodeMessage_GETReplica)(nil),         // 3: StorageNodeMessage.GETReplica
-	(*StorageNodeMessage_GETReplicaResponse)(nil), // 4: StorageNodeMessage.GETReplicaResponse
+	(*StorageNodeMessage_ReplicaStatus)(nil),      // 3: StorageNodeMessage.ReplicaStatus
+	(*StorageNodeMessage_GETReplica)(nil),         // 4: StorageNodeMessage.GETReplica
+	(*StorageNodeMessage_GETReplicaResponse)(nil), // 5: StorageNodeMessage.GETReplicaResponse
+	(*StorageNodeMessage_DataChunk)(nil),          // 6: StorageNodeMessage.DataChunk
 }
 var file_storage_storage_proto_depIdxs = []int32{
 	1, // 0: StorageNodeMessage.put_copy:type_name -> StorageNodeMessage.PUTCopy
 	2, // 1: StorageNodeMessage.put_copy_response:type_name -> StorageNodeMessage.PUTCopyResponse
-	3, // 2: StorageNodeMessage.get_replica:type_name -> StorageNodeMessage.GETReplica
-	4, // 3: StorageNodeMessage.get_replica_response:type_name -> StorageNodeMessage.GETReplicaResponse
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	4, // 2: StorageNodeMessage.get_replica:type_name -> StorageNodeMessage.GETReplica
+	5, // 3: StorageNodeMessage.get_replica_response:type_name -> StorageNodeMessage.GETReplicaResponse
+	6, // 4: StorageNodeMessage.chunk:type_name -> StorageNodeMessage.DataChunk
+	3, // 5: StorageNodeMessage.PUTCopyResponse.replicas:type_name -> StorageNodeMessage.ReplicaStatus
+	0, // 6: ReplicationService.PutCopy:input_type -> StorageNodeMessage
+	4, // 7: ReplicationService.GetReplica:input_type -> StorageNodeMessage.GETReplica
+	2, // 8: ReplicationService.PutCopy:output_type -> StorageNodeMessage.PUTCopyResponse
+	0, // 9: ReplicationService.GetReplica:output_type -> StorageNodeMessage
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_storage_storage_proto_init() }
@@ -473,7 +751,7 @@ func file_storage_storage_proto_init() {
 			}
 		}
 		file_storage_storage_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageNodeMessage_GETReplica); i {
+			switch v := v.(*StorageNodeMessage_ReplicaStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -485,6 +763,18 @@ func file_storage_storage_proto_init() {
 			}
 		}
 		file_storage_storage_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageNodeMessage_GETReplica); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_storage_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StorageNodeMessage_GETReplicaResponse); i {
 			case 0:
 				return &v.state
@@ -496,12 +786,25 @@ func file_storage_storage_proto_init() {
 				return nil
 			}
 		}
+		file_storage_storage_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageNodeMessage_DataChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_storage_storage_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*StorageNodeMessage_PutCopy)(nil),
 		(*StorageNodeMessage_PutCopyResponse)(nil),
 		(*StorageNodeMessage_GetReplica)(nil),
 		(*StorageNodeMessage_GetReplicaResponse)(nil),
+		(*StorageNodeMessage_Chunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -509,9 +812,9 @@ func file_storage_storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_storage_storage_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_storage_storage_proto_goTypes,
 		DependencyIndexes: file_storage_storage_proto_depIdxs,
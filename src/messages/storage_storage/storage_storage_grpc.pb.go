@@ -0,0 +1,208 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: storage_storage.proto
+
+package storage_storage
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ReplicationServiceClient is the client API for ReplicationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReplicationServiceClient interface {
+	// The first message carries a streamed PUTCopy, every following one a DataChunk of the copy. Answered once
+	// the copy and the ones after it in the pipeline were written.
+	PutCopy(ctx context.Context, opts ...grpc.CallOption) (ReplicationService_PutCopyClient, error)
+	// Answered with the GETReplicaResponse header, followed by the replica in DataChunk frames.
+	GetReplica(ctx context.Context, in *StorageNodeMessage_GETReplica, opts ...grpc.CallOption) (ReplicationService_GetReplicaClient, error)
+}
+
+type replicationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicationServiceClient(cc grpc.ClientConnInterface) ReplicationServiceClient {
+	return &replicationServiceClient{cc}
+}
+
+func (c *replicationServiceClient) PutCopy(ctx context.Context, opts ...grpc.CallOption) (ReplicationService_PutCopyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReplicationService_ServiceDesc.Streams[0], "/ReplicationService/PutCopy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicationServicePutCopyClient{stream}
+	return x, nil
+}
+
+type ReplicationService_PutCopyClient interface {
+	Send(*StorageNodeMessage) error
+	CloseAndRecv() (*StorageNodeMessage_PUTCopyResponse, error)
+	grpc.ClientStream
+}
+
+type replicationServicePutCopyClient struct {
+	grpc.ClientStream
+}
+
+func (x *replicationServicePutCopyClient) Send(m *StorageNodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *replicationServicePutCopyClient) CloseAndRecv() (*StorageNodeMessage_PUTCopyResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StorageNodeMessage_PUTCopyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *replicationServiceClient) GetReplica(ctx context.Context, in *StorageNodeMessage_GETReplica, opts ...grpc.CallOption) (ReplicationService_GetReplicaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReplicationService_ServiceDesc.Streams[1], "/ReplicationService/GetReplica", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &replicationServiceGetReplicaClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReplicationService_GetReplicaClient interface {
+	Recv() (*StorageNodeMessage, error)
+	grpc.ClientStream
+}
+
+type replicationServiceGetReplicaClient struct {
+	grpc.ClientStream
+}
+
+func (x *replicationServiceGetReplicaClient) Recv() (*StorageNodeMessage, error) {
+	m := new(StorageNodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReplicationServiceServer is the server API for ReplicationService service.
+// All implementations must embed UnimplementedReplicationServiceServer
+// for forward compatibility
+type ReplicationServiceServer interface {
+	// The first message carries a streamed PUTCopy, every following one a DataChunk of the copy. Answered once
+	// the copy and the ones after it in the pipeline were written.
+	PutCopy(ReplicationService_PutCopyServer) error
+	// Answered with the GETReplicaResponse header, followed by the replica in DataChunk frames.
+	GetReplica(*StorageNodeMessage_GETReplica, ReplicationService_GetReplicaServer) error
+	mustEmbedUnimplementedReplicationServiceServer()
+}
+
+// UnimplementedReplicationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReplicationServiceServer struct {
+}
+
+func (UnimplementedReplicationServiceServer) PutCopy(ReplicationService_PutCopyServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutCopy not implemented")
+}
+func (UnimplementedReplicationServiceServer) GetReplica(*StorageNodeMessage_GETReplica, ReplicationService_GetReplicaServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetReplica not implemented")
+}
+func (UnimplementedReplicationServiceServer) mustEmbedUnimplementedReplicationServiceServer() {}
+
+// UnsafeReplicationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicationServiceServer will
+// result in compilation errors.
+type UnsafeReplicationServiceServer interface {
+	mustEmbedUnimplementedReplicationServiceServer()
+}
+
+func RegisterReplicationServiceServer(s grpc.ServiceRegistrar, srv ReplicationServiceServer) {
+	s.RegisterService(&ReplicationService_ServiceDesc, srv)
+}
+
+func _ReplicationService_PutCopy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReplicationServiceServer).PutCopy(&replicationServicePutCopyServer{stream})
+}
+
+type ReplicationService_PutCopyServer interface {
+	SendAndClose(*StorageNodeMessage_PUTCopyResponse) error
+	Recv() (*StorageNodeMessage, error)
+	grpc.ServerStream
+}
+
+type replicationServicePutCopyServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicationServicePutCopyServer) SendAndClose(m *StorageNodeMessage_PUTCopyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *replicationServicePutCopyServer) Recv() (*StorageNodeMessage, error) {
+	m := new(StorageNodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ReplicationService_GetReplica_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StorageNodeMessage_GETReplica)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplicationServiceServer).GetReplica(m, &replicationServiceGetReplicaServer{stream})
+}
+
+type ReplicationService_GetReplicaServer interface {
+	Send(*StorageNodeMessage) error
+	grpc.ServerStream
+}
+
+type replicationServiceGetReplicaServer struct {
+	grpc.ServerStream
+}
+
+func (x *replicationServiceGetReplicaServer) Send(m *StorageNodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ReplicationService_ServiceDesc is the grpc.ServiceDesc for ReplicationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReplicationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ReplicationService",
+	HandlerType: (*ReplicationServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PutCopy",
+			Handler:       _ReplicationService_PutCopy_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetReplica",
+			Handler:       _ReplicationService_GetReplica_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "storage_storage.proto",
+}
@@ -0,0 +1,370 @@
+// Package wire frames the messages every component of the DFS exchanges over TCP.
+//
+// A frame is a 12 byte header followed by its payload:
+//
+//	magic "DFSW" | version (1 byte) | type (1 byte) | reserved (2 bytes) | payload length (4 bytes, little endian)
+//
+// The two sides of a connection first agree on a protocol version. The side that sends first opens the
+// handshake with a hello frame carrying the range of versions it speaks, and the other side answers with the
+// highest version both speak, or with an error frame if there is none. Every frame after that carries the
+// agreed version. A peer may answer any message with an error frame instead.
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// VERSION is the newest protocol version this build speaks, MIN_VERSION the oldest.
+	VERSION     = 1
+	MIN_VERSION = 1
+
+	// HEADER_SIZE is the size of a frame header.
+	HEADER_SIZE = 12
+
+	// DEFAULT_MAX_FRAME_SIZE is the largest payload a connection accepts unless SetMaxFrameSize was called.
+	// Fragments are streamed in frames of at most 1 MB, so only block reports come close to it.
+	DEFAULT_MAX_FRAME_SIZE = 16 * 1024 * 1024
+)
+
+var magic = [4]byte{'D', 'F', 'S', 'W'}
+
+type FrameType uint8
+
+const (
+	FRAME_HELLO FrameType = iota + 1
+	FRAME_MESSAGE
+	FRAME_ERROR
+)
+
+// ErrorCode says why a peer answered with an error frame.
+type ErrorCode uint16
+
+const (
+	ERROR_INTERNAL ErrorCode = iota + 1
+	ERROR_VERSION_MISMATCH
+	ERROR_CORRUPT_FRAME
+	ERROR_BAD_REQUEST
+)
+
+func (c ErrorCode) String() string {
+	switch c {
+	case ERROR_INTERNAL:
+		return "INTERNAL"
+	case ERROR_VERSION_MISMATCH:
+		return "VERSION_MISMATCH"
+	case ERROR_CORRUPT_FRAME:
+		return "CORRUPT_FRAME"
+	case ERROR_BAD_REQUEST:
+		return "BAD_REQUEST"
+	}
+	return fmt.Sprintf("ERROR_%d", uint16(c))
+}
+
+var (
+	ErrCorruptFrame    = errors.New("corrupt frame")
+	ErrBadMagic        = errors.New("bad magic, the peer does not speak this protocol")
+	ErrFrameTooLarge   = errors.New("frame exceeds the maximum size")
+	ErrUnknownFrame    = errors.New("unknown frame type")
+	ErrVersionMismatch = errors.New("no protocol version in common with the peer")
+)
+
+// FrameError is a frame that could not be read. The connection cannot be read any further. errors.Is matches
+// it against ErrCorruptFrame and its Kind: ErrBadMagic, ErrFrameTooLarge, ErrUnknownFrame or ErrVersionMismatch.
+type FrameError struct {
+	Kind   error
+	Detail string
+}
+
+func (e *FrameError) Error() string {
+	if e.Detail == "" {
+		return e.Kind.Error()
+	}
+	return e.Kind.Error() + ": " + e.Detail
+}
+
+func (e *FrameError) Unwrap() error {
+	return e.Kind
+}
+
+func (e *FrameError) Is(target error) bool {
+	return target == ErrCorruptFrame
+}
+
+// RemoteError is an error frame the peer answered with. errors.Is matches a VERSION_MISMATCH against
+// ErrVersionMismatch.
+type RemoteError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	return "peer answered " + e.Code.String() + ": " + e.Message
+}
+
+func (e *RemoteError) Is(target error) bool {
+	return e.Code == ERROR_VERSION_MISMATCH && target == ErrVersionMismatch
+}
+
+// Conn sends and receives frames on a connection. Sends are serialised, so it can be written to from several
+// goroutines.
+type Conn struct {
+	conn         net.Conn
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+	maxFrameSize uint32
+
+	sendMutex sync.Mutex
+
+	handshakeMutex sync.Mutex
+	version        uint8
+	handshakeErr   error
+}
+
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{
+		conn:         conn,
+		maxFrameSize: DEFAULT_MAX_FRAME_SIZE,
+	}
+}
+
+// SetTimeouts bounds the read and the write of every frame on the connection. Zero leaves it without a deadline.
+func (c *Conn) SetTimeouts(read time.Duration, write time.Duration) {
+	c.readTimeout = read
+	c.writeTimeout = write
+}
+
+// SetMaxFrameSize sets the largest payload the connection accepts, larger frames fail with ErrFrameTooLarge.
+func (c *Conn) SetMaxFrameSize(size uint32) {
+	c.maxFrameSize = size
+}
+
+// Version returns the protocol version agreed with the peer, or 0 before the handshake.
+func (c *Conn) Version() uint8 {
+	c.handshakeMutex.Lock()
+	defer c.handshakeMutex.Unlock()
+	return c.version
+}
+
+// Send sends a message. The first frame sent on a connection that has not received anything opens the handshake.
+// A message larger than the maximum frame size fails with ErrFrameTooLarge without anything being sent.
+func (c *Conn) Send(payload []byte) error {
+
+	if uint64(len(payload)) > uint64(c.maxFrameSize) {
+		return &FrameError{Kind: ErrFrameTooLarge, Detail: fmt.Sprintf("%d bytes, at most %d", len(payload), c.maxFrameSize)}
+	}
+
+	version, err := c.handshake(true)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(version, FRAME_MESSAGE, payload)
+}
+
+// Receive returns the next message. It fails with a *RemoteError if the peer answered with an error frame, and
+// with a *FrameError if the frame is corrupt, which the peer is told about. io.EOF means the peer closed the
+// connection between two frames.
+func (c *Conn) Receive() ([]byte, error) {
+
+	version, err := c.handshake(false)
+	if err != nil {
+		return nil, err
+	}
+
+	frameVersion, frameType, payload, err := c.readFrame()
+	if err != nil {
+		c.rejectFrame(err)
+		return nil, err
+	}
+
+	switch {
+	case frameType == FRAME_ERROR:
+		return nil, decodeError(payload)
+	case frameType != FRAME_MESSAGE:
+		err = &FrameError{Kind: ErrUnknownFrame, Detail: fmt.Sprintf("frame type %d after the handshake", frameType)}
+	case frameVersion != version:
+		err = &FrameError{Kind: ErrVersionMismatch, Detail: fmt.Sprintf("frame of version %d, agreed on %d", frameVersion, version)}
+	}
+	if err != nil {
+		c.rejectFrame(err)
+		return nil, err
+	}
+	return payload, nil
+}
+
+// SendError answers the peer with an error frame instead of a message.
+func (c *Conn) SendError(code ErrorCode, message string) error {
+
+	version, err := c.handshake(true)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(version, FRAME_ERROR, encodeError(code, message))
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// handshake agrees on a version with the peer unless that was done already. The side that sends first opens it.
+func (c *Conn) handshake(opening bool) (uint8, error) {
+
+	c.handshakeMutex.Lock()
+	defer c.handshakeMutex.Unlock()
+
+	if c.version == 0 && c.handshakeErr == nil {
+		if opening {
+			c.version, c.handshakeErr = c.openHandshake()
+		} else {
+			c.version, c.handshakeErr = c.answerHandshake()
+		}
+	}
+	return c.version, c.handshakeErr
+}
+
+func (c *Conn) openHandshake() (uint8, error) {
+
+	err := c.writeFrame(VERSION, FRAME_HELLO, []byte{MIN_VERSION, VERSION})
+	if err != nil {
+		return 0, err
+	}
+
+	_, frameType, payload, err := c.readFrame()
+	if err != nil {
+		return 0, err
+	}
+
+	switch frameType {
+	case FRAME_ERROR:
+		return 0, decodeError(payload)
+	case FRAME_HELLO:
+		if len(payload) != 1 || payload[0] < MIN_VERSION || payload[0] > VERSION {
+			return 0, &FrameError{Kind: ErrVersionMismatch, Detail: fmt.Sprintf("peer chose version %v", payload)}
+		}
+		return payload[0], nil
+	}
+	return 0, &FrameError{Kind: ErrUnknownFrame, Detail: fmt.Sprintf("frame type %d instead of a hello", frameType)}
+}
+
+func (c *Conn) answerHandshake() (uint8, error) {
+
+	_, frameType, payload, err := c.readFrame()
+	if err == nil && (frameType != FRAME_HELLO || len(payload) != 2) {
+		err = &FrameError{Kind: ErrUnknownFrame, Detail: fmt.Sprintf("frame type %d instead of a hello", frameType)}
+	}
+	if err != nil {
+		c.rejectFrame(err)
+		return 0, err
+	}
+
+	peerMin, peerMax := payload[0], payload[1]
+	version := uint8(VERSION)
+	if peerMax < version {
+		version = peerMax
+	}
+	if version < MIN_VERSION || version < peerMin {
+		detail := fmt.Sprintf("peer speaks versions %d to %d, this node %d to %d", peerMin, peerMax, MIN_VERSION, VERSION)
+		c.writeFrame(VERSION, FRAME_ERROR, encodeError(ERROR_VERSION_MISMATCH, detail))
+		return 0, &FrameError{Kind: ErrVersionMismatch, Detail: detail}
+	}
+
+	err = c.writeFrame(version, FRAME_HELLO, []byte{version})
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// rejectFrame tells the peer that a frame it sent could not be read. It is only a courtesy, the connection is
+// of no use anymore either way.
+func (c *Conn) rejectFrame(err error) {
+
+	var frameErr *FrameError
+	if !errors.As(err, &frameErr) {
+		return
+	}
+	code := ERROR_CORRUPT_FRAME
+	if frameErr.Kind == ErrVersionMismatch {
+		code = ERROR_VERSION_MISMATCH
+	}
+	c.writeFrame(VERSION, FRAME_ERROR, encodeError(code, frameErr.Error()))
+}
+
+func (c *Conn) writeFrame(version uint8, frameType FrameType, payload []byte) error {
+
+	frame := make([]byte, HEADER_SIZE+len(payload))
+	copy(frame, magic[:])
+	frame[4] = version
+	frame[5] = byte(frameType)
+	binary.LittleEndian.PutUint32(frame[8:], uint32(len(payload)))
+	copy(frame[HEADER_SIZE:], payload)
+
+	c.sendMutex.Lock()
+	defer c.sendMutex.Unlock()
+	if c.writeTimeout > 0 {
+		c.conn.SetWriteDeadline(time.Now().Add(c.writeTimeout))
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+func (c *Conn) readFrame() (version uint8, frameType FrameType, payload []byte, err error) {
+
+	if c.readTimeout > 0 {
+		c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
+	}
+
+	header := make([]byte, HEADER_SIZE)
+	_, err = io.ReadFull(c.conn, header)
+	if err == io.ErrUnexpectedEOF {
+		err = &FrameError{Kind: ErrCorruptFrame, Detail: "truncated header"}
+	}
+	if err != nil {
+		return
+	}
+
+	if [4]byte(header[:4]) != magic {
+		err = &FrameError{Kind: ErrBadMagic, Detail: fmt.Sprintf("%q", header[:4])}
+		return
+	}
+	version = header[4]
+	frameType = FrameType(header[5])
+	if frameType < FRAME_HELLO || frameType > FRAME_ERROR {
+		err = &FrameError{Kind: ErrUnknownFrame, Detail: fmt.Sprintf("frame type %d", frameType)}
+		return
+	}
+	size := binary.LittleEndian.Uint32(header[8:])
+	if size > c.maxFrameSize {
+		err = &FrameError{Kind: ErrFrameTooLarge, Detail: fmt.Sprintf("%d bytes, at most %d", size, c.maxFrameSize)}
+		return
+	}
+
+	payload = make([]byte, size)
+	_, err = io.ReadFull(c.conn, payload)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = &FrameError{Kind: ErrCorruptFrame, Detail: "truncated payload"}
+	}
+	return
+}
+
+func encodeError(code ErrorCode, message string) []byte {
+
+	payload := make([]byte, 2+len(message))
+	binary.LittleEndian.PutUint16(payload, uint16(code))
+	copy(payload[2:], message)
+	return payload
+}
+
+func decodeError(payload []byte) error {
+
+	if len(payload) < 2 {
+		return &FrameError{Kind: ErrCorruptFrame, Detail: "error frame without a code"}
+	}
+	return &RemoteError{Code: ErrorCode(binary.LittleEndian.Uint16(payload)), Message: string(payload[2:])}
+}
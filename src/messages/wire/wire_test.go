@@ -0,0 +1,168 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+)
+
+func rawFrame(version uint8, frameType FrameType, payload []byte) []byte {
+
+	frame := make([]byte, HEADER_SIZE+len(payload))
+	copy(frame, magic[:])
+	frame[4] = version
+	frame[5] = byte(frameType)
+	binary.LittleEndian.PutUint32(frame[8:], uint32(len(payload)))
+	copy(frame[HEADER_SIZE:], payload)
+	return frame
+}
+
+func TestConn_SendReceive(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	client := NewConn(clientConn)
+	server := NewConn(serverConn)
+
+	messages := [][]byte{[]byte("first"), {}, bytes.Repeat([]byte{7}, 4096)}
+	go func() {
+		for _, msg := range messages {
+			client.Send(msg)
+		}
+		client.SendError(ERROR_INTERNAL, "failed")
+		client.Close()
+	}()
+
+	for _, want := range messages {
+		got, err := server.Receive()
+		if err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Receive() = %q, want %q", got, want)
+		}
+	}
+
+	_, err := server.Receive()
+	var remote *RemoteError
+	if !errors.As(err, &remote) || remote.Code != ERROR_INTERNAL || remote.Message != "failed" {
+		t.Errorf("Receive() error = %v, want the error frame", err)
+	}
+
+	_, err = server.Receive()
+	if err != io.EOF {
+		t.Errorf("Receive() error = %v, want io.EOF", err)
+	}
+	if server.Version() != VERSION || client.Version() != VERSION {
+		t.Errorf("Version() = %d and %d, want %d", server.Version(), client.Version(), VERSION)
+	}
+}
+
+func TestConn_Receive(t *testing.T) {
+	tests := []struct {
+		name     string
+		peer     []byte
+		wantKind error
+		wantCode ErrorCode
+	}{
+		{
+			name:     "Test unprefixed peer",
+			peer:     []byte{5, 0, 0, 0, 0, 0, 0, 0, 'h', 'e', 'l', 'l', 'o'},
+			wantKind: ErrBadMagic,
+			wantCode: ERROR_CORRUPT_FRAME,
+		},
+		{
+			name:     "Test newer peer",
+			peer:     rawFrame(VERSION+1, FRAME_HELLO, []byte{VERSION + 1, VERSION + 2}),
+			wantKind: ErrVersionMismatch,
+			wantCode: ERROR_VERSION_MISMATCH,
+		},
+		{
+			name:     "Test message before the handshake",
+			peer:     rawFrame(VERSION, FRAME_MESSAGE, []byte("hello")),
+			wantKind: ErrUnknownFrame,
+			wantCode: ERROR_CORRUPT_FRAME,
+		},
+		{
+			name:     "Test frame too large",
+			peer:     append(rawFrame(VERSION, FRAME_HELLO, []byte{MIN_VERSION, VERSION}), rawFrame(VERSION, FRAME_MESSAGE, make([]byte, 65))...),
+			wantKind: ErrFrameTooLarge,
+			wantCode: ERROR_CORRUPT_FRAME,
+		},
+		{
+			name:     "Test truncated payload",
+			peer:     append(rawFrame(VERSION, FRAME_HELLO, []byte{MIN_VERSION, VERSION}), rawFrame(VERSION, FRAME_MESSAGE, []byte("hello"))[:HEADER_SIZE+2]...),
+			wantKind: ErrCorruptFrame,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peerConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+
+			server := NewConn(serverConn)
+			server.SetMaxFrameSize(64)
+
+			//the peer writes its bytes and reads back whatever it is answered with
+			answers := make(chan []byte, 1)
+			go func() {
+				peerConn.Write(tt.peer)
+				peerConn.Close()
+			}()
+			go func() {
+				var answer bytes.Buffer
+				io.Copy(&answer, peerConn)
+				answers <- answer.Bytes()
+			}()
+
+			_, err := server.Receive()
+			if !errors.Is(err, ErrCorruptFrame) || !errors.Is(err, tt.wantKind) {
+				t.Fatalf("Receive() error = %v, want %v", err, tt.wantKind)
+			}
+			serverConn.Close()
+
+			if tt.wantCode == 0 {
+				return
+			}
+			answer := <-answers
+			//a hello may come before the error frame
+			for len(answer) >= HEADER_SIZE && FrameType(answer[5]) == FRAME_HELLO {
+				answer = answer[HEADER_SIZE+int(binary.LittleEndian.Uint32(answer[8:])):]
+			}
+			if len(answer) < HEADER_SIZE+2 || FrameType(answer[5]) != FRAME_ERROR {
+				t.Fatalf("peer was answered %v, want an error frame", answer)
+			}
+			remote := decodeError(answer[HEADER_SIZE:]).(*RemoteError)
+			if remote.Code != tt.wantCode {
+				t.Errorf("peer was answered %v, want %v", remote.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestConn_Send(t *testing.T) {
+	peerConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+
+	//an older peer turns the handshake down
+	go func() {
+		header := make([]byte, HEADER_SIZE+2)
+		io.ReadFull(peerConn, header)
+		peerConn.Write(rawFrame(1, FRAME_ERROR, encodeError(ERROR_VERSION_MISMATCH, "too new")))
+		peerConn.Close()
+	}()
+
+	client := NewConn(clientConn)
+	err := client.Send([]byte("hello"))
+	if !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("Send() error = %v, want %v", err, ErrVersionMismatch)
+	}
+	//the failed handshake is not tried again
+	if err2 := client.Send([]byte("hello")); err2 != err {
+		t.Errorf("Send() error = %v, want %v", err2, err)
+	}
+}
@@ -1,13 +1,25 @@
 package proto
 
 import (
+	"errors"
+	"go.uber.org/zap"
+	"src/file"
 	messages "src/messages/client_storage"
 )
 
+// ErrFileExists is returned when a node already holds the fragment a put is for. A node only keeps a
+// fragment once its checksum matched, so the copy it holds is complete.
+var ErrFileExists = errors.New(messages.ErrorCode_FILE_ALREADY_EXISTS.String())
+
 func (p *ProtoHandler) HandleFileGetRequest(fragID string) (err error) {
+	return p.HandleFileRangeRequest(fragID, 0, 0)
+}
+
+// HandleFileRangeRequest asks for bytes [offset, offset+length) of a fragment, up to its end for a length of 0.
+func (p *ProtoHandler) HandleFileRangeRequest(fragID string, offset int64, length int64) (err error) {
 
 	p.logger.Info("Handling File Get Request")
-	msg := messages.FileGetRequest{FileName: fragID}
+	msg := messages.FileGetRequest{FileName: fragID, Streamed: true, Offset: offset, Length: length}
 	p.sendClientGetRequest(p.msgHandler, &messages.ClientRequest_FileGetRequest{FileGetRequest: &msg})
 
 	return
@@ -22,19 +34,80 @@ func (p *ProtoHandler) fetchFileGetResponse(msg *messages.ServerResponse_FileGet
 		fileHandler := p.FileHandler()
 		p.logger.Sugar().Infof("File Name: %s", fileHandler.FileName())
 
+		if msg.FileGetResponse.Streamed && p.receiver != nil {
+			p.partial, p.receiver = p.receiver, nil
+			return
+		}
+		if msg.FileGetResponse.Streamed {
+			var partial *file.PartialFile
+			partial, err = fileHandler.CreatePartial()
+			if err == nil {
+				p.partial = partial
+			}
+			return
+		}
+
 		fileHandler.SetDataStream(msg.FileGetResponse.MessageBody)
 		fileHandler.FindAndSetCheckSum()
 
 		if !fileHandler.CompareChecksum(msg.FileGetResponse.Checksum) {
 			p.logger.Info("Failed in checking the checksum")
+			err = errors.New(messages.ErrorCode_CHECKSUM_MISMATCH.String())
 		} else {
 			p.logger.Info("Success in checking the checksum")
-			fileHandler.WriteFile()
+			err = fileHandler.WriteFile()
 		}
 
 	} else {
 
 		p.logger.Info("Failed in FileGetResponse")
+		err = errors.New(msg.FileGetResponse.ErrorCode.String())
+	}
+	return
+}
+
+// fetchFileChunkResponse writes one frame of a streamed GET to disk, and commits the fragment on the last frame.
+func (p *ProtoHandler) fetchFileChunkResponse(msg *messages.ServerResponse_FileChunk) (err error) {
+
+	if p.partial == nil {
+		return errors.New("received a chunk without a streamed get response")
+	}
+
+	chunk := toChunk(msg.FileChunk)
+	err = p.partial.WriteChunk(chunk)
+	if err == nil && chunk.Last {
+		err = p.partial.Commit(chunk.Checksum)
+		p.partial = nil
+		if err == nil {
+			p.logger.Sugar().Infof("Fetched %s, checksums match", p.FileHandler().FileName())
+		}
+	}
+
+	if err != nil {
+		p.logger.Error("Error receiving file data", zap.Error(err))
+		p.AbortTransfer()
+	}
+	return
+}
+
+func (p *ProtoHandler) HandleFileDeleteRequest(fragID string) (err error) {
+
+	p.logger.Info("Sending File Delete Request")
+	msg := messages.FileDeleteRequest{FileName: fragID}
+	wrapper := &messages.ClientRequest{
+		Request: &messages.ClientRequest_FileDeleteRequest{FileDeleteRequest: &msg},
+	}
+	err = p.msgHandler.ClientRequestSend(wrapper)
+	return
+}
+
+func (p *ProtoHandler) fetchFileDeleteResponse(msg *messages.ServerResponse_FileDeleteResponse) (err error) {
+
+	if msg.FileDeleteResponse.Success {
+		p.logger.Sugar().Infof("Fragment %s deleted", msg.FileDeleteResponse.FileName)
+	} else {
+		p.logger.Sugar().Infof("Failed to delete fragment %s", msg.FileDeleteResponse.FileName)
+		err = errors.New(msg.FileDeleteResponse.ErrorCode.String())
 	}
 	return
 }
@@ -52,9 +125,13 @@ func (p *ProtoHandler) fetchFilePutResponse(msg *messages.ServerResponse_FilePut
 
 	if msg.FilePutResponse.Success {
 		p.logger.Info("File Put Request Success")
-		p.handleFileDataRequest(msg.FilePutResponse.FileName)
+		err = p.handleFileDataStream(msg.FilePutResponse.FileName)
 	} else {
 		p.logger.Info("File Put Request Failed")
+		err = errors.New(msg.FilePutResponse.ErrorCode.String())
+		if msg.FilePutResponse.ErrorCode == messages.ErrorCode_FILE_ALREADY_EXISTS {
+			err = ErrFileExists
+		}
 	}
 	return
 }
@@ -70,6 +147,48 @@ func (p *ProtoHandler) handleFileDataRequest(fragID string) (err error) {
 	return
 }
 
+// handleFileDataStream sends the fragment in FileChunk frames, reading it from disk one frame at a time.
+func (p *ProtoHandler) handleFileDataStream(fragID string) (err error) {
+	p.logger.Info("Streaming File Data")
+	fragment := p.FileHandler().FragmentMap()[fragID]
+	msg := messages.FileDataRequest{
+		FileName:    fragID,
+		Streamed:    true,
+		FileSize:    fragment.FragSize(),
+		OtherNodes:  fragment.Location(),
+		MinReplicas: uint32(p.minReplicas),
+	}
+	p.sendClientDataRequest(p.msgHandler, &messages.ClientRequest_FileDataRequest{FileDataRequest: &msg})
+
+	err = p.FileHandler().StreamFragment(fragID, func(chunk *file.Chunk) error {
+		return p.msgHandler.ClientRequestSend(&messages.ClientRequest{
+			Request: &messages.ClientRequest_FileChunk{FileChunk: fromChunk(fragID, chunk)},
+		})
+	})
+	if err != nil {
+		p.logger.Error("Error streaming fragment", zap.Error(err))
+	}
+	return
+}
+
+// fetchFileDataResponse reports every replica of the pipeline, and fails unless enough of them were written.
+func (p *ProtoHandler) fetchFileDataResponse(msg *messages.ServerResponse_FileDataResponse) (err error) {
+
+	for _, replica := range msg.FileDataResponse.Replicas {
+		if replica.Success {
+			p.logger.Sugar().Infof("Replica written on %s", replica.Host)
+		} else {
+			p.logger.Sugar().Errorf("Replica failed on %s: %s", replica.Host, replica.Error)
+		}
+	}
+
+	if !msg.FileDataResponse.Success {
+		p.logger.Sugar().Errorf("File Data Request Failed: %s", msg.FileDataResponse.ErrorCode)
+		err = errors.New(msg.FileDataResponse.ErrorCode.String())
+	}
+	return
+}
+
 /*
 func (p *ProtoHandler) handleFileDataRequest() (err error) {
 	//fileSize := p.FileSize()
@@ -12,6 +12,10 @@ type ProtoHandler struct {
 	msgHandler  *messages.MessageHandler
 	logger      *zap.Logger
 	dir         string
+	partial     file.Receiver
+	receiver    file.Receiver
+	pipeline    file.Pipeline
+	minReplicas int
 }
 
 func (p *ProtoHandler) FileHandler() *file.FileHandler {
@@ -22,6 +26,21 @@ func (p *ProtoHandler) SetFileHandler(fileHandler *file.FileHandler) {
 	p.fileHandler = fileHandler
 }
 
+// SetReceiver makes the next streamed GET hand its frames to r instead of writing the fragment to a file.
+func (p *ProtoHandler) SetReceiver(r file.Receiver) {
+	p.receiver = r
+}
+
+// SetPipeline sets where a streamed PUT is forwarded to as it is written.
+func (p *ProtoHandler) SetPipeline(pipeline file.Pipeline) {
+	p.pipeline = pipeline
+}
+
+// SetMinReplicas sets how many replicas a PUT needs before it is acknowledged. 0 means all of them.
+func (p *ProtoHandler) SetMinReplicas(minReplicas int) {
+	p.minReplicas = minReplicas
+}
+
 func (p *ProtoHandler) SetMsgHandler(msgHandler *messages.MessageHandler) {
 	p.msgHandler = msgHandler
 }
@@ -38,6 +57,40 @@ func (p *ProtoHandler) MsgHandler() *messages.MessageHandler {
 	return p.msgHandler
 }
 
+// TransferPending reports whether a streamed transfer has started but not yet been committed.
+func (p *ProtoHandler) TransferPending() bool {
+	return p.partial != nil
+}
+
+// AbortTransfer removes the partial file of a streamed transfer that did not complete.
+func (p *ProtoHandler) AbortTransfer() {
+	if p.partial != nil {
+		p.partial.Abort()
+		p.partial = nil
+	}
+}
+
+func toChunk(msg *messages.FileChunk) *file.Chunk {
+	return &file.Chunk{
+		Offset:       msg.Offset,
+		Data:         msg.Data,
+		RunningCRC32: msg.RunningCrc32,
+		Last:         msg.Last,
+		Checksum:     msg.Checksum,
+	}
+}
+
+func fromChunk(fileName string, chunk *file.Chunk) *messages.FileChunk {
+	return &messages.FileChunk{
+		FileName:     fileName,
+		Offset:       chunk.Offset,
+		Data:         chunk.Data,
+		RunningCrc32: chunk.RunningCRC32,
+		Last:         chunk.Last,
+		Checksum:     chunk.Checksum,
+	}
+}
+
 //func (p *ProtoHandler) FFileHandler() *file.FileHandler {
 //	return p.FileHandler
 //}
@@ -52,28 +105,39 @@ func NewProtoHandler(msgHandler *messages.MessageHandler, logger *zap.Logger, di
 	return newProtoHandler
 }
 
-func (p *ProtoHandler) HandleResponse(wrapper *messages.ServerResponse) {
+func (p *ProtoHandler) HandleResponse(wrapper *messages.ServerResponse) (err error) {
 
 	switch msg := wrapper.Response.(type) {
 
 	case *messages.ServerResponse_FilePutResponse:
 
-		p.fetchFilePutResponse(msg)
+		err = p.fetchFilePutResponse(msg)
 
 	case *messages.ServerResponse_FileDataResponse:
 
 		fmt.Println("Received: ", msg.FileDataResponse.Success)
 		fmt.Println("Received: ", msg.FileDataResponse.ErrorCode)
+		err = p.fetchFileDataResponse(msg)
 
 	case *messages.ServerResponse_FileGetResponse:
 		p.logger.Info("Received FileGetResponse")
-		p.fetchFileGetResponse(msg)
+		err = p.fetchFileGetResponse(msg)
+		return
+
+	case *messages.ServerResponse_FileChunk:
+		err = p.fetchFileChunkResponse(msg)
+		return
+
+	case *messages.ServerResponse_FileDeleteResponse:
+		p.logger.Info("Received FileDeleteResponse")
+		err = p.fetchFileDeleteResponse(msg)
 		return
 
 	case nil:
 		return
 	}
 
+	return
 }
 
 type Req struct {
@@ -107,6 +171,17 @@ func (p *ProtoHandler) HandleRequest(wrapper *messages.ClientRequest) (req *Req,
 
 	case *messages.ClientRequest_FileDataRequest:
 		p.logger.Info("Received FileDataRequest")
+		if msg.FileDataRequest.Streamed {
+			err = p.fetchFileDataStreamRequest(msg)
+			if err != nil {
+				return
+			}
+			req = &Req{
+				Operation: "CHUNK",
+				Success:   true,
+			}
+			return
+		}
 		result, err = p.fetchFileDataRequest(msg)
 		if err != nil {
 			p.logger.Error("FileDataRequest Failed", zap.Error(err))
@@ -121,6 +196,27 @@ func (p *ProtoHandler) HandleRequest(wrapper *messages.ClientRequest) (req *Req,
 			return
 		}
 
+	case *messages.ClientRequest_FileChunk:
+		var res *file.FileHandler
+		res, err = p.fetchFileChunk(msg)
+		if err != nil {
+			return
+		}
+		if res == nil {
+			req = &Req{
+				Operation: "CHUNK",
+				Success:   true,
+			}
+			return
+		}
+		p.logger.Info("FileDataRequest Success")
+		req = &Req{
+			Operation: "DATA",
+			Success:   true,
+			Result:    res,
+			DataType:  "STREAM",
+		}
+
 	case *messages.ClientRequest_FileGetRequest:
 
 		p.fileHandler = &file.FileHandler{}
@@ -135,6 +231,20 @@ func (p *ProtoHandler) HandleRequest(wrapper *messages.ClientRequest) (req *Req,
 			Success:   true,
 		}
 
+	case *messages.ClientRequest_FileDeleteRequest:
+		p.logger.Info("Received FileDeleteRequest")
+		p.fileHandler = &file.FileHandler{}
+		err = p.fetchFileDeleteRequest(msg)
+		if err != nil {
+			p.logger.Error("FileDeleteRequest Failed", zap.Error(err))
+			return
+		}
+		p.logger.Info("FileDeleteRequest Success")
+		req = &Req{
+			Operation: "DELETE",
+			Success:   true,
+		}
+
 	}
 	return
 }
@@ -3,6 +3,7 @@ package proto
 import (
 	"errors"
 	"fmt"
+	"go.uber.org/zap"
 	FileHandler "src/file"
 	messages "src/messages/client_storage"
 )
@@ -81,6 +82,109 @@ func (p *ProtoHandler) fetchFileDataRequest(msg *messages.ClientRequest_FileData
 
 }
 
+// fetchFileDataStreamRequest opens the partial file the following FileChunk frames are written to,
+// and the replication pipeline they are forwarded to.
+func (p *ProtoHandler) fetchFileDataStreamRequest(msg *messages.ClientRequest_FileDataRequest) (err error) {
+
+	fileHandler := p.FileHandler()
+	fileHandler.SetDir(p.dir)
+	fileHandler.SetFileSize(msg.FileDataRequest.FileSize)
+	fileHandler.SetLocation(msg.FileDataRequest.OtherNodes)
+	p.minReplicas = int(msg.FileDataRequest.MinReplicas)
+	p.logger.Sugar().Infof("Receiving %d bytes of %s in frames", msg.FileDataRequest.FileSize, fileHandler.FileName())
+
+	if p.pipeline != nil {
+		p.pipeline.Open(fileHandler.FileName(), fileHandler.FileSize(), fileHandler.Location())
+	}
+
+	partial, err := fileHandler.CreatePartial()
+	if err != nil {
+		p.logger.Error("Error creating partial file", zap.Error(err))
+		p.handleFileDataStreamResponse(err)
+		return
+	}
+	p.partial = partial
+	return
+}
+
+// fetchFileChunk writes one frame of a streamed PUT to disk and forwards it down the pipeline.
+// Once the last frame is committed it returns the file handler, until then res is nil.
+func (p *ProtoHandler) fetchFileChunk(msg *messages.ClientRequest_FileChunk) (res *FileHandler.FileHandler, err error) {
+
+	if p.partial == nil {
+		return nil, errors.New("received a chunk without a streamed data request")
+	}
+
+	chunk := toChunk(msg.FileChunk)
+	err = p.partial.WriteChunk(chunk)
+	if err == nil && p.pipeline != nil {
+		p.pipeline.Forward(chunk)
+	}
+	if err == nil && chunk.Last {
+		err = p.partial.Commit(chunk.Checksum)
+		p.partial = nil
+	}
+
+	if err != nil {
+		p.logger.Error("Error receiving file data", zap.Error(err))
+		p.AbortTransfer()
+		p.handleFileDataStreamResponse(err)
+		return
+	}
+
+	if !chunk.Last {
+		return
+	}
+
+	p.logger.Sugar().Infof("Received %d bytes, checksums match", p.FileHandler().FileSize())
+	p.FileHandler().ChecksumOnDisk()
+
+	err = p.handleFileDataStreamResponse(nil)
+	if err != nil {
+		return
+	}
+	return p.FileHandler(), nil
+}
+
+// handleFileDataStreamResponse waits for the rest of the pipeline and acks the PUT only once
+// enough replicas have been written and verified.
+func (p *ProtoHandler) handleFileDataStreamResponse(local error) (err error) {
+
+	var replicas []FileHandler.ReplicaStatus
+	if p.pipeline != nil {
+		replicas = p.pipeline.Wait(local)
+	} else {
+		replicas = []FileHandler.ReplicaStatus{FileHandler.NewReplicaStatus("", local)}
+	}
+
+	required := p.minReplicas
+	if required == 0 {
+		required = len(replicas)
+	}
+	written := FileHandler.CountReplicas(replicas)
+
+	res := messages.FileDataResponse{Success: true, ErrorCode: messages.ErrorCode_NO_ERROR}
+	switch {
+	case errors.Is(local, FileHandler.ErrChecksumMismatch) || errors.Is(local, FileHandler.ErrChunkCorrupted):
+		res = messages.FileDataResponse{Success: false, ErrorCode: messages.ErrorCode_CHECKSUM_MISMATCH}
+	case local != nil:
+		res = messages.FileDataResponse{Success: false, ErrorCode: messages.ErrorCode_SERVER_ERROR}
+	case written < required:
+		res = messages.FileDataResponse{Success: false, ErrorCode: messages.ErrorCode_NOT_ENOUGH_REPLICAS}
+	}
+
+	for _, replica := range replicas {
+		res.Replicas = append(res.Replicas, &messages.ReplicaStatus{Host: replica.Host, Success: replica.Success, Error: replica.Error})
+	}
+	p.logger.Sugar().Infof("%d of %d replicas written, %d required", written, len(replicas), required)
+
+	p.sendServerDataResponse(p.msgHandler, &messages.ServerResponse_FileDataResponse{FileDataResponse: &res})
+	if !res.Success {
+		err = errors.New(res.ErrorCode.String())
+	}
+	return
+}
+
 func (p *ProtoHandler) handleFileDataResponse(checksumCheck bool) (err error) {
 
 	var res messages.FileDataResponse
@@ -125,10 +229,63 @@ func (p *ProtoHandler) fetchFileGetRequest(msg *messages.ClientRequest_FileGetRe
 	p.FileHandler().SetDir(p.dir)
 
 	fileExists, _ := p.FileHandler().FileCheck()
+	if fileExists && msg.FileGetRequest.Streamed {
+		err = p.handleFileGetStreamResponse(msg.FileGetRequest.Offset, msg.FileGetRequest.Length)
+		return
+	}
 	err = p.handleFileGetResponse(fileExists)
 	return nil
 }
 
+// handleFileGetStreamResponse sends the FileGetResponse header followed by bytes [offset, offset+length) of the
+// file in FileChunk frames. A length of 0 sends up to the end of the file.
+func (p *ProtoHandler) handleFileGetStreamResponse(offset int64, length int64) (err error) {
+
+	fileHandler := p.FileHandler()
+	fileHandler.CalcFileSize()
+
+	start, end := clipRange(fileHandler.FileSize(), offset, length)
+	res := messages.FileGetResponse{
+		Success:   true,
+		FileSize:  end - start,
+		ErrorCode: messages.ErrorCode_NO_ERROR,
+		Streamed:  true,
+	}
+	err = p.sendFileGetResponse(p.msgHandler, &messages.ServerResponse_FileGetResponse{FileGetResponse: &res})
+	if err != nil {
+		return
+	}
+
+	err = fileHandler.StreamRange(start, end, func(chunk *FileHandler.Chunk) error {
+		return p.msgHandler.ServerResponseSend(&messages.ServerResponse{
+			Response: &messages.ServerResponse_FileChunk{FileChunk: fromChunk(fileHandler.FileName(), chunk)},
+		})
+	})
+	if err != nil {
+		p.logger.Error("Error streaming file", zap.Error(err))
+		return
+	}
+
+	p.logger.Sugar().Infof("Streamed %d bytes of %s", end-start, fileHandler.FileName())
+	return
+}
+
+// clipRange turns offset and length into the range [start, end) of a file of the given size.
+func clipRange(size int64, offset int64, length int64) (start int64, end int64) {
+
+	start, end = offset, size
+	if start < 0 {
+		start = 0
+	}
+	if start > size {
+		start = size
+	}
+	if length > 0 && start+length < end {
+		end = start + length
+	}
+	return
+}
+
 func (p *ProtoHandler) handleFileGetResponse(fileExists bool) (err error) {
 
 	var res messages.FileGetResponse
@@ -173,6 +330,47 @@ func (p *ProtoHandler) sendFileGetResponse(msgHandler *messages.MessageHandler,
 	wrapper := &messages.ServerResponse{
 		Response: response,
 	}
-	msgHandler.ServerResponseSend(wrapper)
+	err = msgHandler.ServerResponseSend(wrapper)
+	return
+}
+
+func (p *ProtoHandler) fetchFileDeleteRequest(msg *messages.ClientRequest_FileDeleteRequest) (err error) {
+
+	fileName := msg.FileDeleteRequest.FileName
+	p.logger.Sugar().Infof("Deleting fragment %s", fileName)
+
+	p.FileHandler().SetFileName(fileName)
+	p.FileHandler().SetDir(p.dir)
+
+	errD := p.FileHandler().DeleteFile()
+	if errD != nil {
+		p.logger.Error("Error deleting file", zap.Error(errD))
+	}
+
+	err = p.handleFileDeleteResponse(errD == nil, fileName)
+	if errD != nil {
+		return errD
+	}
+	return
+}
+
+func (p *ProtoHandler) handleFileDeleteResponse(deleted bool, fileName string) (err error) {
+
+	var res messages.FileDeleteResponse
+	if deleted {
+		res = messages.FileDeleteResponse{Success: true, ErrorCode: messages.ErrorCode_NO_ERROR, FileName: fileName}
+	} else {
+		res = messages.FileDeleteResponse{Success: false, ErrorCode: messages.ErrorCode_SERVER_ERROR, FileName: fileName}
+	}
+
+	err = p.sendFileDeleteResponse(p.msgHandler, &messages.ServerResponse_FileDeleteResponse{FileDeleteResponse: &res})
+	return
+}
+
+func (p *ProtoHandler) sendFileDeleteResponse(msgHandler *messages.MessageHandler, response *messages.ServerResponse_FileDeleteResponse) (err error) {
+	wrapper := &messages.ServerResponse{
+		Response: response,
+	}
+	err = msgHandler.ServerResponseSend(wrapper)
 	return
 }
@@ -1,8 +1,13 @@
 package controller_client
 
-import messages "src/messages/controller_client"
-
-func (p *ProtoHandler) HandlePutRequest(fileName string, fileSize int64, chunkSize int64) {
+import (
+	"src/erasure"
+	messages "src/messages/controller_client"
+)
+
+// HandlePutRequest asks the Controller for a plan. Non-zero dataShards and parityShards store the file erasure coded,
+// a non-empty uploadId resumes an interrupted PUT. owner is the user storing the file.
+func (p *ProtoHandler) HandlePutRequest(fileName string, fileSize int64, chunkSize int64, replicationFactor int, dataShards int, parityShards int, uploadId string, owner string) {
 	res := &messages.ClientMessage{
 		ClientMessage: &messages.ClientMessage_PutRequest_{
 			PutRequest: &messages.ClientMessage_PutRequest{
@@ -10,6 +15,11 @@ func (p *ProtoHandler) HandlePutRequest(fileName string, fileSize int64, chunkSi
 				Filename:          fileName,
 				Filesize:          fileSize,
 				OptionalChunkSize: chunkSize,
+				ReplicationFactor: uint32(replicationFactor),
+				DataShards:        uint32(dataShards),
+				ParityShards:      uint32(parityShards),
+				UploadId:          uploadId,
+				Owner:             owner,
 			},
 		},
 	}
@@ -27,6 +37,11 @@ type FragmentInfo struct {
 	FragmentId   string
 	Size         int64
 	StorageNodes []StorageNodeInfo
+	//position of the fragment in the file, or of the shard in an erasure coded file
+	Index int
+	//set for a range read, the bytes of the fragment to fetch
+	Offset int64
+	Length int64
 }
 
 type PlanResponse struct {
@@ -34,6 +49,10 @@ type PlanResponse struct {
 	StatusCode        string
 	TotalNumFragments uint32
 	FragmentLayout    []FragmentInfo
+	//nil unless the file is erasure coded, FragmentLayout then lists its shards
+	Erasure *erasure.Layout
+	//sent with a later PUT of the file to resume this one
+	UploadId string
 }
 
 type FragLayoutResponse struct {
@@ -41,6 +60,11 @@ type FragLayoutResponse struct {
 	StatusCode        string
 	TotalNumFragments uint32
 	FragmentLayout    []FragmentInfo
+	Erasure           *erasure.Layout
+	UploadId          string
+	//set for a range read, the bytes of the file it covers
+	RangeOffset int64
+	RangeLength int64
 }
 
 func (pr *FragLayoutResponse) GetResType() string {
@@ -75,6 +99,8 @@ func (p *ProtoHandler) fetchPlanResponse(msg *messages.ControllerMessage_PlanRes
 		StatusCode:        msg.PlanResponse.StatusCode.String(),
 		TotalNumFragments: msg.PlanResponse.TotalNumFragments,
 		FragmentLayout:    make([]FragmentInfo, 0),
+		Erasure:           fromErasureCoding(msg.PlanResponse.ErasureCoding),
+		UploadId:          msg.PlanResponse.UploadId,
 	}
 
 	i := 0
@@ -86,6 +112,7 @@ func (p *ProtoHandler) fetchPlanResponse(msg *messages.ControllerMessage_PlanRes
 			FragmentId:   frag.FragmentId,
 			Size:         frag.Size,
 			StorageNodes: make([]StorageNodeInfo, 0),
+			Index:        int(frag.Index),
 		})
 
 		for _, node := range frag.StorageNodeIds {
@@ -120,6 +147,10 @@ func (p *ProtoHandler) fetchLayoutResponse(msg *messages.ControllerMessage_FragL
 		StatusCode:        msg.FragLayoutResponse.StatusCode.String(),
 		TotalNumFragments: msg.FragLayoutResponse.TotalNumFragments,
 		FragmentLayout:    make([]FragmentInfo, 0),
+		Erasure:           fromErasureCoding(msg.FragLayoutResponse.ErasureCoding),
+		UploadId:          msg.FragLayoutResponse.UploadId,
+		RangeOffset:       msg.FragLayoutResponse.RangeOffset,
+		RangeLength:       msg.FragLayoutResponse.RangeLength,
 	}
 
 	i := 0
@@ -131,6 +162,9 @@ func (p *ProtoHandler) fetchLayoutResponse(msg *messages.ControllerMessage_FragL
 			FragmentId:   frag.FragmentId,
 			Size:         frag.Size,
 			StorageNodes: make([]StorageNodeInfo, 0),
+			Index:        int(frag.Index),
+			Offset:       frag.Offset,
+			Length:       frag.Length,
 		})
 
 		for _, node := range frag.StorageNodeIds {
@@ -148,14 +182,62 @@ func (p *ProtoHandler) fetchLayoutResponse(msg *messages.ControllerMessage_FragL
 
 }
 
-func (p *ProtoHandler) fetchDeleteResponse(msg *messages.ControllerMessage_DeleteResponse_) {
+type FailedReplica struct {
+	FragmentId string
+	NodeId     string
+	Host       string
+	Port       string
+}
+
+type DeleteResponse struct {
+	ResponseType   string
+	StatusCode     string
+	FailedReplicas []FailedReplica
+}
+
+func (pr *DeleteResponse) GetResType() string {
+	return pr.ResponseType
+}
+
+func (p *ProtoHandler) fetchDeleteResponse(msg *messages.ControllerMessage_DeleteResponse_) (res ResponseInterface) {
+
+	p.logger.Info("Received delete response from the Controller.")
+	p.logger.Sugar().Info("Status code: ", msg.DeleteResponse.StatusCode.String())
 
+	res = &DeleteResponse{
+		ResponseType:   "DeleteResponse",
+		StatusCode:     msg.DeleteResponse.StatusCode.String(),
+		FailedReplicas: make([]FailedReplica, 0),
+	}
+
+	for _, replica := range msg.DeleteResponse.FailedReplicas {
+		res.(*DeleteResponse).FailedReplicas = append(res.(*DeleteResponse).FailedReplicas, FailedReplica{
+			FragmentId: replica.FragmentId,
+			NodeId:     replica.StorageNodeId,
+			Host:       replica.Host,
+			Port:       replica.Port,
+		})
+	}
+
+	return
+}
+
+type LsEntry struct {
+	Name            string
+	Dir             bool
+	Size            int64
+	Fragments       int
+	UnderReplicated bool
 }
 
 type LsResponse struct {
 	ResponseType string
 	StatusCode   string
 	Files        []string
+	Dirs         []string
+	//the listed page, sorted by name
+	Entries       []LsEntry
+	NextPageToken string
 }
 
 func (pr *LsResponse) GetResType() string {
@@ -179,11 +261,25 @@ func (p *ProtoHandler) fetchLsResponse(msg *messages.ControllerMessage_LsRespons
 		ResponseType: "LsResponse",
 		StatusCode:   msg.LsResponse.StatusCode.String(),
 		Files:        make([]string, 0),
+		Dirs:         make([]string, 0),
 	}
 
 	for _, file := range msg.LsResponse.FileNames {
 		res.(*LsResponse).Files = append(res.(*LsResponse).Files, file)
 	}
+	for _, dir := range msg.LsResponse.DirNames {
+		res.(*LsResponse).Dirs = append(res.(*LsResponse).Dirs, dir)
+	}
+	for _, entry := range msg.LsResponse.Entries {
+		res.(*LsResponse).Entries = append(res.(*LsResponse).Entries, LsEntry{
+			Name:            entry.Name,
+			Dir:             entry.Dir,
+			Size:            entry.Size,
+			Fragments:       int(entry.NumFragments),
+			UnderReplicated: entry.UnderReplicated,
+		})
+	}
+	res.(*LsResponse).NextPageToken = msg.LsResponse.NextPageToken
 
 	return
 
@@ -236,3 +332,114 @@ func (p *ProtoHandler) fetchNodeStats(msg *messages.ControllerMessage_NodeStats_
 	return res
 
 }
+
+type CommitResponse struct {
+	ResponseType string
+	StatusCode   string
+}
+
+func (pr *CommitResponse) GetResType() string {
+	return pr.ResponseType
+}
+
+func (p *ProtoHandler) fetchCommitResponse(msg *messages.ControllerMessage_CommitResponse_) (res ResponseInterface) {
+
+	p.logger.Info("Received commit response from the Controller.")
+	p.logger.Sugar().Info("Status code: ", msg.CommitResponse.StatusCode.String())
+
+	return &CommitResponse{
+		ResponseType: "CommitResponse",
+		StatusCode:   msg.CommitResponse.StatusCode.String(),
+	}
+}
+
+type NamespaceResponse struct {
+	ResponseType string
+	StatusCode   string
+}
+
+func (pr *NamespaceResponse) GetResType() string {
+	return pr.ResponseType
+}
+
+func (p *ProtoHandler) fetchNamespaceResponse(msg *messages.ControllerMessage_NamespaceResponse_) (res ResponseInterface) {
+
+	p.logger.Info("Received namespace response from the Controller.")
+	p.logger.Sugar().Info("Status code: ", msg.NamespaceResponse.StatusCode.String())
+
+	return &NamespaceResponse{
+		ResponseType: "NamespaceResponse",
+		StatusCode:   msg.NamespaceResponse.StatusCode.String(),
+	}
+}
+
+// FragmentStat is a fragment of a file, the live nodes holding it and how healthy it is.
+type FragmentStat struct {
+	FragmentId   string
+	Index        int
+	Size         int64
+	StorageNodes []StorageNodeInfo
+	//HEALTHY, UNDER_REPLICATED or MISSING
+	Health string
+}
+
+type StatResponse struct {
+	ResponseType      string
+	StatusCode        string
+	FileName          string
+	Size              int64
+	ChunkSize         int64
+	NumFragments      uint32
+	ReplicationFactor int
+	Erasure           *erasure.Layout
+	//unix time in milliseconds, 0 if not known
+	Created   int64
+	Owner     string
+	Checksum  string
+	Fragments []FragmentStat
+}
+
+func (pr *StatResponse) GetResType() string {
+	return pr.ResponseType
+}
+
+func (p *ProtoHandler) fetchStatResponse(msg *messages.ControllerMessage_StatResponse_) (res ResponseInterface) {
+
+	p.logger.Info("Received stat response from the Controller.")
+	p.logger.Sugar().Info("Status code: ", msg.StatResponse.StatusCode.String())
+
+	stat := &StatResponse{
+		ResponseType:      "StatResponse",
+		StatusCode:        msg.StatResponse.StatusCode.String(),
+		FileName:          msg.StatResponse.FileName,
+		Size:              msg.StatResponse.Size,
+		ChunkSize:         msg.StatResponse.ChunkSize,
+		NumFragments:      msg.StatResponse.NumFragments,
+		ReplicationFactor: int(msg.StatResponse.ReplicationFactor),
+		Erasure:           fromErasureCoding(msg.StatResponse.ErasureCoding),
+		Created:           msg.StatResponse.Created,
+		Owner:             msg.StatResponse.Owner,
+		Checksum:          msg.StatResponse.Checksum,
+		Fragments:         make([]FragmentStat, 0, len(msg.StatResponse.Fragments)),
+	}
+
+	for _, frag := range msg.StatResponse.Fragments {
+		fragStat := FragmentStat{
+			FragmentId:   frag.FragmentId,
+			Index:        int(frag.Index),
+			Size:         frag.Size,
+			StorageNodes: make([]StorageNodeInfo, 0, len(frag.Replicas)),
+			Health:       frag.Health.String(),
+		}
+		for _, node := range frag.Replicas {
+			fragStat.StorageNodes = append(fragStat.StorageNodes, StorageNodeInfo{
+				NodeId: node.StorageNodeId,
+				Host:   node.Host,
+				Port:   node.Port,
+			})
+		}
+		stat.Fragments = append(stat.Fragments, fragStat)
+	}
+
+	return stat
+}
@@ -3,10 +3,31 @@ package controller_client
 import messages "src/messages/controller_client"
 
 type Request struct {
-	reqType   string
-	fileName  string
-	fileSize  int64
-	chunkSize int64
+	reqType string
+	//the path the request is about, the source of a RENAME and the directory listed by LIST
+	fileName string
+	//where a RENAME moves fileName to
+	destination string
+	fileSize    int64
+	chunkSize   int64
+
+	replicationFactor int
+	dataShards        int
+	parityShards      int
+	uploadId          string
+	//the user storing the file of a PUT
+	owner string
+	//the checksum of the whole file a COMMIT makes visible
+	checksum string
+
+	//which page of the entries of a LIST, see storage_handler.ListOptions
+	pattern   string
+	pageToken string
+	limit     int
+
+	//the range of a GET, a length of 0 reads to the end
+	offset int64
+	length int64
 }
 
 func (r *Request) GetReqType() string {
@@ -17,6 +38,10 @@ func (r *Request) GetFileName() string {
 	return r.fileName
 }
 
+func (r *Request) GetDestination() string {
+	return r.destination
+}
+
 func (r *Request) GetFileSize() int64 {
 	return r.fileSize
 }
@@ -25,6 +50,55 @@ func (r *Request) GetChunkSize() int64 {
 	return r.chunkSize
 }
 
+func (r *Request) GetReplicationFactor() int {
+	return r.replicationFactor
+}
+
+func (r *Request) GetDataShards() int {
+	return r.dataShards
+}
+
+func (r *Request) GetParityShards() int {
+	return r.parityShards
+}
+
+func (r *Request) GetUploadId() string {
+	return r.uploadId
+}
+
+func (r *Request) GetOwner() string {
+	return r.owner
+}
+
+func (r *Request) GetChecksum() string {
+	return r.checksum
+}
+
+func (r *Request) GetPattern() string {
+	return r.pattern
+}
+
+func (r *Request) GetPageToken() string {
+	return r.pageToken
+}
+
+func (r *Request) GetLimit() int {
+	return r.limit
+}
+
+func (r *Request) GetOffset() int64 {
+	return r.offset
+}
+
+func (r *Request) GetLength() int64 {
+	return r.length
+}
+
+// Ranged reports whether a GET reads only part of the file.
+func (r *Request) Ranged() bool {
+	return r.offset != 0 || r.length != 0
+}
+
 func (p *ProtoHandler) fetchPutRequest(msg *messages.ClientMessage_PutRequest_) *Request {
 	p.logger.Info("Received Put Request")
 	putReq := &Request{
@@ -32,6 +106,12 @@ func (p *ProtoHandler) fetchPutRequest(msg *messages.ClientMessage_PutRequest_)
 		fileName:  msg.PutRequest.Filename,
 		fileSize:  int64(msg.PutRequest.Filesize),
 		chunkSize: int64(msg.PutRequest.OptionalChunkSize),
+
+		replicationFactor: int(msg.PutRequest.ReplicationFactor),
+		dataShards:        int(msg.PutRequest.DataShards),
+		parityShards:      int(msg.PutRequest.ParityShards),
+		uploadId:          msg.PutRequest.UploadId,
+		owner:             msg.PutRequest.Owner,
 	}
 	p.logger.Sugar().Info("Request: ", putReq.GetReqType())
 	p.logger.Sugar().Info("Request for filename: ", putReq.GetFileName())
@@ -44,21 +124,34 @@ func (p *ProtoHandler) fetchGetRequest(msg *messages.ClientMessage_GetRequest_)
 	getReq := &Request{
 		reqType:  msg.GetRequest.RestOption.String(),
 		fileName: msg.GetRequest.FileName,
+		offset:   msg.GetRequest.Offset,
+		length:   msg.GetRequest.Length,
 	}
 	p.logger.Sugar().Info("Request: ", getReq.GetReqType())
 	p.logger.Sugar().Info("Request for filename: ", getReq.GetFileName())
 	return getReq
 }
 
-func (p *ProtoHandler) fetchDeleteRequest(msg *messages.ClientMessage_DeleteRequest_) {
-
+func (p *ProtoHandler) fetchDeleteRequest(msg *messages.ClientMessage_DeleteRequest_) *Request {
+	p.logger.Info("Received Delete Request")
+	deleteReq := &Request{
+		reqType:  "DELETE",
+		fileName: msg.DeleteRequest.Filename,
+	}
+	p.logger.Sugar().Info("Request: ", deleteReq.GetReqType())
+	p.logger.Sugar().Info("Request for filename: ", deleteReq.GetFileName())
+	return deleteReq
 }
 
 func (p *ProtoHandler) fetchLsRequest(msg *messages.ClientMessage_LsRequest_) *Request {
 
 	p.logger.Info("Received Ls Request")
 	lsReq := &Request{
-		reqType: "LIST",
+		reqType:   "LIST",
+		fileName:  msg.LsRequest.Path,
+		pattern:   msg.LsRequest.Pattern,
+		pageToken: msg.LsRequest.PageToken,
+		limit:     int(msg.LsRequest.Limit),
 	}
 
 	p.logger.Sugar().Info("Request: ", lsReq.GetReqType())
@@ -78,3 +171,52 @@ func (p *ProtoHandler) fetchNodeStatsRequest(msg *messages.ClientMessage_NodeSta
 	return nodeStatsReq
 
 }
+
+func (p *ProtoHandler) fetchCommitRequest(msg *messages.ClientMessage_CommitRequest_) *Request {
+
+	p.logger.Info("Received Commit Request")
+	commitReq := &Request{
+		reqType:  "COMMIT",
+		fileName: msg.CommitRequest.Filename,
+		checksum: msg.CommitRequest.Checksum,
+	}
+	p.logger.Sugar().Info("Request for filename: ", commitReq.GetFileName())
+	return commitReq
+}
+
+func (p *ProtoHandler) fetchMkdirRequest(msg *messages.ClientMessage_MkdirRequest_) *Request {
+
+	p.logger.Info("Received Mkdir Request")
+	return &Request{
+		reqType:  "MKDIR",
+		fileName: msg.MkdirRequest.Path,
+	}
+}
+
+func (p *ProtoHandler) fetchRmdirRequest(msg *messages.ClientMessage_RmdirRequest_) *Request {
+
+	p.logger.Info("Received Rmdir Request")
+	return &Request{
+		reqType:  "RMDIR",
+		fileName: msg.RmdirRequest.Path,
+	}
+}
+
+func (p *ProtoHandler) fetchRenameRequest(msg *messages.ClientMessage_RenameRequest_) *Request {
+
+	p.logger.Info("Received Rename Request")
+	return &Request{
+		reqType:     "RENAME",
+		fileName:    msg.RenameRequest.Source,
+		destination: msg.RenameRequest.Destination,
+	}
+}
+
+func (p *ProtoHandler) fetchStatRequest(msg *messages.ClientMessage_StatRequest_) *Request {
+
+	p.logger.Info("Received Stat Request")
+	return &Request{
+		reqType:  "STAT",
+		fileName: msg.StatRequest.Filename,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"go.uber.org/zap"
 	"src/controller/file_distributor"
 	"src/controller/storage_handler"
+	"src/erasure"
 	messages "src/messages/controller_client"
 )
 
@@ -36,12 +37,21 @@ func (p *ProtoHandler) HandleControllerResponse(wrapper *messages.ControllerMess
 	case *messages.ControllerMessage_FragLayoutResponse_:
 		res = p.fetchLayoutResponse(msg)
 	case *messages.ControllerMessage_DeleteResponse_:
-		p.fetchDeleteResponse(msg)
+		res = p.fetchDeleteResponse(msg)
 	case *messages.ControllerMessage_LsResponse_:
 		res = p.fetchLsResponse(msg)
 
 	case *messages.ControllerMessage_NodeStats_:
 		res = p.fetchNodeStats(msg)
+
+	case *messages.ControllerMessage_CommitResponse_:
+		res = p.fetchCommitResponse(msg)
+
+	case *messages.ControllerMessage_NamespaceResponse_:
+		res = p.fetchNamespaceResponse(msg)
+
+	case *messages.ControllerMessage_StatResponse_:
+		res = p.fetchStatResponse(msg)
 	}
 
 	return
@@ -58,7 +68,7 @@ func (p *ProtoHandler) HandleClientRequest(wrapper *messages.ClientMessage) (req
 		req = p.fetchGetRequest(msg)
 
 	case *messages.ClientMessage_DeleteRequest_:
-		p.fetchDeleteRequest(msg)
+		req = p.fetchDeleteRequest(msg)
 
 	case *messages.ClientMessage_LsRequest_:
 		req = p.fetchLsRequest(msg)
@@ -66,12 +76,100 @@ func (p *ProtoHandler) HandleClientRequest(wrapper *messages.ClientMessage) (req
 	case *messages.ClientMessage_NodeStatsRequest_:
 		req = p.fetchNodeStatsRequest(msg)
 
+	case *messages.ClientMessage_CommitRequest_:
+		req = p.fetchCommitRequest(msg)
+
+	case *messages.ClientMessage_MkdirRequest_:
+		req = p.fetchMkdirRequest(msg)
+
+	case *messages.ClientMessage_RmdirRequest_:
+		req = p.fetchRmdirRequest(msg)
+
+	case *messages.ClientMessage_RenameRequest_:
+		req = p.fetchRenameRequest(msg)
+
+	case *messages.ClientMessage_StatRequest_:
+		req = p.fetchStatRequest(msg)
+
 	}
 
 	return
 }
 
-func (p *ProtoHandler) HandlePlanResponse(fragMap map[*file_distributor.Fragment][]*storage_handler.Node, req *Request) {
+// HandlePlanError rejects a PUT without a plan.
+func (p *ProtoHandler) HandlePlanError(statusCode string, req *Request) {
+
+	p.logger.Sugar().Infof("Rejecting PUT of %s: %s", req.GetFileName(), statusCode)
+
+	code, ok := messages.ControllerMessage_StatusCode_value[statusCode]
+	if !ok {
+		code = int32(messages.ControllerMessage_ERROR)
+	}
+
+	res := &messages.ControllerMessage_PlanResponse_{
+		PlanResponse: &messages.ControllerMessage_PlanResponse{
+			StatusCode: messages.ControllerMessage_StatusCode(code),
+		},
+	}
+
+	wrapper := &messages.ControllerMessage{
+		ControllerMessage: res,
+	}
+
+	p.msgHandler.ControllerResponseSend(wrapper)
+}
+
+// HandleGetError rejects a GET without a layout.
+func (p *ProtoHandler) HandleGetError(statusCode string, req *Request) {
+
+	p.logger.Sugar().Infof("Rejecting GET of %s: %s", req.GetFileName(), statusCode)
+
+	code, ok := messages.ControllerMessage_StatusCode_value[statusCode]
+	if !ok {
+		code = int32(messages.ControllerMessage_ERROR)
+	}
+
+	res := &messages.ControllerMessage_FragLayoutResponse_{
+		FragLayoutResponse: &messages.ControllerMessage_FragLayoutResponse{
+			StatusCode: messages.ControllerMessage_StatusCode(code),
+		},
+	}
+
+	wrapper := &messages.ControllerMessage{
+		ControllerMessage: res,
+	}
+
+	p.msgHandler.ControllerResponseSend(wrapper)
+}
+
+func toErasureCoding(layout *erasure.Layout) *messages.ControllerMessage_ErasureCoding {
+	if layout == nil {
+		return nil
+	}
+	return &messages.ControllerMessage_ErasureCoding{
+		DataShards:   uint32(layout.DataShards),
+		ParityShards: uint32(layout.ParityShards),
+		ChunkSize:    layout.ChunkSize,
+		FileSize:     layout.FileSize,
+		BlockIds:     layout.Blocks,
+	}
+}
+
+func fromErasureCoding(msg *messages.ControllerMessage_ErasureCoding) *erasure.Layout {
+	if msg == nil {
+		return nil
+	}
+	return &erasure.Layout{
+		DataShards:   int(msg.DataShards),
+		ParityShards: int(msg.ParityShards),
+		ChunkSize:    msg.ChunkSize,
+		FileSize:     msg.FileSize,
+		Blocks:       msg.BlockIds,
+	}
+}
+
+// HandlePlanResponse sends the plan of a PUT. layout is nil for a replicated file.
+func (p *ProtoHandler) HandlePlanResponse(fragMap map[*file_distributor.Fragment][]*storage_handler.Node, layout *erasure.Layout, uploadId string, req *Request) {
 
 	p.logger.Info("Sending plan response to the Controller.")
 
@@ -97,6 +195,8 @@ func (p *ProtoHandler) HandlePlanResponse(fragMap map[*file_distributor.Fragment
 			TotalNumFragments: uint32(len(fragMap)),
 			//repeated fragments
 			FragmentLayout: []*messages.ControllerMessage_PlanResponse_FragmentInfo{},
+			ErasureCoding:  toErasureCoding(layout),
+			UploadId:       uploadId,
 		},
 	}
 
@@ -104,6 +204,7 @@ func (p *ProtoHandler) HandlePlanResponse(fragMap map[*file_distributor.Fragment
 		fragInfo := &messages.ControllerMessage_PlanResponse_FragmentInfo{
 			FragmentId:     frag.GetFragmentName(),
 			Size:           frag.GetFragmentSize(),
+			Index:          uint32(frag.GetFragmentIndex()),
 			StorageNodeIds: []*messages.ControllerMessage_PlanResponse_StorageNodeInfo{},
 		}
 
@@ -127,7 +228,9 @@ func (p *ProtoHandler) HandlePlanResponse(fragMap map[*file_distributor.Fragment
 
 }
 
-func (p *ProtoHandler) HandleGetRequest(file string) {
+// HandleGetRequest asks where the fragments of a file are. A non-zero offset or length reads only bytes
+// [offset, offset+length) of it, a length of 0 reads to the end.
+func (p *ProtoHandler) HandleGetRequest(file string, offset int64, length int64) {
 
 	p.logger.Info("Sending Get request to the Controller.")
 
@@ -135,6 +238,8 @@ func (p *ProtoHandler) HandleGetRequest(file string) {
 		GetRequest: &messages.ClientMessage_GetRequest{
 			RestOption: messages.ClientMessage_GET,
 			FileName:   file,
+			Offset:     offset,
+			Length:     length,
 		},
 	}
 
@@ -171,7 +276,7 @@ func (p *ProtoHandler) HandleNodeInfoResponse(extractedMap map[string]storage_ha
 		for _, node := range extractedMap {
 
 			p.logger.Info("NodeInfo response to send.", zap.String("nodeId", node.GetID()))
-			p.logger.Info("NodeInfo response to send.", zap.String("node free space", string(node.GetFreeSpace())))
+			p.logger.Info("NodeInfo response to send.", zap.Int64("node free space", node.GetFreeSpace()))
 			nodeInfo := &messages.ControllerMessage_NodeStats_NodeInfo{
 				NodeId:    node.GetID(),
 				DiskSpace: node.GetFreeSpace(),
@@ -188,7 +293,10 @@ func (p *ProtoHandler) HandleNodeInfoResponse(extractedMap map[string]storage_ha
 	p.msgHandler.ControllerResponseSend(wrapper)
 
 }
-func (p *ProtoHandler) HandleGetResponse(fileMap map[string][]*storage_handler.Node, req *Request) {
+
+// HandleGetResponse sends where the fragments of a file are. layout is nil for a replicated file. fileRange is
+// the part of the file a range read covers; for a GET of the whole file it only gives the size of each fragment.
+func (p *ProtoHandler) HandleGetResponse(fileMap map[string][]*storage_handler.Node, blocks []string, layout *erasure.Layout, uploadId string, fileRange *storage_handler.FileRange, req *Request) {
 
 	p.logger.Info("Handling Get response to send.")
 	var res *messages.ControllerMessage_FragLayoutResponse_
@@ -209,14 +317,34 @@ func (p *ProtoHandler) HandleGetResponse(fileMap map[string][]*storage_handler.N
 				TotalNumFragments: uint32(len(fileMap)),
 				//repeated fragments
 				FragmentLayout: []*messages.ControllerMessage_FragLayoutResponse_FragmentInfo{},
+				ErasureCoding:  toErasureCoding(layout),
+				UploadId:       uploadId,
 			},
 		}
+		if fileRange != nil && req.Ranged() {
+			res.FragLayoutResponse.RangeOffset = fileRange.Offset
+			res.FragLayoutResponse.RangeLength = fileRange.Length
+		}
+
+		//a fragment is found by its position in the file, its id says nothing about it
+		position := make(map[string]int, len(blocks))
+		for i, block := range blocks {
+			position[block] = i
+		}
 
 		for frag, nodes := range fileMap {
 			fragInfo := &messages.ControllerMessage_FragLayoutResponse_FragmentInfo{
 				FragmentId:     frag,
+				Index:          uint32(position[frag]),
 				StorageNodeIds: []*messages.ControllerMessage_FragLayoutResponse_StorageNodeInfo{},
 			}
+			if fileRange != nil {
+				if r, ok := fileRange.Fragments[frag]; ok && req.Ranged() {
+					fragInfo.Offset, fragInfo.Length = r.Offset, r.Length
+				} else if ok {
+					fragInfo.Size = r.Length
+				}
+			}
 
 			for _, node := range nodes {
 				nodeInfo := &messages.ControllerMessage_FragLayoutResponse_StorageNodeInfo{
@@ -239,33 +367,112 @@ func (p *ProtoHandler) HandleGetResponse(fileMap map[string][]*storage_handler.N
 
 }
 
-func (p *ProtoHandler) HandleListResponse(set map[string]bool, req *Request) {
+func (p *ProtoHandler) HandleDeleteRequest(file string) {
 
-	p.logger.Info("Handling List response to send.")
-	var res *messages.ControllerMessage_LsResponse_
+	p.logger.Info("Sending Delete request to the Controller.")
 
-	if set == nil {
+	req := &messages.ClientMessage_DeleteRequest_{
+		DeleteRequest: &messages.ClientMessage_DeleteRequest{
+			RestOption: messages.ClientMessage_DELETE,
+			Filename:   file,
+		},
+	}
 
-		res = &messages.ControllerMessage_LsResponse_{
-			LsResponse: &messages.ControllerMessage_LsResponse{
+	wrapper := &messages.ClientMessage{
+		ClientMessage: req,
+	}
+
+	p.msgHandler.ClientRequestSend(wrapper)
+
+}
+
+// HandleDeleteResponse reports the outcome of a delete. fileMap is nil when the file was not found,
+// failed holds the replicas that could not be removed.
+func (p *ProtoHandler) HandleDeleteResponse(fileMap map[string][]*storage_handler.Node, failed map[string][]*storage_handler.Node, req *Request) {
+
+	p.logger.Info("Handling Delete response to send.")
+	var res *messages.ControllerMessage_DeleteResponse_
+
+	if fileMap == nil {
+
+		res = &messages.ControllerMessage_DeleteResponse_{
+			DeleteResponse: &messages.ControllerMessage_DeleteResponse{
 				StatusCode: messages.ControllerMessage_FILE_NOT_FOUND,
 			},
 		}
 
+	} else if len(failed) != 0 {
+
+		res = &messages.ControllerMessage_DeleteResponse_{
+			DeleteResponse: &messages.ControllerMessage_DeleteResponse{
+				StatusCode:     messages.ControllerMessage_ERROR,
+				FailedReplicas: []*messages.ControllerMessage_DeleteResponse_FailedReplica{},
+			},
+		}
+
+		for frag, nodes := range failed {
+			for _, node := range nodes {
+				replica := &messages.ControllerMessage_DeleteResponse_FailedReplica{
+					FragmentId:    frag,
+					StorageNodeId: node.GetID(),
+					Host:          node.GetAddress(),
+					Port:          node.GetOpenPort(),
+				}
+				res.DeleteResponse.FailedReplicas = append(res.DeleteResponse.FailedReplicas, replica)
+			}
+		}
+
 	} else {
 
-		res = &messages.ControllerMessage_LsResponse_{
-			LsResponse: &messages.ControllerMessage_LsResponse{
+		res = &messages.ControllerMessage_DeleteResponse_{
+			DeleteResponse: &messages.ControllerMessage_DeleteResponse{
 				StatusCode: messages.ControllerMessage_OK,
-				//repeated fragments
-				FileNames: []string{},
 			},
 		}
+	}
 
-		for file := range set {
-			res.LsResponse.FileNames = append(res.LsResponse.FileNames, file)
-		}
+	wrapper := &messages.ControllerMessage{
+		ControllerMessage: res,
+	}
 
+	p.msgHandler.ControllerResponseSend(wrapper)
+
+}
+
+// HandleListResponse sends a page of what is in the listed directory and the token of the next one. entries is
+// only sent with the status code OK.
+func (p *ProtoHandler) HandleListResponse(entries []storage_handler.ListEntry, nextPageToken string, statusCode string, req *Request) {
+
+	p.logger.Info("Handling List response to send.")
+
+	code, ok := messages.ControllerMessage_StatusCode_value[statusCode]
+	if !ok {
+		code = int32(messages.ControllerMessage_ERROR)
+	}
+	res := &messages.ControllerMessage_LsResponse_{
+		LsResponse: &messages.ControllerMessage_LsResponse{
+			StatusCode: messages.ControllerMessage_StatusCode(code),
+		},
+	}
+
+	if res.LsResponse.StatusCode == messages.ControllerMessage_OK {
+		res.LsResponse.FileNames = []string{}
+		res.LsResponse.DirNames = []string{}
+		res.LsResponse.NextPageToken = nextPageToken
+		for _, entry := range entries {
+			if entry.Dir {
+				res.LsResponse.DirNames = append(res.LsResponse.DirNames, entry.Name)
+			} else {
+				res.LsResponse.FileNames = append(res.LsResponse.FileNames, entry.Name)
+			}
+			res.LsResponse.Entries = append(res.LsResponse.Entries, &messages.ControllerMessage_LsResponse_Entry{
+				Name:            entry.Name,
+				Dir:             entry.Dir,
+				Size:            entry.Size,
+				NumFragments:    uint32(entry.Fragments),
+				UnderReplicated: entry.UnderReplicated,
+			})
+		}
 	}
 	wrapper := &messages.ControllerMessage{
 		ControllerMessage: res,
@@ -275,13 +482,19 @@ func (p *ProtoHandler) HandleListResponse(set map[string]bool, req *Request) {
 
 }
 
-func (p *ProtoHandler) HandleLsRequest() {
+// HandleLsRequest asks for a page of what is in the directory path, the root when it is empty. Only the
+// entries whose name matches pattern are listed, at most limit of them after the one named pageToken.
+func (p *ProtoHandler) HandleLsRequest(path string, pattern string, pageToken string, limit int) {
 
 	p.logger.Info("Sending Ls request to the Controller.")
 
 	req := &messages.ClientMessage_LsRequest_{
 		LsRequest: &messages.ClientMessage_LsRequest{
 			RestOption: messages.ClientMessage_LS,
+			Path:       path,
+			Pattern:    pattern,
+			PageToken:  pageToken,
+			Limit:      uint32(limit),
 		},
 	}
 
@@ -310,3 +523,176 @@ func (p *ProtoHandler) HandleNodeStatsRequest() {
 	p.msgHandler.ClientRequestSend(wrapper)
 
 }
+
+// HandleCommitRequest tells the Controller every fragment of a PUT was stored. checksum is the SHA-256 of the
+// whole file in hex.
+func (p *ProtoHandler) HandleCommitRequest(file string, checksum string) {
+
+	p.logger.Info("Sending Commit request to the Controller.")
+
+	req := &messages.ClientMessage_CommitRequest_{
+		CommitRequest: &messages.ClientMessage_CommitRequest{
+			RestOption: messages.ClientMessage_COMMIT,
+			Filename:   file,
+			Checksum:   checksum,
+		},
+	}
+
+	wrapper := &messages.ClientMessage{
+		ClientMessage: req,
+	}
+
+	p.msgHandler.ClientRequestSend(wrapper)
+
+}
+
+func (p *ProtoHandler) HandleCommitResponse(statusCode string, req *Request) {
+
+	p.logger.Sugar().Infof("Commit of %s: %s", req.GetFileName(), statusCode)
+
+	code, ok := messages.ControllerMessage_StatusCode_value[statusCode]
+	if !ok {
+		code = int32(messages.ControllerMessage_ERROR)
+	}
+
+	res := &messages.ControllerMessage_CommitResponse_{
+		CommitResponse: &messages.ControllerMessage_CommitResponse{
+			StatusCode: messages.ControllerMessage_StatusCode(code),
+		},
+	}
+
+	wrapper := &messages.ControllerMessage{
+		ControllerMessage: res,
+	}
+
+	p.msgHandler.ControllerResponseSend(wrapper)
+
+}
+
+func (p *ProtoHandler) HandleMkdirRequest(path string) {
+
+	p.logger.Info("Sending Mkdir request to the Controller.")
+
+	req := &messages.ClientMessage_MkdirRequest_{
+		MkdirRequest: &messages.ClientMessage_MkdirRequest{
+			RestOption: messages.ClientMessage_MKDIR,
+			Path:       path,
+		},
+	}
+
+	p.msgHandler.ClientRequestSend(&messages.ClientMessage{ClientMessage: req})
+}
+
+func (p *ProtoHandler) HandleRmdirRequest(path string) {
+
+	p.logger.Info("Sending Rmdir request to the Controller.")
+
+	req := &messages.ClientMessage_RmdirRequest_{
+		RmdirRequest: &messages.ClientMessage_RmdirRequest{
+			RestOption: messages.ClientMessage_RMDIR,
+			Path:       path,
+		},
+	}
+
+	p.msgHandler.ClientRequestSend(&messages.ClientMessage{ClientMessage: req})
+}
+
+// HandleRenameRequest asks the Controller to move a file or directory from source to destination.
+func (p *ProtoHandler) HandleRenameRequest(source string, destination string) {
+
+	p.logger.Info("Sending Rename request to the Controller.")
+
+	req := &messages.ClientMessage_RenameRequest_{
+		RenameRequest: &messages.ClientMessage_RenameRequest{
+			RestOption:  messages.ClientMessage_RENAME,
+			Source:      source,
+			Destination: destination,
+		},
+	}
+
+	p.msgHandler.ClientRequestSend(&messages.ClientMessage{ClientMessage: req})
+}
+
+// HandleNamespaceResponse answers a MKDIR, RMDIR or RENAME.
+func (p *ProtoHandler) HandleNamespaceResponse(statusCode string, req *Request) {
+
+	p.logger.Sugar().Infof("%s of %s: %s", req.GetReqType(), req.GetFileName(), statusCode)
+
+	code, ok := messages.ControllerMessage_StatusCode_value[statusCode]
+	if !ok {
+		code = int32(messages.ControllerMessage_ERROR)
+	}
+
+	res := &messages.ControllerMessage_NamespaceResponse_{
+		NamespaceResponse: &messages.ControllerMessage_NamespaceResponse{
+			StatusCode: messages.ControllerMessage_StatusCode(code),
+		},
+	}
+
+	p.msgHandler.ControllerResponseSend(&messages.ControllerMessage{ControllerMessage: res})
+}
+
+// HandleStatRequest asks the Controller to describe a file.
+func (p *ProtoHandler) HandleStatRequest(file string) {
+
+	p.logger.Info("Sending Stat request to the Controller.")
+
+	req := &messages.ClientMessage_StatRequest_{
+		StatRequest: &messages.ClientMessage_StatRequest{
+			RestOption: messages.ClientMessage_STAT,
+			Filename:   file,
+		},
+	}
+
+	p.msgHandler.ClientRequestSend(&messages.ClientMessage{ClientMessage: req})
+}
+
+// HandleStatResponse describes a file. stat is only sent with the status code OK.
+func (p *ProtoHandler) HandleStatResponse(stat *storage_handler.FileStat, statusCode string, req *Request) {
+
+	p.logger.Sugar().Infof("Stat of %s: %s", req.GetFileName(), statusCode)
+
+	code, ok := messages.ControllerMessage_StatusCode_value[statusCode]
+	if !ok {
+		code = int32(messages.ControllerMessage_ERROR)
+	}
+	res := &messages.ControllerMessage_StatResponse_{
+		StatResponse: &messages.ControllerMessage_StatResponse{
+			StatusCode: messages.ControllerMessage_StatusCode(code),
+		},
+	}
+
+	if stat != nil && res.StatResponse.StatusCode == messages.ControllerMessage_OK {
+		res.StatResponse.FileName = stat.Name
+		res.StatResponse.Size = stat.Size
+		res.StatResponse.ChunkSize = stat.ChunkSize
+		res.StatResponse.NumFragments = uint32(len(stat.Fragments))
+		res.StatResponse.ReplicationFactor = uint32(stat.ReplicationFactor)
+		res.StatResponse.ErasureCoding = toErasureCoding(stat.Layout)
+		res.StatResponse.Owner = stat.Owner
+		res.StatResponse.Checksum = stat.Checksum
+		if !stat.Created.IsZero() {
+			res.StatResponse.Created = stat.Created.UnixMilli()
+		}
+
+		for _, frag := range stat.Fragments {
+			fragInfo := &messages.ControllerMessage_StatResponse_FragmentInfo{
+				FragmentId: frag.Id,
+				Index:      uint32(frag.Index),
+				Size:       frag.Size,
+				Health:     messages.ControllerMessage_StatResponse_FragmentHealth(messages.ControllerMessage_StatResponse_FragmentHealth_value[frag.Health]),
+				Replicas:   []*messages.ControllerMessage_StatResponse_StorageNodeInfo{},
+			}
+			for _, node := range frag.Replicas {
+				fragInfo.Replicas = append(fragInfo.Replicas, &messages.ControllerMessage_StatResponse_StorageNodeInfo{
+					StorageNodeId: node.GetID(),
+					Host:          node.GetAddress(),
+					Port:          node.GetOpenPort(),
+				})
+			}
+			res.StatResponse.Fragments = append(res.StatResponse.Fragments, fragInfo)
+		}
+	}
+
+	p.msgHandler.ControllerResponseSend(&messages.ControllerMessage{ControllerMessage: res})
+}
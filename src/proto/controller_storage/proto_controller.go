@@ -1,6 +1,7 @@
 package controller_storage
 
 import (
+	"src/erasure"
 	messages "src/messages/controller_storage"
 )
 
@@ -9,15 +10,25 @@ type Request struct {
 	nodeId               string
 	openPort             string
 	host                 string
+	rack                 string
 	corruptedFile        string
 	nodeStatus           messages.StorageNodeMessage_NodeStatus
 	freeSpace            int64
 	numRequestsProcessed int32
-	newFiles             []string
-	allFiles             []string
+	report               BlockReport
 	err                  error
 }
 
+// BlockReport is the list of files a node sends with a heartbeat. A full report lists every file in Files,
+// the others only what was Added and Removed since the report numbered Seq-1.
+type BlockReport struct {
+	Seq     uint64
+	Full    bool
+	Files   []string
+	Added   []string
+	Removed []string
+}
+
 func (r *Request) RequestType() string {
 	return r.requestType
 }
@@ -46,22 +57,35 @@ func (r *Request) GetNodeNumRequestsProcessed() int32 {
 	return r.numRequestsProcessed
 }
 
-func (r *Request) GetNewFiles() []string {
-	return r.newFiles
-}
-
-func (r *Request) GetAllFiles() []string {
-	return r.allFiles
+func (r *Request) GetBlockReport() BlockReport {
+	return r.report
 }
 
 func (r *Request) GetNodeHost() string {
 	return r.host
 }
 
+func (r *Request) GetNodeRack() string {
+	return r.rack
+}
+
 type RequestHandler interface {
 	RequestType() string
 }
 
+// Ack is a storage node's answer to a command pushed over its session. Failed lists the fragments or shards
+// the command could not be carried out for.
+type Ack struct {
+	requestType string
+	CommandId   uint64
+	Success     bool
+	Failed      []string
+}
+
+func (a *Ack) RequestType() string {
+	return a.requestType
+}
+
 func (p *ProtoHandler) fetchIntroRequest(msg *messages.StorageNodeMessage_Intro_, interval int) RequestHandler {
 
 	//fmt.Println("The newly added node is: ", msg.Intro.NodeId)
@@ -73,6 +97,7 @@ func (p *ProtoHandler) fetchIntroRequest(msg *messages.StorageNodeMessage_Intro_
 		nodeStatus:  msg.Intro.NodeStatus,
 		openPort:    msg.Intro.OpenPort,
 		host:        msg.Intro.Host,
+		rack:        msg.Intro.Rack,
 	}
 
 	p.handleIntroResponse(interval)
@@ -81,14 +106,6 @@ func (p *ProtoHandler) fetchIntroRequest(msg *messages.StorageNodeMessage_Intro_
 }
 
 func (p *ProtoHandler) fetchHeartbeatRequest(msg *messages.StorageNodeMessage_Heartbeat_) RequestHandler {
-	/*
-		string node_id = 1;
-		    NodeStatus node_status = 2;
-		    float free_space = 3;
-		    int32 num_requests_processed = 4;
-		    repeated string new_files = 5;
-		    repeated string all_files = 6;
-	*/
 
 	HeartbeatRequest := &Request{
 		requestType:          "heartbeat",
@@ -96,14 +113,13 @@ func (p *ProtoHandler) fetchHeartbeatRequest(msg *messages.StorageNodeMessage_He
 		nodeStatus:           msg.Heartbeat.NodeStatus,
 		freeSpace:            msg.Heartbeat.FreeSpace,
 		numRequestsProcessed: msg.Heartbeat.NumRequestsProcessed,
-	}
-
-	for _, files := range msg.Heartbeat.NewFiles {
-		HeartbeatRequest.newFiles = append(HeartbeatRequest.newFiles, files)
-	}
-
-	for _, files := range msg.Heartbeat.AllFiles {
-		HeartbeatRequest.allFiles = append(HeartbeatRequest.allFiles, files)
+		report: BlockReport{
+			Seq:     msg.Heartbeat.ReportSeq,
+			Full:    msg.Heartbeat.FullReport,
+			Files:   msg.Heartbeat.AllFiles,
+			Added:   msg.Heartbeat.AddedFiles,
+			Removed: msg.Heartbeat.RemovedFiles,
+		},
 	}
 
 	//TODO: verify if the storage_node is valid, and take actions accordingly
@@ -111,6 +127,16 @@ func (p *ProtoHandler) fetchHeartbeatRequest(msg *messages.StorageNodeMessage_He
 	return HeartbeatRequest
 }
 
+func (p *ProtoHandler) fetchCommandAck(msg *messages.StorageNodeMessage_CommandAck_) RequestHandler {
+
+	return &Ack{
+		requestType: "ack",
+		CommandId:   msg.CommandAck.CommandId,
+		Success:     msg.CommandAck.Success,
+		Failed:      msg.CommandAck.Failed,
+	}
+}
+
 func (p *ProtoHandler) fetchFileCorruptionRequest(msg *messages.StorageNodeMessage_FileCorruption_) RequestHandler {
 
 	FileCorruptionRequest := &Request{
@@ -154,6 +180,16 @@ func (p *ProtoHandler) HandleHeartbeatMiss(nodeId string) {
 	p.msgHandler.ServerResponseSend(res)
 }
 
+// HandleBlockReportRequest asks the node to make its next block report a full one.
+func (p *ProtoHandler) HandleBlockReportRequest() (err error) {
+
+	res := &messages.ControllerMessage{ControllerMessage: &messages.ControllerMessage_BlockReportRequest_{
+		BlockReportRequest: &messages.ControllerMessage_BlockReportRequest{},
+	}}
+
+	return p.msgHandler.ServerResponseSend(res)
+}
+
 type Node struct {
 	ID   string
 	Host string
@@ -165,6 +201,18 @@ type FragmentDistribution struct {
 	Nodes    []*Node
 }
 
+type ShardSource struct {
+	Shard string
+	Node  *Node
+}
+
+// ShardReconstruction asks a node to rebuild Shard from the surviving shards of its group.
+type ShardReconstruction struct {
+	Shard   string
+	Layout  erasure.Layout
+	Sources []*ShardSource
+}
+
 func (p *ProtoHandler) HandleFileCorruptionResponse(nodes []*Node, req *Request) {
 
 	res := &messages.ControllerMessage{
@@ -190,11 +238,12 @@ func (p *ProtoHandler) HandleFileCorruptionResponse(nodes []*Node, req *Request)
 
 }
 
-func (p *ProtoHandler) HandleReplicationRequest(proto []*FragmentDistribution, id string) {
+func (p *ProtoHandler) HandleReplicationRequest(proto []*FragmentDistribution, commandId uint64) (err error) {
 
 	var res *messages.ControllerMessage
 
 	res = &messages.ControllerMessage{
+		CommandId: commandId,
 		ControllerMessage: &messages.ControllerMessage_ReplicationRequest_{
 			ReplicationRequest: &messages.ControllerMessage_ReplicationRequest{
 				StatusCode:      messages.ControllerMessage_OK,
@@ -220,5 +269,63 @@ func (p *ProtoHandler) HandleReplicationRequest(proto []*FragmentDistribution, i
 
 	}
 
-	p.msgHandler.ServerResponseSend(res)
+	return p.msgHandler.ServerResponseSend(res)
+}
+
+func (p *ProtoHandler) HandleReconstructionRequest(shards []*ShardReconstruction, commandId uint64) (err error) {
+
+	req := &messages.ControllerMessage_ReconstructionRequest{
+		StatusCode: messages.ControllerMessage_OK,
+		Shards:     make([]*messages.ControllerMessage_ShardReconstruction, len(shards)),
+	}
+
+	for i, shard := range shards {
+		req.Shards[i] = &messages.ControllerMessage_ShardReconstruction{
+			Shard:        shard.Shard,
+			DataShards:   uint32(shard.Layout.DataShards),
+			ParityShards: uint32(shard.Layout.ParityShards),
+			ChunkSize:    shard.Layout.ChunkSize,
+			FileSize:     shard.Layout.FileSize,
+			BlockIds:     shard.Layout.Blocks,
+			Sources:      make([]*messages.ControllerMessage_ShardSource, len(shard.Sources)),
+		}
+
+		for j, source := range shard.Sources {
+			req.Shards[i].Sources[j] = &messages.ControllerMessage_ShardSource{
+				Shard: source.Shard,
+				StorageNode: &messages.ControllerMessage_StorageNodeInfo{
+					StorageNodeId: source.Node.ID,
+					Host:          source.Node.Host,
+					Port:          source.Node.Port,
+				},
+			}
+		}
+	}
+
+	return p.msgHandler.ServerResponseSend(&messages.ControllerMessage{
+		CommandId: commandId,
+		ControllerMessage: &messages.ControllerMessage_ReconstructionRequest_{
+			ReconstructionRequest: req,
+		},
+	})
+}
+
+func (p *ProtoHandler) HandleDeleteRequest(fragments []string, commandId uint64) (err error) {
+
+	return p.msgHandler.ServerResponseSend(&messages.ControllerMessage{
+		CommandId: commandId,
+		ControllerMessage: &messages.ControllerMessage_DeleteRequest_{
+			DeleteRequest: &messages.ControllerMessage_DeleteRequest{Fragments: fragments},
+		},
+	})
+}
+
+func (p *ProtoHandler) HandleVerifyRequest(fragments []string, commandId uint64) (err error) {
+
+	return p.msgHandler.ServerResponseSend(&messages.ControllerMessage{
+		CommandId: commandId,
+		ControllerMessage: &messages.ControllerMessage_VerifyRequest_{
+			VerifyRequest: &messages.ControllerMessage_VerifyRequest{Fragments: fragments},
+		},
+	})
 }
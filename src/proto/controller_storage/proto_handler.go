@@ -39,7 +39,35 @@ func (p *ProtoHandler) HandleControllerResponse(wrapper *messages.ControllerMess
 
 	case *messages.ControllerMessage_ReplicationRequest_:
 
-		res = p.fetchReplicationRequest(msg)
+		res = p.fetchReplicationRequest(msg, wrapper.CommandId)
+		return
+
+	case *messages.ControllerMessage_ReconstructionRequest_:
+
+		res = p.fetchReconstructionRequest(msg, wrapper.CommandId)
+		return
+
+	case *messages.ControllerMessage_DeleteRequest_:
+
+		res = &DeleteRequest{
+			responseType: "DeleteRequest",
+			CommandId:    wrapper.CommandId,
+			Fragments:    msg.DeleteRequest.Fragments,
+		}
+		return
+
+	case *messages.ControllerMessage_BlockReportRequest_:
+
+		res = &BlockReportRequest{responseType: "BlockReportRequest"}
+		return
+
+	case *messages.ControllerMessage_VerifyRequest_:
+
+		res = &VerifyRequest{
+			responseType: "VerifyRequest",
+			CommandId:    wrapper.CommandId,
+			Fragments:    msg.VerifyRequest.Fragments,
+		}
 		return
 
 	}
@@ -60,6 +88,9 @@ func (p *ProtoHandler) HandleStorageNodeRequest(wrapper *messages.StorageNodeMes
 	case *messages.StorageNodeMessage_FileCorruption_:
 		Req = p.fetchFileCorruptionRequest(msg)
 
+	case *messages.StorageNodeMessage_CommandAck_:
+		Req = p.fetchCommandAck(msg)
+
 	}
 
 	return
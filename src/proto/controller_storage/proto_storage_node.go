@@ -2,10 +2,11 @@ package controller_storage
 
 import (
 	"fmt"
+	"src/erasure"
 	messages "src/messages/controller_storage"
 )
 
-func (p *ProtoHandler) HandleIntroRequest(nodeID, openPort, address string) (err error) {
+func (p *ProtoHandler) HandleIntroRequest(nodeID, openPort, address, rack string) (err error) {
 
 	msg := &messages.StorageNodeMessage{
 		StorageNodeMessage: &messages.StorageNodeMessage_Intro_{
@@ -14,6 +15,7 @@ func (p *ProtoHandler) HandleIntroRequest(nodeID, openPort, address string) (err
 				NodeStatus: messages.StorageNodeMessage_NEW,
 				OpenPort:   openPort,
 				Host:       address,
+				Rack:       rack,
 			},
 		},
 	}
@@ -22,6 +24,22 @@ func (p *ProtoHandler) HandleIntroRequest(nodeID, openPort, address string) (err
 	return
 }
 
+// SendCommandAck acknowledges a command, listing the fragments or shards it failed on.
+func (p *ProtoHandler) SendCommandAck(commandId uint64, failed []string) (err error) {
+
+	msg := &messages.StorageNodeMessage{
+		StorageNodeMessage: &messages.StorageNodeMessage_CommandAck_{
+			CommandAck: &messages.StorageNodeMessage_CommandAck{
+				CommandId: commandId,
+				Success:   len(failed) == 0,
+				Failed:    failed,
+			},
+		},
+	}
+
+	return p.msgHandler.ClientRequestSend(msg)
+}
+
 func (p *ProtoHandler) HandleCorruptedFile(id string, f string) {
 
 	msg := &messages.StorageNodeMessage{
@@ -70,6 +88,7 @@ type FileCorruption struct {
 
 type ReplicationRequest struct {
 	responseType    string
+	CommandId       uint64
 	StatusCode      messages.ControllerMessage_StatusCode
 	ReplicationInfo []*ReplicationInfo
 	//FileName     string
@@ -81,6 +100,46 @@ type ReplicationInfo struct {
 	StorageNodes []*StorageNodes
 }
 
+type ReconstructionRequest struct {
+	responseType string
+	CommandId    uint64
+	StatusCode   messages.ControllerMessage_StatusCode
+	Shards       []*ShardReconstruction
+}
+
+func (r ReconstructionRequest) ResponseType() string {
+	return r.responseType
+}
+
+type DeleteRequest struct {
+	responseType string
+	CommandId    uint64
+	Fragments    []string
+}
+
+func (d DeleteRequest) ResponseType() string {
+	return d.responseType
+}
+
+type BlockReportRequest struct {
+	responseType string
+}
+
+func (b BlockReportRequest) ResponseType() string {
+	return b.responseType
+}
+
+// VerifyRequest asks the node to check Fragments against their checksums.
+type VerifyRequest struct {
+	responseType string
+	CommandId    uint64
+	Fragments    []string
+}
+
+func (v VerifyRequest) ResponseType() string {
+	return v.responseType
+}
+
 func (r ReplicationRequest) ResponseType() string {
 	return r.responseType
 }
@@ -178,10 +237,11 @@ func (p *ProtoHandler) fetchFileCorruptionResponse(msg *messages.ControllerMessa
 
 }
 
-func (p *ProtoHandler) fetchReplicationRequest(msg *messages.ControllerMessage_ReplicationRequest_) (res Response) {
+func (p *ProtoHandler) fetchReplicationRequest(msg *messages.ControllerMessage_ReplicationRequest_, commandId uint64) (res Response) {
 
 	res = &ReplicationRequest{
 		responseType:    "ReplicationRequest",
+		CommandId:       commandId,
 		StatusCode:      msg.ReplicationRequest.StatusCode,
 		ReplicationInfo: make([]*ReplicationInfo, len(msg.ReplicationRequest.ReplicationInfo)),
 	}
@@ -206,7 +266,44 @@ func (p *ProtoHandler) fetchReplicationRequest(msg *messages.ControllerMessage_R
 
 }
 
-func (p *ProtoHandler) SendHeartbeatRequest(nodeID string, freeSpace int64, numRequestsProcessed int32, newFiles []string, allFiles []string) {
+func (p *ProtoHandler) fetchReconstructionRequest(msg *messages.ControllerMessage_ReconstructionRequest_, commandId uint64) (res Response) {
+
+	req := &ReconstructionRequest{
+		responseType: "ReconstructionRequest",
+		CommandId:    commandId,
+		StatusCode:   msg.ReconstructionRequest.StatusCode,
+		Shards:       make([]*ShardReconstruction, len(msg.ReconstructionRequest.Shards)),
+	}
+
+	for i, shard := range msg.ReconstructionRequest.Shards {
+		req.Shards[i] = &ShardReconstruction{
+			Shard: shard.Shard,
+			Layout: erasure.Layout{
+				DataShards:   int(shard.DataShards),
+				ParityShards: int(shard.ParityShards),
+				ChunkSize:    shard.ChunkSize,
+				FileSize:     shard.FileSize,
+				Blocks:       shard.BlockIds,
+			},
+			Sources: make([]*ShardSource, len(shard.Sources)),
+		}
+
+		for j, source := range shard.Sources {
+			req.Shards[i].Sources[j] = &ShardSource{
+				Shard: source.Shard,
+				Node: &Node{
+					ID:   source.StorageNode.GetStorageNodeId(),
+					Host: source.StorageNode.GetHost(),
+					Port: source.StorageNode.GetPort(),
+				},
+			}
+		}
+	}
+
+	return req
+}
+
+func (p *ProtoHandler) SendHeartbeatRequest(nodeID string, freeSpace int64, numRequestsProcessed int32, report BlockReport) {
 	msg := &messages.StorageNodeMessage{
 		StorageNodeMessage: &messages.StorageNodeMessage_Heartbeat_{
 			Heartbeat: &messages.StorageNodeMessage_Heartbeat{
@@ -214,8 +311,11 @@ func (p *ProtoHandler) SendHeartbeatRequest(nodeID string, freeSpace int64, numR
 				NodeStatus:           messages.StorageNodeMessage_ACTIVE,
 				FreeSpace:            freeSpace,
 				NumRequestsProcessed: numRequestsProcessed,
-				NewFiles:             newFiles,
-				AllFiles:             allFiles,
+				AllFiles:             report.Files,
+				ReportSeq:            report.Seq,
+				FullReport:           report.Full,
+				AddedFiles:           report.Added,
+				RemovedFiles:         report.Removed,
 			},
 		},
 	}
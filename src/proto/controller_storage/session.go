@@ -0,0 +1,127 @@
+package controller_storage
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrSessionClosed = errors.New("storage node session closed")
+var ErrCommandTimeout = errors.New("storage node did not acknowledge the command in time")
+
+// Session is the Controller's end of the long-lived connection to a storage node. The node's heartbeats
+// and acks arrive on it, and commands are pushed on it and matched to their acks by command id.
+type Session struct {
+	proto   *ProtoHandler
+	timeout time.Duration
+
+	mutex   sync.Mutex
+	nextId  uint64
+	pending map[uint64]chan *Ack
+	closed  bool
+}
+
+// NewSession wraps the connection of a storage node. Commands fail with ErrCommandTimeout if the node
+// does not acknowledge them within timeout.
+func NewSession(proto *ProtoHandler, timeout time.Duration) *Session {
+	return &Session{
+		proto:   proto,
+		timeout: timeout,
+		pending: make(map[uint64]chan *Ack),
+	}
+}
+
+func (s *Session) Replicate(fragments []*FragmentDistribution) (*Ack, error) {
+	return s.command(func(id uint64) error {
+		return s.proto.HandleReplicationRequest(fragments, id)
+	})
+}
+
+func (s *Session) Reconstruct(shards []*ShardReconstruction) (*Ack, error) {
+	return s.command(func(id uint64) error {
+		return s.proto.HandleReconstructionRequest(shards, id)
+	})
+}
+
+func (s *Session) Delete(fragments []string) (*Ack, error) {
+	return s.command(func(id uint64) error {
+		return s.proto.HandleDeleteRequest(fragments, id)
+	})
+}
+
+// Verify asks the node to check fragments against their checksums. The ack lists the ones that are
+// corrupt or missing.
+func (s *Session) Verify(fragments []string) (*Ack, error) {
+	return s.command(func(id uint64) error {
+		return s.proto.HandleVerifyRequest(fragments, id)
+	})
+}
+
+// command sends a command under a new id and waits for the node to acknowledge it.
+func (s *Session) command(send func(id uint64) error) (ack *Ack, err error) {
+
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil, ErrSessionClosed
+	}
+	s.nextId++
+	id := s.nextId
+	acks := make(chan *Ack, 1)
+	s.pending[id] = acks
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.pending, id)
+		s.mutex.Unlock()
+	}()
+
+	err = send(id)
+	if err != nil {
+		return
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case ack, ok := <-acks:
+		if !ok {
+			return nil, ErrSessionClosed
+		}
+		return ack, nil
+	case <-timer.C:
+		return nil, ErrCommandTimeout
+	}
+}
+
+// Acknowledge hands an ack read off the connection to the command waiting for it. Acks nobody waits for
+// any more are dropped.
+func (s *Session) Acknowledge(ack *Ack) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	acks, ok := s.pending[ack.CommandId]
+	if !ok {
+		return
+	}
+	select {
+	case acks <- ack:
+	default:
+	}
+}
+
+// Close fails every command still waiting for an ack, and every command sent after it.
+func (s *Session) Close() {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	for id, acks := range s.pending {
+		close(acks)
+		delete(s.pending, id)
+	}
+}
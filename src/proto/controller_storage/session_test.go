@@ -0,0 +1,103 @@
+package controller_storage
+
+import (
+	"net"
+	"reflect"
+	messages "src/messages/controller_storage"
+	"testing"
+	"time"
+)
+
+func TestSession_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		fragments []string
+		failed    []string
+		ack       bool
+		wantErr   error
+	}{
+		{
+			name:      "Test all fragments deleted",
+			fragments: []string{"file_0", "file_1"},
+			ack:       true,
+		},
+		{
+			name:      "Test some fragments not deleted",
+			fragments: []string{"file_0", "file_1"},
+			failed:    []string{"file_1"},
+			ack:       true,
+		},
+		{
+			name:      "Test node never acknowledges",
+			fragments: []string{"file_0"},
+			wantErr:   ErrCommandTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controllerConn, nodeConn := net.Pipe()
+			defer controllerConn.Close()
+			defer nodeConn.Close()
+
+			session := NewSession(NewProtoHandler(messages.NewMessageHandler(controllerConn)), 100*time.Millisecond)
+			node := NewProtoHandler(messages.NewMessageHandler(nodeConn))
+
+			//the node side reads the command, and the Controller side feeds acks back to the session
+			go func() {
+				wrapper, err := node.MsgHandler().ServerResponseReceive()
+				if err != nil {
+					return
+				}
+				req := node.HandleControllerResponse(wrapper).(*DeleteRequest)
+				if !reflect.DeepEqual(req.Fragments, tt.fragments) {
+					t.Errorf("node got fragments %v, want %v", req.Fragments, tt.fragments)
+				}
+				if tt.ack {
+					node.SendCommandAck(req.CommandId, tt.failed)
+				}
+			}()
+			go func() {
+				for {
+					wrapper, err := session.proto.MsgHandler().ClientRequestReceive()
+					if err != nil {
+						return
+					}
+					session.Acknowledge(session.proto.HandleStorageNodeRequest(wrapper, 0).(*Ack))
+				}
+			}()
+
+			ack, err := session.Delete(tt.fragments)
+			if err != tt.wantErr {
+				t.Fatalf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if ack.Success != (len(tt.failed) == 0) || !reflect.DeepEqual(ack.Failed, tt.failed) {
+				t.Errorf("Delete() ack = %+v, want failed %v", ack, tt.failed)
+			}
+		})
+	}
+}
+
+func TestSession_Close(t *testing.T) {
+	controllerConn, nodeConn := net.Pipe()
+	defer controllerConn.Close()
+	defer nodeConn.Close()
+
+	session := NewSession(NewProtoHandler(messages.NewMessageHandler(controllerConn)), time.Minute)
+	node := messages.NewMessageHandler(nodeConn)
+
+	//close the session once the command is on the wire
+	go func() {
+		node.ServerResponseReceive()
+		session.Close()
+	}()
+
+	if _, err := session.Verify([]string{"file_0"}); err != ErrSessionClosed {
+		t.Errorf("Verify() error = %v, want %v", err, ErrSessionClosed)
+	}
+	if _, err := session.Verify([]string{"file_0"}); err != ErrSessionClosed {
+		t.Errorf("Verify() after Close error = %v, want %v", err, ErrSessionClosed)
+	}
+}
@@ -1,6 +1,8 @@
 package storage_storage
 
 import (
+	"errors"
+	"fmt"
 	"go.uber.org/zap"
 	"src/file"
 	messages "src/messages/storage_storage"
@@ -12,6 +14,9 @@ type ProtoHandler struct {
 	logger     *zap.Logger
 	file       *file.FileHandler
 	nodeId     string
+	partial    *file.PartialFile
+	pipeline   file.Pipeline
+	ack        bool
 }
 
 func (p *ProtoHandler) Logger() *zap.Logger {
@@ -35,6 +40,24 @@ func NewProtoHandler(msgHandler *messages.MessageHandler, logger *zap.Logger, di
 	return newProtoHandler
 }
 
+// SetPipeline sets where a streamed PUTCopy is forwarded to as it is written.
+func (p *ProtoHandler) SetPipeline(pipeline file.Pipeline) {
+	p.pipeline = pipeline
+}
+
+// TransferPending reports whether a streamed transfer has started but not yet been committed.
+func (p *ProtoHandler) TransferPending() bool {
+	return p.partial != nil
+}
+
+// AbortTransfer removes the partial file of a streamed transfer that did not complete.
+func (p *ProtoHandler) AbortTransfer() {
+	if p.partial != nil {
+		p.partial.Abort()
+		p.partial = nil
+	}
+}
+
 type Response interface {
 	GetType() string
 }
@@ -45,7 +68,14 @@ func (p *ProtoHandler) HandleStorageNodeResponse(wrapper *messages.StorageNodeMe
 
 	case *messages.StorageNodeMessage_GetReplicaResponse:
 		p.logger.Info("Got GetReplicaResponse")
+		if msg.GetReplicaResponse.Streamed {
+			err = p.startTransfer(msg.GetReplicaResponse.FileName)
+			return
+		}
 		p.fetchGetReplicaResponse(msg)
+
+	case *messages.StorageNodeMessage_Chunk:
+		err = p.fetchChunk(msg)
 	}
 
 	return
@@ -57,17 +87,139 @@ func (p *ProtoHandler) HandleStorageNodeRequest(wrapper *messages.StorageNodeMes
 
 	case *messages.StorageNodeMessage_PutCopy:
 		p.logger.Info("Handling PUTCopy request")
+		if msg.PutCopy.Streamed {
+			p.ack = true
+			if p.pipeline != nil {
+				p.pipeline.Open(msg.PutCopy.FileName, msg.PutCopy.FileSize, msg.PutCopy.Pipeline)
+			}
+			if err := p.startTransfer(msg.PutCopy.FileName); err != nil {
+				p.handlePUTCopyResponse(err)
+			}
+			return
+		}
 		p.fetchPutCopyRequest(msg)
 
 	case *messages.StorageNodeMessage_GetReplica:
 		p.logger.Info("Handling GetReplica request")
+		if msg.GetReplica.Streamed {
+			p.HandleGetReplicaStream(msg.GetReplica.FileName)
+			return
+		}
 		p.fetchGetReplicaRequest(msg)
 
+	case *messages.StorageNodeMessage_Chunk:
+		p.fetchChunk(msg)
+
 	}
 
 	return
 }
 
+// startTransfer opens the partial file the following Chunk frames are written to.
+func (p *ProtoHandler) startTransfer(fileName string) (err error) {
+
+	fileHandler := &file.FileHandler{}
+	fileHandler.SetDir(p.dir)
+	fileHandler.SetFileName(fileName)
+
+	p.partial, err = fileHandler.CreatePartial()
+	if err != nil {
+		p.logger.Error("Error creating partial file", zap.Error(err))
+		return
+	}
+	p.file = fileHandler
+	return
+}
+
+// fetchChunk writes one frame to disk. The last frame commits the file and its checksum.
+func (p *ProtoHandler) fetchChunk(msg *messages.StorageNodeMessage_Chunk) (err error) {
+
+	if p.partial == nil {
+		p.logger.Error("Received a chunk without a streamed transfer")
+		return errors.New("received a chunk without a streamed transfer")
+	}
+
+	chunk := &file.Chunk{
+		Offset:       msg.Chunk.Offset,
+		Data:         msg.Chunk.Data,
+		RunningCRC32: msg.Chunk.RunningCrc32,
+		Last:         msg.Chunk.Last,
+		Checksum:     msg.Chunk.Checksum,
+	}
+
+	err = p.partial.WriteChunk(chunk)
+	if err == nil && p.ack && p.pipeline != nil {
+		p.pipeline.Forward(chunk)
+	}
+	if err == nil && chunk.Last {
+		err = p.partial.Commit(chunk.Checksum)
+		p.partial = nil
+	}
+	if err != nil {
+		p.logger.Error("Error receiving file", zap.Error(err))
+		p.AbortTransfer()
+		if p.ack {
+			p.handlePUTCopyResponse(err)
+		}
+		return
+	}
+
+	if chunk.Last {
+		p.logger.Sugar().Infof("Received %s, checksums match. Writing checksum to disk", p.file.FileName())
+		p.file.ChecksumOnDisk()
+		if p.ack {
+			p.handlePUTCopyResponse(nil)
+		}
+	}
+	return
+}
+
+// handlePUTCopyResponse acks a PUTCopy with the status of this replica and every one after it in the pipeline.
+func (p *ProtoHandler) handlePUTCopyResponse(local error) {
+
+	var replicas []file.ReplicaStatus
+	if p.pipeline != nil {
+		replicas = p.pipeline.Wait(local)
+	} else {
+		replicas = []file.ReplicaStatus{file.NewReplicaStatus("", local)}
+	}
+
+	res := &messages.StorageNodeMessage_PUTCopyResponse{Success: local == nil}
+	for _, replica := range replicas {
+		res.Replicas = append(res.Replicas, &messages.StorageNodeMessage_ReplicaStatus{
+			Host:    replica.Host,
+			Success: replica.Success,
+			Error:   replica.Error,
+		})
+	}
+
+	err := p.MsgHandler().ServerResponseSend(&messages.StorageNodeMessage{
+		StorageNodeMessage: &messages.StorageNodeMessage_PutCopyResponse{PutCopyResponse: res},
+	})
+	if err != nil {
+		p.logger.Error("Error sending PUTCopy response", zap.Error(err))
+	}
+}
+
+// streamFile sends the file on disk in DataChunk frames.
+func (p *ProtoHandler) streamFile(handler *file.FileHandler, send func(wrapper *messages.StorageNodeMessage) error) (err error) {
+
+	return handler.StreamFile(func(chunk *file.Chunk) error {
+		return send(&messages.StorageNodeMessage{
+			StorageNodeMessage: &messages.StorageNodeMessage_Chunk{
+				Chunk: &messages.StorageNodeMessage_DataChunk{
+					FileName:     handler.FileName(),
+					Offset:       chunk.Offset,
+					Data:         chunk.Data,
+					RunningCrc32: chunk.RunningCRC32,
+					Last:         chunk.Last,
+					Checksum:     chunk.Checksum,
+				},
+			},
+		})
+	})
+}
+
 func (p *ProtoHandler) fetchPutCopyRequest(msg *messages.StorageNodeMessage_PutCopy) *interface{} {
 
 	fileHandler := file.FileHandler{}
@@ -135,12 +287,14 @@ func (p *ProtoHandler) fetchGetReplicaResponse(msg *messages.StorageNodeMessage_
 
 }
 
-func (p *ProtoHandler) HandlePUTCopyRequest(id string, handler *file.FileHandler, node string) {
+// HandlePUTCopyStart announces a streamed copy. pipeline lists the nodes the receiver forwards it to.
+func (p *ProtoHandler) HandlePUTCopyStart(fileName string, fileSize int64, pipeline []string) (err error) {
 
 	req := &messages.StorageNodeMessage_PUTCopy{
-		FileName: handler.FileName(),
-		Checksum: handler.Checksum(),
-		FileData: handler.DataStream(),
+		FileName: fileName,
+		Streamed: true,
+		FileSize: fileSize,
+		Pipeline: pipeline,
 	}
 
 	wrapper := &messages.StorageNodeMessage{
@@ -149,7 +303,69 @@ func (p *ProtoHandler) HandlePUTCopyRequest(id string, handler *file.FileHandler
 		},
 	}
 
-	p.MsgHandler().ClientRequestSend(wrapper)
+	return p.MsgHandler().ClientRequestSend(wrapper)
+}
+
+func (p *ProtoHandler) SendChunk(fileName string, chunk *file.Chunk) (err error) {
+
+	return p.MsgHandler().ClientRequestSend(&messages.StorageNodeMessage{
+		StorageNodeMessage: &messages.StorageNodeMessage_Chunk{
+			Chunk: &messages.StorageNodeMessage_DataChunk{
+				FileName:     fileName,
+				Offset:       chunk.Offset,
+				Data:         chunk.Data,
+				RunningCrc32: chunk.RunningCRC32,
+				Last:         chunk.Last,
+				Checksum:     chunk.Checksum,
+			},
+		},
+	})
+}
+
+// ReceivePUTCopyResponse waits for the ack of a streamed copy and returns the status of every replica it covers.
+func (p *ProtoHandler) ReceivePUTCopyResponse() (replicas []file.ReplicaStatus, err error) {
+
+	wrapper, err := p.MsgHandler().ServerResponseReceive()
+	if err != nil {
+		return
+	}
+
+	msg, ok := wrapper.StorageNodeMessage.(*messages.StorageNodeMessage_PutCopyResponse)
+	if !ok {
+		return nil, errors.New("connection closed before the copy was acknowledged")
+	}
+
+	for _, replica := range msg.PutCopyResponse.Replicas {
+		replicas = append(replicas, file.ReplicaStatus{Host: replica.Host, Success: replica.Success, Error: replica.Error})
+	}
+	return
+}
+
+// HandlePUTCopyRequest streams a copy of the file on disk to another node and waits for it to be acknowledged.
+func (p *ProtoHandler) HandlePUTCopyRequest(id string, handler *file.FileHandler, node string) (err error) {
+
+	handler.CalcFileSize()
+	err = p.HandlePUTCopyStart(handler.FileName(), handler.FileSize(), nil)
+	if err != nil {
+		return
+	}
+
+	err = handler.StreamFile(func(chunk *file.Chunk) error {
+		return p.SendChunk(handler.FileName(), chunk)
+	})
+	if err != nil {
+		p.logger.Error("Error streaming copy", zap.String("node", node), zap.Error(err))
+		return
+	}
+
+	replicas, err := p.ReceivePUTCopyResponse()
+	if err != nil {
+		return
+	}
+	if file.CountReplicas(replicas) != len(replicas) || len(replicas) == 0 {
+		return fmt.Errorf("copy of %s was not written on %s", handler.FileName(), node)
+	}
+	return
 }
 
 func (p *ProtoHandler) HandleGetReplicationRequest(fileName string) {
@@ -158,6 +374,7 @@ func (p *ProtoHandler) HandleGetReplicationRequest(fileName string) {
 	req := &messages.StorageNodeMessage_GETReplica{
 
 		FileName: fileName,
+		Streamed: true,
 	}
 
 	wrapper := &messages.StorageNodeMessage{
@@ -169,6 +386,39 @@ func (p *ProtoHandler) HandleGetReplicationRequest(fileName string) {
 
 }
 
+// HandleGetReplicaStream sends the GETReplicaResponse header followed by the file in DataChunk frames.
+func (p *ProtoHandler) HandleGetReplicaStream(fileName string) {
+
+	fileHandler := file.NewFileHandler(fileName)
+	fileHandler.SetDir(p.dir)
+	fileHandler.CalcFileSize()
+
+	if exists, _ := fileHandler.FileCheck(); !exists {
+		p.logger.Error("Replica requested for a missing file", zap.String("file", fileName))
+		return
+	}
+
+	res := &messages.StorageNodeMessage_GETReplicaResponse{
+		FileName: fileName,
+		Streamed: true,
+		FileSize: fileHandler.FileSize(),
+	}
+
+	err := p.MsgHandler().ServerResponseSend(&messages.StorageNodeMessage{
+		StorageNodeMessage: &messages.StorageNodeMessage_GetReplicaResponse{
+			GetReplicaResponse: res,
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	err = p.streamFile(fileHandler, p.MsgHandler().ServerResponseSend)
+	if err != nil {
+		p.logger.Error("Error streaming replica", zap.Error(err))
+	}
+}
+
 func (p *ProtoHandler) HandleGetReplicaResponse(f *file.FileHandler) {
 
 	p.logger.Info("Sending Replication response")
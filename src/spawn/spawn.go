@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -9,11 +10,15 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
-	messages "src/messages/controller_storage"
+	"os/signal"
 	"src/storage_node"
+	"syscall"
 	"time"
 )
 
+// RECONNECT_DELAY is how long the node waits before reconnecting to the Controller.
+const RECONNECT_DELAY = 2 * time.Second
+
 func main() {
 
 	if len(os.Args) != 2 {
@@ -45,41 +50,26 @@ func main() {
 	id := uuid.New()
 	newStorageNode := storage_node.NewStorageNode(id.String(), networkInterfaces, logger)
 	newStorageNode.SetDir(dir)
-	proto, conn, err := newStorageNode.Dial()
-	if err != nil {
-		logger.Error("Error dialing: ", zap.Error(err))
-		return
-	}
 	newStorageNode.ConcurrentChecksumCheck()
-	newStorageNode.HandleIntroduction(proto)
-	newStorageNode.ConcurrentListen()
-	newStorageNode.HandleConnection(proto)
-	newStorageNode.Disconnect(conn)
-	for {
-		timer := time.Duration(newStorageNode.GetInterval()) * time.Second
-		time.Sleep(timer / 2)
-		proto, conn, err = newStorageNode.Dial()
-		if err != nil {
-			logger.Error("Error dialing: ", zap.Error(err))
-			//TODO: Add a retry mechanism or return, continue for now
-			continue
-		}
 
-		time.Sleep(timer / 2)
+	//stopping the node ends its session and the transfers in flight
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	newStorageNode.ConcurrentListen(ctx)
 
-		status := newStorageNode.GetNodeStatus()
-		if status == messages.StorageNodeMessage_ACTIVE {
-			newStorageNode.HandleHeartbeats(proto)
-		} else {
-			newStorageNode.HandleIntroduction(proto)
+	//the node keeps one session open with the Controller, and reconnects when it breaks
+	for {
+		err = newStorageNode.RunSession(ctx)
+		logger.Error("Session with the Controller ended: ", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Shutting down")
+			return
+		case <-time.After(RECONNECT_DELAY):
 		}
-
-		newStorageNode.HandleConnection(proto)
-		newStorageNode.Disconnect(conn)
 	}
 
-	select {}
-
 }
 func appendLogger(dir string) *zap.Logger {
 
@@ -0,0 +1,74 @@
+package storage_node
+
+import (
+	"sort"
+	proto3 "src/proto/controller_storage"
+	"sync"
+)
+
+// FULL_REPORT_EVERY is how many heartbeats pass between full block reports.
+const FULL_REPORT_EVERY = 60
+
+// blockReporter builds the block reports sent with the heartbeats. It remembers what the Controller was
+// last told the node holds, so normal heartbeats only carry what changed since.
+type blockReporter struct {
+	mutex     sync.Mutex
+	reported  map[string]bool
+	seq       uint64
+	sinceFull int
+	fullDue   bool
+}
+
+func newBlockReporter() *blockReporter {
+	return &blockReporter{
+		reported: make(map[string]bool),
+		fullDue:  true,
+	}
+}
+
+// requestFull makes the next report a full one.
+func (b *blockReporter) requestFull() {
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.fullDue = true
+}
+
+// next builds the report for the files the node holds now, numbered after the last one.
+func (b *blockReporter) next(files []string) (report proto3.BlockReport) {
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.seq++
+	report.Seq = b.seq
+
+	current := make(map[string]bool, len(files))
+	for _, f := range files {
+		current[f] = true
+	}
+
+	if b.fullDue || b.sinceFull >= FULL_REPORT_EVERY {
+		report.Full = true
+		report.Files = files
+		b.fullDue = false
+		b.sinceFull = 0
+	} else {
+		for f := range current {
+			if !b.reported[f] {
+				report.Added = append(report.Added, f)
+			}
+		}
+		for f := range b.reported {
+			if !current[f] {
+				report.Removed = append(report.Removed, f)
+			}
+		}
+		sort.Strings(report.Added)
+		sort.Strings(report.Removed)
+		b.sinceFull++
+	}
+
+	b.reported = current
+	return
+}
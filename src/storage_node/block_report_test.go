@@ -0,0 +1,68 @@
+package storage_node
+
+import (
+	"reflect"
+	proto3 "src/proto/controller_storage"
+	"testing"
+)
+
+func TestBlockReporter_next(t *testing.T) {
+	type step struct {
+		files       []string
+		requestFull bool
+		want        proto3.BlockReport
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "Test first report is full",
+			steps: []step{
+				{files: []string{"file_0", "file_1"}, want: proto3.BlockReport{Seq: 1, Full: true, Files: []string{"file_0", "file_1"}}},
+			},
+		},
+		{
+			name: "Test deltas after a full report",
+			steps: []step{
+				{files: []string{"file_0", "file_1"}, want: proto3.BlockReport{Seq: 1, Full: true, Files: []string{"file_0", "file_1"}}},
+				{files: []string{"file_1", "file_2", "file_3"}, want: proto3.BlockReport{Seq: 2, Added: []string{"file_2", "file_3"}, Removed: []string{"file_0"}}},
+				{files: []string{"file_1", "file_2", "file_3"}, want: proto3.BlockReport{Seq: 3}},
+			},
+		},
+		{
+			name: "Test requested full report",
+			steps: []step{
+				{files: []string{"file_0"}, want: proto3.BlockReport{Seq: 1, Full: true, Files: []string{"file_0"}}},
+				{files: []string{"file_1"}, requestFull: true, want: proto3.BlockReport{Seq: 2, Full: true, Files: []string{"file_1"}}},
+				{files: []string{}, want: proto3.BlockReport{Seq: 3, Removed: []string{"file_1"}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBlockReporter()
+			for i, step := range tt.steps {
+				if step.requestFull {
+					b.requestFull()
+				}
+				if got := b.next(step.files); !reflect.DeepEqual(got, step.want) {
+					t.Errorf("next() report %d = %+v, want %+v", i, got, step.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockReporter_periodicFull(t *testing.T) {
+	b := newBlockReporter()
+	b.next([]string{"file_0"})
+	for i := 0; i < FULL_REPORT_EVERY; i++ {
+		if b.next([]string{"file_0"}).Full {
+			t.Fatalf("report %d is full, want a delta", i+2)
+		}
+	}
+	if !b.next([]string{"file_0"}).Full {
+		t.Errorf("report %d is a delta, want a full report", FULL_REPORT_EVERY+2)
+	}
+}
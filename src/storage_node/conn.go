@@ -0,0 +1,63 @@
+package storage_node
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// CONNECT_TIMEOUT is how long connecting to the Controller or another node may take.
+	CONNECT_TIMEOUT = 10 * time.Second
+	// PEER_TIMEOUT is how long the node waits on a client or another node to send or take the next message.
+	PEER_TIMEOUT = 60 * time.Second
+	// SESSION_WRITE_TIMEOUT is how long sending a message to the Controller may take. The session is read
+	// without a deadline, the Controller only sends when it has something for the node.
+	SESSION_WRITE_TIMEOUT = 30 * time.Second
+)
+
+// watchedConn is a connection that is closed once its context is cancelled, which fails whatever is reading
+// or writing on it. Closing it stops the watch.
+type watchedConn struct {
+	net.Conn
+	done chan struct{}
+	once sync.Once
+}
+
+func watch(ctx context.Context, conn net.Conn) net.Conn {
+
+	w := &watchedConn{Conn: conn, done: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Conn.Close()
+		case <-w.done:
+		}
+	}()
+	return w
+}
+
+func (w *watchedConn) Close() error {
+	w.once.Do(func() { close(w.done) })
+	return w.Conn.Close()
+}
+
+// dial connects to addr, and closes the connection when ctx is cancelled.
+func dial(ctx context.Context, addr string) (net.Conn, error) {
+
+	dialer := net.Dialer{Timeout: CONNECT_TIMEOUT}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return watch(ctx, conn), nil
+}
+
+// closeOnDone closes a listener once ctx is cancelled, which ends its accept loop.
+func closeOnDone(ctx context.Context, ln net.Listener) {
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"go.uber.org/zap"
 	"os"
-	"regexp"
+	"strings"
 	"syscall"
 	"time"
 )
@@ -44,53 +44,6 @@ func (s *StorageNode) potentiallyCorrupt(f string) bool {
 
 }
 
-func (s *StorageNode) isNewFile(f string) bool {
-
-	// Get file information
-	fileInfo, err := os.Stat(s.dir + "/" + f)
-	if err != nil {
-		fmt.Println(err)
-
-		//s.logger.Error("Error getting file information", zap.Error(err))
-		return true
-	}
-
-	stat := fileInfo.Sys().(*syscall.Stat_t)
-	createTime := time.Unix(int64(stat.Ctim.Sec), int64(stat.Ctim.Nsec))
-
-	currentTime := time.Now()
-
-	timeDiff := currentTime.Sub(createTime)
-
-	if timeDiff < time.Minute {
-		return true
-	} else {
-		return false
-	}
-
-}
-
-func (s *StorageNode) GetNewFiles() (allFiles []string) {
-
-	files, err := os.ReadDir(s.dir)
-	if err != nil {
-		fmt.Println("Error reading directory:", err)
-		return
-	}
-
-	var filesFound []string
-	for _, file := range files {
-
-		if !file.IsDir() {
-			if s.isNewFile(file.Name()) {
-				filesFound = append(filesFound, file.Name())
-			}
-		}
-	}
-	return filesFound
-
-}
-
 func (s *StorageNode) GetAllFiles() (allFiles []string) {
 
 	files, err := os.ReadDir(s.dir)
@@ -102,7 +55,7 @@ func (s *StorageNode) GetAllFiles() (allFiles []string) {
 	var filesFound []string
 	for _, file := range files {
 
-		if !file.IsDir() {
+		if !file.IsDir() && !isPartialFile(file.Name()) {
 			filesFound = append(filesFound, file.Name())
 		}
 	}
@@ -111,20 +64,15 @@ func (s *StorageNode) GetAllFiles() (allFiles []string) {
 	//s.fileInfo.AllFiles = filesFound
 }
 
-func (s *StorageNode) isFileFragment(f string) bool {
-
-	//check if the file name ends with _x, where x is a number
-	//if it does, then it is a file fragment
-	//if it doesn't, then it is a file
-
-	pattern := regexp.MustCompile(`_\d+$`)
-
-	if pattern.MatchString(f) {
-		return true
-	}
-
-	return false
+// isPartialFile reports whether f is a streamed transfer still in flight.
+func isPartialFile(f string) bool {
+	return strings.HasSuffix(f, ".part")
+}
 
+// isBlock reports whether f holds a fragment rather than the checksum of one. Fragments are stored under the
+// opaque ids the controller generates, so nothing else can be told from the name.
+func isBlock(f string) bool {
+	return !strings.HasSuffix(f, ".checksum") && !isPartialFile(f)
 }
 
 // check for free space
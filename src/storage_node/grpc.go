@@ -0,0 +1,279 @@
+package storage_node
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net"
+	messagesClient "src/messages/client_storage"
+	messagesStorage "src/messages/storage_storage"
+)
+
+// storageService serves StorageService and ReplicationService over gRPC. Every call is handed to handleClient
+// or handleOtherNode over an in-memory connection, so both transports are served by the same code.
+type storageService struct {
+	messagesClient.UnimplementedStorageServiceServer
+	messagesStorage.UnimplementedReplicationServiceServer
+	ctx  context.Context
+	node *StorageNode
+}
+
+// ServeGRPC serves StorageService and ReplicationService on the node's gRPC port until ctx is cancelled.
+func (s *StorageNode) ServeGRPC(ctx context.Context) {
+
+	host := s.networkInterfaces.NodeInterface.Host
+	port := s.networkInterfaces.NodeInterface.GrpcPort
+
+	ln, err := net.Listen("tcp", host+":"+port)
+	if err != nil {
+		s.logger.Sugar().Errorf("There was an error listening on the port: %s", err)
+		return
+	}
+	s.logger.Info("Listening for gRPC calls on port " + port)
+
+	server := grpc.NewServer()
+	service := &storageService{ctx: ctx, node: s}
+	messagesClient.RegisterStorageServiceServer(server, service)
+	messagesStorage.RegisterReplicationServiceServer(server, service)
+
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+
+	err = server.Serve(ln)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Sugar().Errorf("gRPC server stopped: %s", err)
+	}
+}
+
+// clientPipe connects to handleClient as a client would over TCP, and returns the client's end of the
+// connection. It is closed when ctx is cancelled.
+func (g *storageService) clientPipe(ctx context.Context) *messagesClient.MessageHandler {
+
+	clientConn, nodeConn := net.Pipe()
+
+	nodeHandler := messagesClient.NewMessageHandler(watch(g.ctx, nodeConn))
+	nodeHandler.SetTimeouts(PEER_TIMEOUT, PEER_TIMEOUT)
+	go g.node.handleClient(g.ctx, nodeHandler)
+
+	return messagesClient.NewMessageHandler(watch(ctx, clientConn))
+}
+
+// nodePipe connects to handleOtherNode as another node would over TCP.
+func (g *storageService) nodePipe(ctx context.Context) *messagesStorage.MessageHandler {
+
+	clientConn, nodeConn := net.Pipe()
+
+	nodeHandler := messagesStorage.NewMessageHandler(watch(g.ctx, nodeConn))
+	nodeHandler.SetTimeouts(PEER_TIMEOUT, PEER_TIMEOUT)
+	go g.node.handleOtherNode(g.ctx, nodeHandler)
+
+	return messagesStorage.NewMessageHandler(watch(ctx, clientConn))
+}
+
+// callError turns what broke a call into a gRPC status.
+func callError(ctx context.Context, err error) error {
+
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if err == io.EOF {
+		return status.Error(codes.Aborted, "the node ended the transfer without answering")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (g *storageService) PutFragment(stream messagesClient.StorageService_PutFragmentServer) error {
+
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	data := first.GetFileDataRequest()
+	if data == nil || !data.Streamed {
+		return status.Error(codes.InvalidArgument, "the first request must be a streamed FileDataRequest")
+	}
+
+	msgHandler := g.clientPipe(ctx)
+	defer msgHandler.Close()
+
+	//the fragment is announced before it is sent
+	err = msgHandler.ClientRequestSend(&messagesClient.ClientRequest{
+		Request: &messagesClient.ClientRequest_FilePutRequest{
+			FilePutRequest: &messagesClient.FilePutRequest{FileName: data.FileName, FileSize: data.FileSize},
+		},
+	})
+	if err != nil {
+		return callError(ctx, err)
+	}
+	res, err := msgHandler.ServerResponseReceive()
+	if err != nil {
+		return callError(ctx, err)
+	}
+	if put := res.GetFilePutResponse(); put == nil || !put.Success {
+		return stream.SendAndClose(&messagesClient.FileDataResponse{Success: false, ErrorCode: put.GetErrorCode()})
+	}
+
+	err = msgHandler.ClientRequestSend(first)
+	if err != nil {
+		return callError(ctx, err)
+	}
+
+	//the node may answer before the last chunk, so the chunks are forwarded while the answer is awaited
+	go func() {
+		for {
+			req, errR := stream.Recv()
+			if errR != nil {
+				//a fragment cut short is dropped by the node
+				msgHandler.Close()
+				return
+			}
+			if msgHandler.ClientRequestSend(req) != nil || req.GetFileChunk().GetLast() {
+				return
+			}
+		}
+	}()
+
+	res, err = msgHandler.ServerResponseReceive()
+	if err != nil {
+		return callError(ctx, err)
+	}
+	if res.GetFileDataResponse() == nil {
+		return status.Errorf(codes.Internal, "unexpected response %T", res.GetResponse())
+	}
+	return stream.SendAndClose(res.GetFileDataResponse())
+}
+
+func (g *storageService) GetFragment(req *messagesClient.FileGetRequest, stream messagesClient.StorageService_GetFragmentServer) error {
+
+	ctx := stream.Context()
+	msgHandler := g.clientPipe(ctx)
+	defer msgHandler.Close()
+
+	req.Streamed = true
+	err := msgHandler.ClientRequestSend(&messagesClient.ClientRequest{
+		Request: &messagesClient.ClientRequest_FileGetRequest{FileGetRequest: req},
+	})
+	if err != nil {
+		return callError(ctx, err)
+	}
+
+	//the node closes the connection once it sent the header and every chunk
+	for {
+		res, err := msgHandler.ServerResponseReceive()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return callError(ctx, err)
+		}
+		err = stream.Send(res)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (g *storageService) DeleteFragment(ctx context.Context, req *messagesClient.FileDeleteRequest) (*messagesClient.FileDeleteResponse, error) {
+
+	msgHandler := g.clientPipe(ctx)
+	defer msgHandler.Close()
+
+	err := msgHandler.ClientRequestSend(&messagesClient.ClientRequest{
+		Request: &messagesClient.ClientRequest_FileDeleteRequest{FileDeleteRequest: req},
+	})
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	res, err := msgHandler.ServerResponseReceive()
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if res.GetFileDeleteResponse() == nil {
+		return nil, status.Errorf(codes.Internal, "unexpected response %T", res.GetResponse())
+	}
+	return res.GetFileDeleteResponse(), nil
+}
+
+func (g *storageService) PutCopy(stream messagesStorage.ReplicationService_PutCopyServer) error {
+
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if !first.GetPutCopy().GetStreamed() {
+		return status.Error(codes.InvalidArgument, "the first message must be a streamed PUTCopy")
+	}
+
+	msgHandler := g.nodePipe(ctx)
+	defer msgHandler.Close()
+
+	err = msgHandler.ClientRequestSend(first)
+	if err != nil {
+		return callError(ctx, err)
+	}
+
+	//the node may answer before the last chunk, so the chunks are forwarded while the answer is awaited
+	go func() {
+		for {
+			msg, errR := stream.Recv()
+			if errR != nil {
+				//a copy cut short is dropped by the node
+				msgHandler.Close()
+				return
+			}
+			if msgHandler.ClientRequestSend(msg) != nil || msg.GetChunk().GetLast() {
+				return
+			}
+		}
+	}()
+
+	res, err := msgHandler.ServerResponseReceive()
+	if err != nil {
+		return callError(ctx, err)
+	}
+	if res.GetPutCopyResponse() == nil {
+		return status.Errorf(codes.Internal, "unexpected response %T", res.GetStorageNodeMessage())
+	}
+	return stream.SendAndClose(res.GetPutCopyResponse())
+}
+
+func (g *storageService) GetReplica(req *messagesStorage.StorageNodeMessage_GETReplica, stream messagesStorage.ReplicationService_GetReplicaServer) error {
+
+	ctx := stream.Context()
+	msgHandler := g.nodePipe(ctx)
+	defer msgHandler.Close()
+
+	req.Streamed = true
+	err := msgHandler.ClientRequestSend(&messagesStorage.StorageNodeMessage{
+		StorageNodeMessage: &messagesStorage.StorageNodeMessage_GetReplica{GetReplica: req},
+	})
+	if err != nil {
+		return callError(ctx, err)
+	}
+
+	//the node closes the connection once it sent the header and every chunk, and at once if it has no replica
+	sent := false
+	for {
+		res, err := msgHandler.ServerResponseReceive()
+		if err == io.EOF && !sent {
+			return status.Errorf(codes.NotFound, "no replica of %s", req.FileName)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return callError(ctx, err)
+		}
+		err = stream.Send(res)
+		if err != nil {
+			return err
+		}
+		sent = true
+	}
+}
@@ -0,0 +1,134 @@
+package storage_node
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"hash/crc32"
+	"io"
+	"net"
+	messagesClient "src/messages/client_storage"
+	"sync"
+	"testing"
+)
+
+func TestStorageService_roundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node := &StorageNode{
+		dir:    t.TempDir() + "/",
+		logger: zap.NewNop(),
+		mutex:  &sync.Mutex{},
+	}
+
+	ln := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	messagesClient.RegisterStorageServiceServer(server, &storageService{ctx: ctx, node: node})
+	go server.Serve(ln)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	client := messagesClient.NewStorageServiceClient(conn)
+
+	data := []byte("hello world")
+	checksum := md5.Sum(data)
+
+	put, err := client.PutFragment(ctx)
+	if err != nil {
+		t.Fatalf("PutFragment() error = %v", err)
+	}
+	requests := []*messagesClient.ClientRequest{
+		{Request: &messagesClient.ClientRequest_FileDataRequest{FileDataRequest: &messagesClient.FileDataRequest{
+			FileName: "file1_1",
+			FileSize: int64(len(data)),
+			Streamed: true,
+		}}},
+		{Request: &messagesClient.ClientRequest_FileChunk{FileChunk: &messagesClient.FileChunk{
+			FileName:     "file1_1",
+			Data:         data,
+			RunningCrc32: crc32.ChecksumIEEE(data),
+			Last:         true,
+			Checksum:     checksum[:],
+		}}},
+	}
+	for _, req := range requests {
+		if err = put.Send(req); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	putRes, err := put.CloseAndRecv()
+	if err != nil || !putRes.Success {
+		t.Fatalf("PutFragment() = %v, %v, want success", putRes, err)
+	}
+
+	tests := []struct {
+		name    string
+		delete  bool
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "Test get a stored fragment",
+			want: data,
+		},
+		{
+			name:    "Test get a deleted fragment",
+			delete:  true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.delete {
+				res, err := client.DeleteFragment(ctx, &messagesClient.FileDeleteRequest{FileName: "file1_1"})
+				if err != nil || !res.Success {
+					t.Fatalf("DeleteFragment() = %v, %v, want success", res, err)
+				}
+			}
+
+			get, err := client.GetFragment(ctx, &messagesClient.FileGetRequest{FileName: "file1_1"})
+			if err != nil {
+				t.Fatalf("GetFragment() error = %v", err)
+			}
+			header, err := get.Recv()
+			if err != nil {
+				t.Fatalf("Recv() error = %v", err)
+			}
+			if !header.GetFileGetResponse().GetSuccess() {
+				if !tt.wantErr {
+					t.Fatalf("GetFragment() = %v, want success", header)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatalf("GetFragment() = %v, want a failure", header)
+			}
+
+			var got bytes.Buffer
+			for {
+				res, err := get.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Recv() error = %v", err)
+				}
+				got.Write(res.GetFileChunk().GetData())
+			}
+			if !bytes.Equal(got.Bytes(), tt.want) {
+				t.Errorf("GetFragment() = %q, want %q", got.Bytes(), tt.want)
+			}
+		})
+	}
+}
@@ -9,9 +9,30 @@ type NodeInterface struct {
 	Host                string `yaml:"host"`
 	ControllerCommsPort string `yaml:"controller_comms_port"`
 	ClientCommsPort     string `yaml:"client_comms_port"`
+	//failure domain the node is in, replicas of a fragment are spread over different ones
+	Rack string `yaml:"rack"`
+	//port StorageService and ReplicationService are served on over gRPC, left empty they are not
+	GrpcPort string `yaml:"grpc_port,omitempty"`
 }
 
 type ControllerInterface struct {
 	Host string `yaml:"host"`
 	Port string `yaml:"port"`
+	//the other controllers of a highly available group, only the active one accepts the node
+	Standbys []ControllerAddress `yaml:"standbys,omitempty"`
+}
+
+type ControllerAddress struct {
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+}
+
+// Addresses returns the host:port of every controller the node may connect to, the one configured first.
+func (c ControllerInterface) Addresses() []string {
+
+	addrs := []string{c.Host + ":" + c.Port}
+	for _, standby := range c.Standbys {
+		addrs = append(addrs, standby.Host+":"+standby.Port)
+	}
+	return addrs
 }
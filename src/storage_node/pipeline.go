@@ -0,0 +1,101 @@
+package storage_node
+
+import (
+	"context"
+	"errors"
+	"src/file"
+	proto3Storage "src/proto/storage_storage"
+)
+
+// pipeline forwards a streamed fragment to the next node of the replication chain. That node forwards it
+// to the one after, and acks travel back up the chain once each replica is written and verified.
+type pipeline struct {
+	ctx      context.Context
+	node     *StorageNode
+	fileName string
+
+	proto   *proto3Storage.ProtoHandler
+	next    string
+	rest    []string
+	skipped []file.ReplicaStatus
+	err     error
+}
+
+// newPipeline returns a pipeline whose connection down the chain is closed when ctx is cancelled.
+func (s *StorageNode) newPipeline(ctx context.Context) *pipeline {
+	return &pipeline{ctx: ctx, node: s}
+}
+
+func (p *pipeline) Open(fileName string, fileSize int64, nodes []string) {
+
+	p.fileName = fileName
+	self := p.node.networkInterfaces.NodeInterface.Host
+
+	chain := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if node != self {
+			chain = append(chain, node)
+		}
+	}
+
+	// a node we cannot reach is reported as failed, and the chain continues with the next one
+	for i, node := range chain {
+		protoStorage, err := p.node.DialOtherNode(p.ctx, node)
+		if err == nil {
+			err = protoStorage.HandlePUTCopyStart(fileName, fileSize, chain[i+1:])
+			if err != nil {
+				protoStorage.MsgHandler().Close()
+			}
+		}
+		if err != nil {
+			p.node.logger.Sugar().Errorf("Skipping %s in the pipeline for %s: %s", node, fileName, err)
+			p.skipped = append(p.skipped, file.NewReplicaStatus(node, err))
+			continue
+		}
+
+		p.proto = protoStorage
+		p.next = node
+		p.rest = chain[i+1:]
+		return
+	}
+}
+
+func (p *pipeline) Forward(chunk *file.Chunk) {
+
+	if p.proto == nil || p.err != nil {
+		return
+	}
+
+	p.err = p.proto.SendChunk(p.fileName, chunk)
+	if p.err != nil {
+		p.node.logger.Sugar().Errorf("Error forwarding %s to %s: %s", p.fileName, p.next, p.err)
+	}
+}
+
+func (p *pipeline) Wait(local error) (replicas []file.ReplicaStatus) {
+
+	replicas = append(replicas, file.NewReplicaStatus(p.node.networkInterfaces.NodeInterface.Host, local))
+	replicas = append(replicas, p.skipped...)
+	if p.proto == nil {
+		return
+	}
+	defer p.proto.MsgHandler().Close()
+
+	err := p.err
+	if local != nil && err == nil {
+		err = errors.New("pipeline aborted upstream")
+	}
+	if err == nil {
+		var downstream []file.ReplicaStatus
+		downstream, err = p.proto.ReceivePUTCopyResponse()
+		if err == nil {
+			return append(replicas, downstream...)
+		}
+	}
+
+	// closing the connection makes the rest of the chain drop its partial copy
+	for _, node := range append([]string{p.next}, p.rest...) {
+		replicas = append(replicas, file.NewReplicaStatus(node, err))
+	}
+	return
+}
@@ -0,0 +1,104 @@
+package storage_node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"src/erasure"
+	"src/file"
+	proto3 "src/proto/controller_storage"
+)
+
+// ReconstructShards rebuilds erasure coded shards the Controller found lost, and returns the ones it could not.
+func (s *StorageNode) ReconstructShards(ctx context.Context, shards []*proto3.ShardReconstruction) (failed []string) {
+
+	for _, shard := range shards {
+		s.logger.Sugar().Infof("Rebuilding shard %s", shard.Shard)
+		err := s.reconstructShard(ctx, shard)
+		if err != nil {
+			s.logger.Sugar().Errorf("Error rebuilding shard %s: %s", shard.Shard, err)
+			failed = append(failed, shard.Shard)
+			continue
+		}
+		s.logger.Sugar().Infof("Shard %s rebuilt", shard.Shard)
+	}
+	return
+}
+
+// reconstructShard fetches enough surviving shards of the group into a scratch dir and rebuilds the lost one
+// from them. Only the rebuilt shard is moved into the node's dir.
+func (s *StorageNode) reconstructShard(ctx context.Context, req *proto3.ShardReconstruction) (err error) {
+
+	if _, errS := os.Stat(s.dir + req.Shard); errS == nil {
+		return
+	}
+
+	index, found := req.Layout.Position(req.Shard)
+	if !found {
+		return fmt.Errorf("%s is not a shard of the file", req.Shard)
+	}
+	group, shard := req.Layout.Locate(index)
+
+	scratch, err := os.MkdirTemp(s.dir, ".rebuild-")
+	if err != nil {
+		return
+	}
+	defer os.RemoveAll(scratch)
+	scratch += "/"
+
+	fetched := 0
+	for _, source := range req.Sources {
+		if fetched == req.Layout.DataShards {
+			break
+		}
+		errF := s.fetchShard(ctx, source.Node.Host, source.Shard, scratch)
+		if errF != nil {
+			s.logger.Sugar().Errorf("Error fetching %s from %s: %s", source.Shard, source.Node.Host, errF)
+			continue
+		}
+		fetched++
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if fetched < req.Layout.DataShards {
+		return erasure.ErrTooFewShards
+	}
+
+	handler := file.NewFileHandler(req.Shard)
+	handler.SetDir(scratch)
+	err = handler.ReconstructShards(req.Layout, group, []int{shard})
+	if err != nil {
+		return
+	}
+
+	err = os.Rename(scratch+req.Shard, s.dir+req.Shard)
+	if err != nil {
+		return
+	}
+
+	rebuilt := file.NewFileHandler(req.Shard)
+	rebuilt.SetDir(s.dir)
+	err = rebuilt.FindChecksumOnDisk()
+	if err != nil {
+		return
+	}
+	return rebuilt.ChecksumOnDisk()
+}
+
+// fetchShard copies a shard from another node into dir.
+func (s *StorageNode) fetchShard(ctx context.Context, host string, shard string, dir string) (err error) {
+
+	protoStorage, err := s.dialOtherNode(ctx, host, dir)
+	if err != nil {
+		return
+	}
+
+	protoStorage.HandleGetReplicationRequest(shard)
+	s.HandleOtherNodeConnection(protoStorage)
+
+	if _, err = os.Stat(dir + shard); err != nil {
+		return fmt.Errorf("%s was not received", shard)
+	}
+	return
+}
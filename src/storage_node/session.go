@@ -0,0 +1,170 @@
+package storage_node
+
+import (
+	"context"
+	"os"
+	"src/file"
+	messages "src/messages/controller_storage"
+	proto3 "src/proto/controller_storage"
+	"time"
+)
+
+// RunSession connects to the Controller and keeps the connection open. The node introduces itself on it,
+// sends a heartbeat every interval, and handles the Controller's responses and commands as they arrive.
+// It returns when the connection breaks or ctx is cancelled, and cancels the commands still running.
+func (s *StorageNode) RunSession(ctx context.Context) (err error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	proto, conn, err := s.Dial(ctx)
+	if err != nil {
+		return
+	}
+	defer s.Disconnect(conn)
+
+	s.mutex.Lock()
+	s.nodeStatus = messages.StorageNodeMessage_NEW
+	s.proto = proto
+	s.mutex.Unlock()
+
+	//deltas sent on an earlier session may not have arrived
+	s.reports.requestFull()
+
+	defer func() {
+		s.mutex.Lock()
+		s.proto = nil
+		s.mutex.Unlock()
+	}()
+
+	err = s.HandleIntroduction(proto)
+	if err != nil {
+		return
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go s.heartbeats(proto, done)
+
+	return s.HandleConnection(ctx, proto)
+}
+
+// session returns the connection of the open session, or nil if there is none.
+func (s *StorageNode) session() *proto3.ProtoHandler {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.proto
+}
+
+func (s *StorageNode) heartbeats(proto *proto3.ProtoHandler, done chan struct{}) {
+
+	for {
+		//the interval is only known once the Controller accepted the node
+		interval := s.GetInterval()
+		if interval <= 0 {
+			interval = 1
+		}
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Duration(interval) * time.Second):
+		}
+
+		if s.GetNodeStatus() == messages.StorageNodeMessage_ACTIVE {
+			s.HandleHeartbeats(proto)
+		} else {
+			s.HandleIntroduction(proto)
+		}
+	}
+}
+
+// runCommand runs a command the Controller pushed and acknowledges it with what it failed on.
+func (s *StorageNode) runCommand(proto *proto3.ProtoHandler, commandId uint64, run func() []string) {
+
+	failed := run()
+	err := proto.SendCommandAck(commandId, failed)
+	if err != nil {
+		s.logger.Sugar().Errorf("Could not acknowledge command %d: %s", commandId, err)
+	}
+}
+
+// Replicate streams fragments to the nodes the Controller picked, and returns the ones that did not reach all of them.
+func (s *StorageNode) Replicate(ctx context.Context, infos []*proto3.ReplicationInfo) (failed []string) {
+
+	for _, frag := range infos {
+		if ctx.Err() != nil {
+			failed = append(failed, frag.FileName)
+			continue
+		}
+		s.logger.Sugar().Infof("Replicating fragment %s", frag.FileName)
+
+		ok := true
+		for _, node := range frag.StorageNodes {
+			//stream the data to the nodes
+			protoStorage, err := s.DialOtherNode(ctx, node.Host())
+			if err != nil {
+				ok = false
+				continue
+			}
+
+			s.logger.Info("Connected to node")
+			fileHandler := file.FileHandler{}
+			fileHandler.SetDir(s.dir)
+			fileHandler.SetFileName(frag.FileName)
+
+			if s.StreamData(protoStorage, &fileHandler, node.Host()) != nil {
+				ok = false
+			}
+		}
+
+		if !ok {
+			failed = append(failed, frag.FileName)
+		}
+	}
+	return
+}
+
+// DeleteFragments removes fragments and their checksums, and returns the ones it could not remove.
+func (s *StorageNode) DeleteFragments(fragments []string) (failed []string) {
+
+	for _, frag := range fragments {
+		s.logger.Sugar().Infof("Deleting fragment %s", frag)
+
+		fileHandler := file.NewFileHandler(frag)
+		fileHandler.SetDir(s.dir)
+		err := fileHandler.DeleteFile()
+		if err != nil {
+			s.logger.Sugar().Errorf("Error deleting fragment %s: %s", frag, err)
+			failed = append(failed, frag)
+		}
+	}
+	return
+}
+
+// VerifyFragments checks fragments against their checksums on disk, and returns the ones that are corrupt or missing.
+func (s *StorageNode) VerifyFragments(fragments []string) (failed []string) {
+
+	for _, frag := range fragments {
+		if _, err := os.Stat(s.dir + frag); err != nil {
+			failed = append(failed, frag)
+			continue
+		}
+
+		fileHandler := file.NewFileHandler(frag)
+		fileHandler.SetDir(s.dir)
+		err := fileHandler.FindChecksumOnDisk()
+		if err != nil {
+			failed = append(failed, frag)
+			continue
+		}
+
+		valid, err := fileHandler.ValidateChecksumFromFile(frag)
+		if err != nil || !valid {
+			s.logger.Sugar().Infof("Fragment %s failed verification", frag)
+			failed = append(failed, frag)
+		}
+	}
+	return
+}
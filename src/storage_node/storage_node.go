@@ -1,6 +1,7 @@
 package storage_node
 
 import (
+	"context"
 	"fmt"
 	"go.uber.org/zap"
 	"log"
@@ -9,6 +10,7 @@ import (
 	messagesClient "src/messages/client_storage"
 	messages "src/messages/controller_storage"
 	messagesStorage "src/messages/storage_storage"
+	"src/messages/wire"
 	"sync"
 
 	proto3Client "src/proto/client_storage"
@@ -30,8 +32,11 @@ type StorageNode struct {
 	protoStorage      *proto3Storage.ProtoHandler
 	logger            *zap.Logger
 	fileInfo          FileInfo
+	reports           *blockReporter
 	mutex             *sync.Mutex
 	networkInterfaces NetworkInterfaces
+	//the controller the node last connected to, in the order of ControllerInterface.Addresses
+	controller int
 }
 
 func (s *StorageNode) SetDir(dir string) {
@@ -63,6 +68,7 @@ func NewStorageNode(nodeID string, interfaces NetworkInterfaces, logger *zap.Log
 		nodeID:            nodeID,
 		networkInterfaces: interfaces,
 		logger:            logger,
+		reports:           newBlockReporter(),
 		mutex:             &sync.Mutex{},
 	}
 	//start a go routine to listen for messages from the client
@@ -85,17 +91,16 @@ func (s *StorageNode) ChecksumCheck() {
 	files := s.GetAllFiles()
 
 	for _, f := range files {
-		if !s.isFileFragment(f) {
+		if !isBlock(f) {
 			continue
 		}
 		if s.potentiallyCorrupt(f) {
 			fileHandler2 := file.NewFileHandler(f)
 			fileHandler2.SetDir(s.Dir())
-			_, err := fileHandler2.ReadFile()
+			err := fileHandler2.FindChecksumOnDisk()
 			if err != nil {
 				s.logger.Error("There was an error reading the file.")
 			}
-			fileHandler2.FindAndSetCheckSum()
 			valid, err := fileHandler2.ValidateChecksumFromFile(f)
 			if err != nil {
 				s.logger.Error("There was an error validating the checksum.")
@@ -112,12 +117,16 @@ func (s *StorageNode) ChecksumCheck() {
 
 }
 
-func (s *StorageNode) ConcurrentListen() {
-	go s.ListenForClients()
-	go s.ListenForOtherNodes()
+// ConcurrentListen serves clients and other nodes until ctx is cancelled, which also ends the transfers in flight.
+func (s *StorageNode) ConcurrentListen(ctx context.Context) {
+	go s.ListenForClients(ctx)
+	go s.ListenForOtherNodes(ctx)
+	if s.networkInterfaces.NodeInterface.GrpcPort != "" {
+		go s.ServeGRPC(ctx)
+	}
 }
 
-func (s *StorageNode) ListenForOtherNodes() {
+func (s *StorageNode) ListenForOtherNodes(ctx context.Context) {
 
 	host := s.networkInterfaces.NodeInterface.Host
 	port := "23100"
@@ -129,6 +138,7 @@ func (s *StorageNode) ListenForOtherNodes() {
 		s.logger.Error("There was an error listening on the port.")
 		return
 	}
+	closeOnDone(ctx, ln)
 
 	s.logger.Info("Listening for other nodes on port " + port)
 	s.logger.Info("Listening for other nodes on host " + host)
@@ -139,13 +149,14 @@ func (s *StorageNode) ListenForOtherNodes() {
 			fmt.Println("There was an error accepting the connection.")
 			return
 		}
-		msgHandler := messagesStorage.NewMessageHandler(conn)
-		go s.handleOtherNode(msgHandler) //TODO: Race condition here
+		msgHandler := messagesStorage.NewMessageHandler(watch(ctx, conn))
+		msgHandler.SetTimeouts(PEER_TIMEOUT, PEER_TIMEOUT)
+		go s.handleOtherNode(ctx, msgHandler) //TODO: Race condition here
 	}
 
 }
 
-func (s *StorageNode) ListenForClients() {
+func (s *StorageNode) ListenForClients(ctx context.Context) {
 
 	//listen on the port specified in the config file
 
@@ -162,43 +173,62 @@ func (s *StorageNode) ListenForClients() {
 		panic(err)
 
 	}
+	closeOnDone(ctx, ln)
 
 	s.logger.Info("Listening for clients on port " + port)
 	s.logger.Info("Listening for clients on host " + host)
 	for {
 		conn, err := ln.Accept()
+		if ctx.Err() != nil {
+			return
+		}
 		if err != nil {
 			fmt.Println("There was an error accepting the connection.")
 			continue
 		}
-		msgHandler := messagesClient.NewMessageHandler(conn)
-		go s.handleClient(msgHandler)
+		msgHandler := messagesClient.NewMessageHandler(watch(ctx, conn))
+		msgHandler.SetTimeouts(PEER_TIMEOUT, PEER_TIMEOUT)
+		go s.handleClient(ctx, msgHandler)
 	}
 
 }
-func (s *StorageNode) DialOtherNode(host string) (proto *proto3Storage.ProtoHandler, err error) {
+
+// DialOtherNode connects to another node. The connection is closed when ctx is cancelled.
+func (s *StorageNode) DialOtherNode(ctx context.Context, host string) (proto *proto3Storage.ProtoHandler, err error) {
+	return s.dialOtherNode(ctx, host, s.dir)
+}
+
+// dialOtherNode connects to another node, writing whatever it sends into dir.
+func (s *StorageNode) dialOtherNode(ctx context.Context, host string, dir string) (proto *proto3Storage.ProtoHandler, err error) {
 	port := "23100"
 
-	conn, err := net.Dial("tcp", host+":"+port)
+	conn, err := dial(ctx, host+":"+port)
 
 	if err != nil {
 		s.logger.Sugar().Errorf("There was an error connecting to the Host: %s", err)
 		return
 	}
 	msgHandler := messagesStorage.NewMessageHandler(conn)
+	msgHandler.SetTimeouts(PEER_TIMEOUT, PEER_TIMEOUT)
 	//s.SetMsgHandlerStorage(msgHandler)
-	proto = proto3Storage.NewProtoHandler(msgHandler, s.logger, s.dir)
+	proto = proto3Storage.NewProtoHandler(msgHandler, s.logger, dir)
 	//s.SetProtoStorage(proto)
 	return proto, nil
 }
 
-func (s *StorageNode) handleClient(msgHandler *messagesClient.MessageHandler) {
+func (s *StorageNode) handleClient(ctx context.Context, msgHandler *messagesClient.MessageHandler) {
 
 	defer msgHandler.Close()
 
 	proto := proto3Client.NewProtoHandler(msgHandler, s.logger, s.dir)
+	proto.SetPipeline(s.newPipeline(ctx))
+	defer proto.AbortTransfer()
 	for {
-		wrapper, _ := proto.MsgHandler().ClientRequestReceive()
+		wrapper, err := proto.MsgHandler().ClientRequestReceive()
+		if err != nil {
+			//the client closed the connection, or sent something that was answered with an error frame
+			return
+		}
 
 		switch wrapper.Request.(type) {
 		default:
@@ -209,16 +239,17 @@ func (s *StorageNode) handleClient(msgHandler *messagesClient.MessageHandler) {
 			}
 			switch res.Operation {
 			case "PUT":
+			case "CHUNK":
 			case "DATA":
 
-				//TODO: handle the race condition before
-				if res.Success {
+				//a streamed PUT was already replicated through the pipeline
+				if res.Success && res.DataType != "STREAM" {
 
 					nodes := res.Result.(*file.FileHandler).Location()
 
 					for _, node := range nodes {
 						//stream the data to the nodes
-						protoStorage, err := s.DialOtherNode(node) //TODO: Race condition here
+						protoStorage, err := s.DialOtherNode(ctx, node) //TODO: Race condition here
 						if err != nil {
 							s.logger.Sugar().Errorf("There was an error connecting to the Host: %s", err)
 							continue
@@ -237,10 +268,14 @@ func (s *StorageNode) handleClient(msgHandler *messagesClient.MessageHandler) {
 			case "GET":
 				return
 
+			case "DELETE":
+				return
+
 			}
 
 		case nil:
 			log.Println("Received an empty message, terminating client")
+			proto.MsgHandler().ErrorSend(wire.ERROR_BAD_REQUEST, "empty or unknown request")
 			return
 
 		}
@@ -250,22 +285,32 @@ func (s *StorageNode) handleClient(msgHandler *messagesClient.MessageHandler) {
 	//TODO
 }
 
-func (s *StorageNode) handleOtherNode(handler *messagesStorage.MessageHandler) {
+func (s *StorageNode) handleOtherNode(ctx context.Context, handler *messagesStorage.MessageHandler) {
 
 	s.logger.Info("New node connected")
 	defer handler.Close()
 	proto := proto3Storage.NewProtoHandler(handler, s.logger, s.dir)
+	proto.SetPipeline(s.newPipeline(ctx))
+	defer proto.AbortTransfer()
 
 	for {
-		wrapper, _ := proto.MsgHandler().ServerResponseReceive()
+		wrapper, err := proto.MsgHandler().ServerResponseReceive()
+		if err != nil {
+			return
+		}
 
 		switch wrapper.StorageNodeMessage.(type) {
 		default:
 			proto.HandleStorageNodeRequest(wrapper)
 
+			//a streamed transfer keeps the connection open until its last chunk
+			if proto.TransferPending() {
+				continue
+			}
 			return
 
 		case nil:
+			proto.MsgHandler().ErrorSend(wire.ERROR_BAD_REQUEST, "empty or unknown request")
 			return
 		}
 	}
@@ -288,19 +333,23 @@ func (s *StorageNode) GetNodeStatus() messages.StorageNodeMessage_NodeStatus {
 }
 
 func (s *StorageNode) GetInterval() int32 {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	return s.interval
 }
 
-func (s *StorageNode) ReConnect() (err error) {
+func (s *StorageNode) ReConnect(ctx context.Context) (err error) {
 	server := s.networkInterfaces.ControllerInterface.Port
 
-	conn, err := net.Dial("tcp", server)
+	conn, err := dial(ctx, server)
 
 	if err != nil {
 		fmt.Println("There was an error connecting to the Host.")
 		return
 	}
 	msgHandler := messages.NewMessageHandler(conn)
+	msgHandler.SetTimeouts(0, SESSION_WRITE_TIMEOUT)
 	s.MsgHandler(msgHandler)
 	proto := proto3.NewProtoHandler(msgHandler)
 	s.Proto(proto)
@@ -309,17 +358,35 @@ func (s *StorageNode) ReConnect() (err error) {
 	return
 }
 
-func (s *StorageNode) Dial() (*proto3.ProtoHandler, net.Conn, error) {
-	host := s.networkInterfaces.ControllerInterface.Host
-	server := s.networkInterfaces.ControllerInterface.Port
+// Dial connects to the Controller. Only the active controller of a highly available group accepts the node,
+// so every controller is tried in turn, from the one it last connected to. The connection is closed when ctx
+// is cancelled.
+func (s *StorageNode) Dial(ctx context.Context) (*proto3.ProtoHandler, net.Conn, error) {
+	controllers := s.networkInterfaces.ControllerInterface.Addresses()
 
-	conn, err := net.Dial("tcp", host+":"+server)
+	s.mutex.Lock()
+	first := s.controller
+	s.mutex.Unlock()
+
+	var conn net.Conn
+	var err error
+	for i := range controllers {
+		next := (first + i) % len(controllers)
+		conn, err = dial(ctx, controllers[next])
+		if err == nil {
+			s.mutex.Lock()
+			s.controller = next
+			s.mutex.Unlock()
+			break
+		}
+	}
 
 	if err != nil {
 		fmt.Println("There was an error connecting to the Host.")
 		return nil, nil, err
 	}
 	msgHandler := messages.NewMessageHandler(conn)
+	msgHandler.SetTimeouts(0, SESSION_WRITE_TIMEOUT)
 	//s.MsgHandler(msgHandler)
 	proto := proto3.NewProtoHandler(msgHandler)
 	//s.Proto(proto)
@@ -354,19 +421,23 @@ func (s *StorageNode) HandleIntroduction(proto *proto3.ProtoHandler) (err error)
 	clientCommsPort := s.networkInterfaces.NodeInterface.ClientCommsPort
 	address := s.networkInterfaces.NodeInterface.Host
 
-	err = proto.HandleIntroRequest(s.nodeID, clientCommsPort, address)
+	err = proto.HandleIntroRequest(s.nodeID, clientCommsPort, address, s.networkInterfaces.NodeInterface.Rack)
 	return
 }
 
 func (s *StorageNode) HandleOtherNodeConnection(protoStorage *proto3Storage.ProtoHandler) {
 	defer protoStorage.MsgHandler().Close()
+	defer protoStorage.AbortTransfer()
 
 	for {
 		wrapper, _ := protoStorage.MsgHandler().ServerResponseReceive()
 
 		switch wrapper.StorageNodeMessage.(type) {
 		default:
-			protoStorage.HandleStorageNodeResponse(wrapper)
+			_, err := protoStorage.HandleStorageNodeResponse(wrapper)
+			if err == nil && protoStorage.TransferPending() {
+				continue
+			}
 			return
 		case nil:
 			return
@@ -375,11 +446,18 @@ func (s *StorageNode) HandleOtherNodeConnection(protoStorage *proto3Storage.Prot
 	}
 
 }
-func (s *StorageNode) HandleConnection(proto *proto3.ProtoHandler) (err error) {
+
+// HandleConnection handles what the Controller sends on the session until the connection breaks. Commands
+// run in their own goroutines and are acknowledged when done, so heartbeats keep flowing meanwhile. They
+// are cancelled through ctx when the session ends.
+func (s *StorageNode) HandleConnection(ctx context.Context, proto *proto3.ProtoHandler) (err error) {
 	defer proto.MsgHandler().Close()
 
 	for {
-		wrapper, _ := proto.MsgHandler().ServerResponseReceive()
+		wrapper, err := proto.MsgHandler().ServerResponseReceive()
+		if err != nil {
+			return err
+		}
 
 		switch wrapper.ControllerMessage.(type) {
 		default:
@@ -395,6 +473,11 @@ func (s *StorageNode) HandleConnection(proto *proto3.ProtoHandler) (err error) {
 				s.mutex.Lock()
 				s.nodeStatus = messages.StorageNodeMessage_NEW
 				s.mutex.Unlock()
+				//the Controller forgot the node, it starts over from a full block report
+				s.reports.requestFull()
+
+			case "BlockReportRequest":
+				s.reports.requestFull()
 
 			case "FileCorruption":
 				//get the file from the node that has it
@@ -403,7 +486,7 @@ func (s *StorageNode) HandleConnection(proto *proto3.ProtoHandler) (err error) {
 				fileName := res.(*proto3.FileCorruption).FileName
 				nodes := res.(*proto3.FileCorruption).StorageNodes
 				for _, node := range nodes {
-					protoStorage, err := s.DialOtherNode(node.Host())
+					protoStorage, err := s.DialOtherNode(ctx, node.Host())
 					if err != nil {
 						s.logger.Sugar().Errorf("There was an error connecting to the Host: %s", err)
 					} else {
@@ -418,42 +501,41 @@ func (s *StorageNode) HandleConnection(proto *proto3.ProtoHandler) (err error) {
 
 			case "ReplicationRequest":
 
-				statusCode := res.(*proto3.ReplicationRequest).StatusCode
-				if statusCode == messages.ControllerMessage_OK {
-
+				req := res.(*proto3.ReplicationRequest)
+				if req.StatusCode == messages.ControllerMessage_OK {
 					s.logger.Info("Received replication request for file")
+					go s.runCommand(proto, req.CommandId, func() []string {
+						return s.Replicate(ctx, req.ReplicationInfo)
+					})
+				}
 
-					for _, frag := range res.(*proto3.ReplicationRequest).ReplicationInfo {
-						s.logger.Sugar().Infof("Fragment %s is corrupted, getting it from another node", frag.FileName)
-						nodes := frag.StorageNodes
-						for _, node := range nodes {
-							//stream the data to the nodes
-							protoStorage, errf := s.DialOtherNode(node.Host())
-							if errf != nil {
-								s.logger.Sugar().Errorf("There was an error connecting to the Host: %s", err)
-								return
-							} else {
-								s.logger.Info("Connected to node")
-								fileHandler := file.FileHandler{}
-								fileHandler.SetDir(s.dir)
-								fileHandler.SetFileName(frag.FileName)
-								fileHandler.ReadFile()
-								fileHandler.FindAndSetCheckSum()
-
-								s.StreamData(protoStorage, &fileHandler, node.Host())
-							}
-						}
-
-					}
+			case "ReconstructionRequest":
 
+				req := res.(*proto3.ReconstructionRequest)
+				if req.StatusCode == messages.ControllerMessage_OK {
+					go s.runCommand(proto, req.CommandId, func() []string {
+						return s.ReconstructShards(ctx, req.Shards)
+					})
 				}
 
-			}
+			case "DeleteRequest":
 
-			return
+				req := res.(*proto3.DeleteRequest)
+				go s.runCommand(proto, req.CommandId, func() []string {
+					return s.DeleteFragments(req.Fragments)
+				})
+
+			case "VerifyRequest":
+
+				req := res.(*proto3.VerifyRequest)
+				go s.runCommand(proto, req.CommandId, func() []string {
+					return s.VerifyFragments(req.Fragments)
+				})
+
+			}
 
 		case nil:
-			return
+			s.logger.Warn("Received an unknown message from the Controller")
 		}
 
 	}
@@ -471,30 +553,34 @@ type HeartBeat struct {
 func (s *StorageNode) HandleHeartbeats(proto *proto3.ProtoHandler) (err error) {
 
 	space := s.CheckFreeSpace()
-	files := s.GetAllFiles()
-	newFiles := s.GetNewFiles()
+	report := s.reports.next(s.GetAllFiles())
 
-	proto.SendHeartbeatRequest(s.nodeID, space, s.fileInfo.NumRequestsProcessed, newFiles, files)
+	proto.SendHeartbeatRequest(s.nodeID, space, s.fileInfo.NumRequestsProcessed, report)
 
 	return
 }
 
-func (s *StorageNode) StreamData(proto *proto3Storage.ProtoHandler, handler *file.FileHandler, node string) {
-
-	proto.HandlePUTCopyRequest(s.nodeID, handler, node)
+func (s *StorageNode) StreamData(proto *proto3Storage.ProtoHandler, handler *file.FileHandler, node string) (err error) {
 
+	defer proto.MsgHandler().Close()
+	err = proto.HandlePUTCopyRequest(s.nodeID, handler, node)
+	if err != nil {
+		s.logger.Sugar().Errorf("There was an error streaming %s to %s: %s", handler.FileName(), node, err)
+	}
+	return
 }
 
 func (s *StorageNode) HandleCorruptedFile(f string) {
 
 	s.logger.Info("Reporting corrupted file to Controller")
-	proto, _, err := s.Dial()
-	if err != nil {
-		s.logger.Error("Error dialing: ", zap.Error(err))
+	proto := s.session()
+	if proto == nil {
+		//the next checksum check reports it again
+		s.logger.Warn("No session with the Controller, cannot report corrupted file", zap.String("file", f))
 		return
 	}
 
+	//the Controller answers on the session
 	proto.HandleCorruptedFile(s.nodeID, f)
-	go s.HandleConnection(proto)
 
 }
@@ -1,9 +1,11 @@
 package storage_node
 
 import (
+	"context"
 	"go.uber.org/zap"
 	"net"
 	"src/messages/controller_storage"
+	"strconv"
 	"testing"
 )
 
@@ -51,3 +53,53 @@ func TestStorageNode_ChecksumCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageNode_DialFailover(t *testing.T) {
+
+	//the first controller is down, the second one is active
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	downPort := portOf(down)
+	down.Close()
+
+	active, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer active.Close()
+	go func() {
+		for {
+			conn, err := active.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	node := NewStorageNode("node1", NetworkInterfaces{ControllerInterface: ControllerInterface{
+		Host:     "127.0.0.1",
+		Port:     downPort,
+		Standbys: []ControllerAddress{{Host: "127.0.0.1", Port: portOf(active)}},
+	}}, zap.NewNop())
+
+	for i := 0; i < 2; i++ {
+		_, conn, err := node.Dial(context.Background())
+		if err != nil {
+			t.Fatalf("Dial() error = %v", err)
+		}
+		if conn.RemoteAddr().String() != active.Addr().String() {
+			t.Errorf("Dial() connected to %s, want %s", conn.RemoteAddr(), active.Addr())
+		}
+		conn.Close()
+		if node.controller != 1 {
+			t.Errorf("controller = %d after Dial(), want 1", node.controller)
+		}
+	}
+}
+
+func portOf(ln net.Listener) string {
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+}